@@ -0,0 +1,226 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+const configName = "tg.yaml"
+
+const (
+	targetServer  = "server"
+	targetClient  = "client"
+	targetSwagger = "swagger"
+	targetAzure   = "azure"
+)
+
+// projectConfig describes generation targets of project, paths are relative to the config file.
+type projectConfig struct {
+	Services   string         `yaml:"services"`
+	Ifaces     []string       `yaml:"ifaces"`
+	APIVersion string         `yaml:"apiVersion"`
+	Strict     bool           `yaml:"strict"`
+	Targets    []targetConfig `yaml:"targets"`
+}
+
+type targetConfig struct {
+	Type       string   `yaml:"type"`
+	Ifaces     []string `yaml:"ifaces"`
+	Out        string   `yaml:"out"`
+	APIVersion string   `yaml:"apiVersion"`
+
+	Backend string `yaml:"backend"`
+
+	Lang          []string `yaml:"lang"`
+	OutPackage    string   `yaml:"outPackage"`
+	Zod           bool     `yaml:"zod"`
+	KotlinPackage string   `yaml:"kotlinPackage"`
+
+	Redoc string `yaml:"redoc"`
+
+	AppName      string `yaml:"appName"`
+	RoutePrefix  string `yaml:"routePrefix"`
+	LogLevel     string `yaml:"logLevel"`
+	EnableHealth bool   `yaml:"enableHealth"`
+}
+
+// findConfig looks for config file in current directory and its parents, like go does for go.mod.
+func findConfig() (configPath string, err error) {
+
+	var dir string
+	if dir, err = os.Getwd(); err != nil {
+		return
+	}
+	for {
+		configPath = filepath.Join(dir, configName)
+		if _, err = os.Stat(configPath); err == nil {
+			return
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%s not found", configName)
+		}
+		dir = parent
+	}
+}
+
+func loadConfig(configPath string) (cfg projectConfig, err error) {
+
+	var data []byte
+	if data, err = os.ReadFile(configPath); err != nil {
+		return
+	}
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", configPath, err)
+	}
+	if cfg.Services == "" {
+		return cfg, fmt.Errorf("%s: services are not set", configPath)
+	}
+	if len(cfg.Targets) == 0 {
+		return cfg, fmt.Errorf("%s: targets are not set", configPath)
+	}
+	for i, target := range cfg.Targets {
+		switch target.Type {
+		case targetServer, targetSwagger, targetAzure:
+		case targetClient:
+			if len(target.Lang) == 0 && target.OutPackage == "" {
+				return cfg, fmt.Errorf("%s: target #%d: lang is not set", configPath, i+1)
+			}
+		default:
+			return cfg, fmt.Errorf("%s: target #%d: unknown type '%s'", configPath, i+1, target.Type)
+		}
+	}
+	return
+}
+
+func cmdGenerate(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	configPath := c.String("config")
+	if configPath == "" {
+		if configPath, err = findConfig(); err != nil {
+			return
+		}
+	}
+	var cfg projectConfig
+	if cfg, err = loadConfig(configPath); err != nil {
+		return
+	}
+	if err = os.Chdir(filepath.Dir(configPath)); err != nil {
+		return
+	}
+	if cfg.Strict || c.Bool("strict") {
+		if err = lintServices(cfg.Services); err != nil {
+			return
+		}
+	}
+	var tr generator.Transport
+	if tr, err = generator.NewTransport(log, Version, cfg.Services, cfg.Ifaces...); err != nil {
+		return
+	}
+	tr.SetCheck(c.Bool("check"))
+	defer func() {
+		if err == nil {
+			err = checkGenerated(tr)
+		}
+	}()
+	for i, target := range cfg.Targets {
+		if target.APIVersion == "" {
+			target.APIVersion = cfg.APIVersion
+		}
+		log.WithField("target", target.Type).WithField("out", target.Out).Info("generate")
+		if err = generateTarget(&tr, cfg.Services, target, c.Bool("check")); err != nil {
+			return fmt.Errorf("target #%d (%s): %w", i+1, target.Type, err)
+		}
+	}
+	return
+}
+
+func generateTarget(tr *generator.Transport, svcDir string, target targetConfig, check bool) (err error) {
+
+	var sub generator.Transport
+	if sub, err = tr.Select(target.Ifaces...); err != nil {
+		return
+	}
+	if err = sub.SetAPIVersion(target.APIVersion); err != nil {
+		return
+	}
+	switch target.Type {
+	case targetServer:
+		if err = sub.SetBackend(target.Backend); err != nil {
+			return
+		}
+		outPath := target.Out
+		if outPath == "" {
+			outPath = path.Join(path.Dir(generator.ServicesRoot(svcDir)), "transport")
+		}
+		return sub.RenderServer(outPath)
+	case targetClient:
+		return generateClient(&sub, target)
+	case targetSwagger:
+		outPath := target.Out
+		if outPath == "" {
+			outPath = path.Join(generator.ServicesRoot(svcDir), "swagger.yaml")
+		}
+		if err = sub.RenderSwagger(outPath); err != nil || target.Redoc == "" || check {
+			return
+		}
+		return renderRedoc(outPath, target.Redoc)
+	case targetAzure:
+		outPath := target.Out
+		if outPath == "" {
+			outPath = path.Join(generator.ServicesRoot(svcDir), "azure-fApp")
+		}
+		return sub.RenderAzure(target.AppName, target.RoutePrefix, outPath, target.LogLevel, target.EnableHealth)
+	}
+	return errors.New("unknown target type")
+}
+
+func generateClient(tr *generator.Transport, target targetConfig) (err error) {
+
+	outPath := target.Out
+	if outPath == "" {
+		outPath = "./pkg/clients"
+	}
+	tr.SetZod(target.Zod)
+	tr.SetKotlinPackage(target.KotlinPackage)
+	if target.OutPackage != "" {
+		if err = tr.RenderPackageNPM(outPath, target.OutPackage); err != nil {
+			return
+		}
+	}
+	for _, lang := range target.Lang {
+		switch lang {
+		case "go":
+			err = tr.RenderClient(outPath)
+		case "js":
+			err = tr.RenderClientJS(outPath)
+		case "ts":
+			err = tr.RenderClientTS(outPath)
+		case "py":
+			err = tr.RenderClientPy(outPath)
+		case "kotlin":
+			err = tr.RenderClientKotlin(outPath)
+		case "swift":
+			err = tr.RenderClientSwift(outPath)
+		default:
+			err = fmt.Errorf("unknown client language '%s'", lang)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
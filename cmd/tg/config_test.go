@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+func TestLoadConfig(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name: "valid",
+			config: `services: ./pkg/...
+apiVersion: v2
+targets:
+  - type: server
+    backend: fiber
+  - type: client
+    lang: [go, ts]
+  - type: client
+    outPackage: "@example/api"
+  - type: swagger
+  - type: azure
+`,
+		},
+		{name: "invalid", config: "services: [", err: "yaml:"},
+		{name: "no services", config: "targets:\n  - type: server\n", err: "services are not set"},
+		{name: "no targets", config: "services: ./pkg/...\n", err: "targets are not set"},
+		{name: "no lang", config: "services: ./pkg/...\ntargets:\n  - type: server\n  - type: client\n", err: "target #2: lang is not set"},
+		{name: "unknown type", config: "services: ./pkg/...\ntargets:\n  - type: lambda\n", err: "target #1: unknown type 'lambda'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), configName)
+			if err := os.WriteFile(configPath, []byte(test.config), 0600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := loadConfig(configPath)
+			if test.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if cfg.Services != "./pkg/..." || cfg.APIVersion != "v2" || len(cfg.Targets) != 5 || cfg.Targets[0].Backend != "fiber" {
+					t.Errorf("unexpected config %+v", cfg)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), configPath+": "+test.err) {
+				t.Errorf("error %v, want %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "internal", "service")
+	if err = os.MkdirAll(nested, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(root, configName), []byte("services: ./internal/...\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(nested)
+	configPath, err := findConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, configName); configPath != want {
+		t.Errorf("config %s, want %s", configPath, want)
+	}
+}
+
+func TestGenerateTarget(t *testing.T) {
+
+	svcDir := "../../pkg/generator/testdata/files"
+	tr, err := generator.NewTransport(log, Version, svcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "clients")
+	if err = generateTarget(&tr, svcDir, targetConfig{Type: targetClient, Lang: []string{"go", "ts"}, Out: outDir}, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, fileName := range []string{"files-http-client.go", "files.ts"} {
+		if _, err = os.Stat(filepath.Join(outDir, fileName)); err != nil {
+			t.Error(err)
+		}
+	}
+	err = generateTarget(&tr, svcDir, targetConfig{Type: targetClient, Lang: []string{"rust"}, Out: outDir}, false)
+	if want := "unknown client language 'rust'"; err == nil || err.Error() != want {
+		t.Errorf("error %v, want %q", err, want)
+	}
+}
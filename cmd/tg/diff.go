@@ -0,0 +1,129 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+func cmdDiff(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	svcDir, ifaces := c.String("services"), c.StringSlice("ifaces")
+	if filepath.IsAbs(svcDir) {
+		return errors.New("services path must be relative to current directory")
+	}
+	var baseDir string
+	if baseDir, err = baseTree(c.String("base")); err != nil {
+		return
+	}
+	if baseDir != c.String("base") {
+		defer os.RemoveAll(baseDir)
+	}
+	var base, current generator.Contract
+	if base, err = contractAt(baseDir, svcDir, ifaces...); err != nil {
+		return fmt.Errorf("base: %w", err)
+	}
+	if current, err = contractAt(".", svcDir, ifaces...); err != nil {
+		return
+	}
+	var breaking int
+	for _, change := range generator.CompareContracts(base, current) {
+		if change.Breaking {
+			breaking++
+		}
+		fmt.Println(change)
+	}
+	if breaking != 0 {
+		return fmt.Errorf("found %d breaking changes", breaking)
+	}
+	return
+}
+
+// contractAt builds contract of services in project directory, types of services are resolved in it.
+func contractAt(projectDir, svcDir string, ifaces ...string) (contract generator.Contract, err error) {
+
+	var wd string
+	if wd, err = os.Getwd(); err != nil {
+		return
+	}
+	if err = os.Chdir(projectDir); err != nil {
+		return
+	}
+	defer func() { _ = os.Chdir(wd) }()
+	var tr generator.Transport
+	if tr, err = generator.NewTransport(log, Version, svcDir, ifaces...); err != nil {
+		return
+	}
+	return tr.Contract(), nil
+}
+
+// baseTree returns project directory of base version: directory itself or current directory of git revision,
+// extracted to temporary directory.
+func baseTree(base string) (dir string, err error) {
+
+	if info, statErr := os.Stat(base); statErr == nil && info.IsDir() {
+		return base, nil
+	}
+	var archive []byte
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "archive", "--format=tar", base) // nolint:gosec
+	cmd.Stderr = &stderr
+	if archive, err = cmd.Output(); err != nil {
+		return "", fmt.Errorf("git archive %s: %s", base, strings.TrimSpace(stderr.String()))
+	}
+	if dir, err = os.MkdirTemp("", "tg-base-"); err != nil {
+		return
+	}
+	if err = untar(bytes.NewReader(archive), dir); err != nil {
+		_ = os.RemoveAll(dir)
+	}
+	return
+}
+
+func untar(r io.Reader, dir string) (err error) {
+
+	reader := tar.NewReader(r)
+	for {
+		var header *tar.Header
+		if header, err = reader.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return
+		}
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0777)
+		case tar.TypeReg:
+			var data []byte
+			if data, err = io.ReadAll(reader); err != nil {
+				return
+			}
+			if err = os.MkdirAll(filepath.Dir(target), 0777); err == nil {
+				err = os.WriteFile(target, data, 0600)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
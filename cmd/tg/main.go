@@ -4,6 +4,8 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path"
@@ -93,6 +95,166 @@ func main() {
 			UsageText:   "tg azure",
 			Description: "generate Azure manifests layer by interfaces",
 		},
+		{
+			Name:   "lambda",
+			Usage:  "generate AWS SAM template by interfaces in 'service' package",
+			Action: cmdLambda,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringFlag{
+					Name:  "appName",
+					Value: "service",
+					Usage: "application name",
+				},
+				&cli.StringFlag{
+					Name:  "codeUri",
+					Value: ".",
+					Usage: "path to main package of lambda binary",
+				},
+				&cli.IntFlag{
+					Name:  "memorySize",
+					Value: 128,
+					Usage: "lambda memory size in MB",
+				},
+				&cli.IntFlag{
+					Name:  "timeout",
+					Value: 30,
+					Usage: "lambda timeout in seconds",
+				},
+				&cli.StringFlag{
+					Name:  "outPath",
+					Usage: "path to output folder",
+				},
+			},
+			UsageText:   "tg lambda",
+			Description: "generate AWS Lambda SAM template with API Gateway routes by interfaces",
+		},
+		{
+			Name:   "k8s",
+			Usage:  "generate Kubernetes manifests by interfaces in 'service' package",
+			Action: cmdK8s,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringFlag{
+					Name:  "appName",
+					Value: "service",
+					Usage: "application name",
+				},
+				&cli.StringFlag{
+					Name:  "image",
+					Usage: "container image",
+				},
+				&cli.StringFlag{
+					Name:  "namespace",
+					Usage: "kubernetes namespace",
+				},
+				&cli.StringFlag{
+					Name:  "host",
+					Usage: "ingress host",
+				},
+				&cli.IntFlag{
+					Name:  "replicas",
+					Value: 1,
+					Usage: "deployment replicas",
+				},
+				&cli.IntFlag{
+					Name:  "port",
+					Value: 9000,
+					Usage: "service port",
+				},
+				&cli.IntFlag{
+					Name:  "healthPort",
+					Value: 9091,
+					Usage: "ServeHealth port",
+				},
+				&cli.IntFlag{
+					Name:  "metricsPort",
+					Value: 9090,
+					Usage: "ServeMetrics port",
+				},
+				&cli.StringFlag{
+					Name:  "metricsPath",
+					Value: "/metrics",
+					Usage: "ServeMetrics path",
+				},
+				&cli.StringFlag{
+					Name:  "outPath",
+					Usage: "path to output folder",
+				},
+			},
+			UsageText:   "tg k8s --image registry/service:latest",
+			Description: "generate Kubernetes Deployment, Service and Ingress manifests by interfaces",
+		},
+		{
+			Name:   "lint",
+			Usage:  "check annotations of interfaces in 'service' package",
+			Action: cmdLint,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+			},
+			UsageText:   "tg lint --services ./pkg/someService/service",
+			Description: "report unknown, misplaced and malformed annotations with their positions",
+		},
+		{
+			Name:   "generate",
+			Usage:  "generate all targets of project config (tg.yaml)",
+			Action: cmdGenerate,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "config",
+					Usage: "path to project config (tg.yaml in current or parent directory by default)",
+				},
+				&cli.BoolFlag{
+					Name:  "strict",
+					Value: false,
+					Usage: "fail on annotation errors (see 'tg lint')",
+				},
+				&cli.BoolFlag{
+					Name:  "check",
+					Value: false,
+					Usage: "do not write files, fail with diff when generated files are stale",
+				},
+			},
+
+			UsageText:   "tg generate",
+			Description: "generate servers, clients and documentation by targets of project config",
+		},
+		{
+			Name:   "diff",
+			Usage:  "report breaking changes of services API against base version",
+			Action: cmdDiff,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringFlag{
+					Name:     "base",
+					Required: true,
+					Usage:    "base version: git revision or directory of project",
+				},
+				&cli.StringSliceFlag{
+					Name:  "ifaces",
+					Usage: "included interfaces",
+				},
+			},
+
+			UsageText:   "tg diff --services ./pkg/someService/service --base v1.2.0",
+			Description: "compare services API with base version and fail on breaking changes",
+		},
 		{
 			Name:   "transport",
 			Usage:  "generate services transport layer by interfaces in 'service' package",
@@ -115,6 +277,21 @@ func main() {
 					Name:  "outSwagger",
 					Usage: "path to output swagger file",
 				},
+				&cli.StringFlag{
+					Name:  "backend",
+					Value: "fiber",
+					Usage: "server backend (fiber, nethttp)",
+				},
+				&cli.BoolFlag{
+					Name:  "strict",
+					Value: false,
+					Usage: "fail on annotation errors (see 'tg lint')",
+				},
+				&cli.BoolFlag{
+					Name:  "check",
+					Value: false,
+					Usage: "do not write files, fail with diff when generated files are stale",
+				},
 			},
 
 			UsageText:   "tg transport",
@@ -158,6 +335,44 @@ func main() {
 					Value: false,
 					Usage: "enable ts client with package manifest",
 				},
+				&cli.BoolFlag{
+					Name:  "py",
+					Value: false,
+					Usage: "enable python client",
+				},
+				&cli.BoolFlag{
+					Name:  "kotlin",
+					Value: false,
+					Usage: "enable kotlin client",
+				},
+				&cli.StringFlag{
+					Name:  "kotlinPackage",
+					Usage: "package of kotlin client (built from module path by default)",
+				},
+				&cli.BoolFlag{
+					Name:  "swift",
+					Value: false,
+					Usage: "enable swift client",
+				},
+				&cli.BoolFlag{
+					Name:  "zod",
+					Value: false,
+					Usage: "generate zod schemas for ts client",
+				},
+				&cli.StringFlag{
+					Name:  "apiVersion",
+					Usage: "API version for generated clients (latest by default)",
+				},
+				&cli.BoolFlag{
+					Name:  "strict",
+					Value: false,
+					Usage: "fail on annotation errors (see 'tg lint')",
+				},
+				&cli.BoolFlag{
+					Name:  "check",
+					Value: false,
+					Usage: "do not write files, fail with diff when generated files are stale",
+				},
 			},
 
 			UsageText:   "tg client --services ./pkg/someService/service",
@@ -185,6 +400,20 @@ func main() {
 					Name:  "redoc",
 					Usage: "path to output redoc bundle",
 				},
+				&cli.StringFlag{
+					Name:  "apiVersion",
+					Usage: "API version for generated documentation (latest by default)",
+				},
+				&cli.BoolFlag{
+					Name:  "strict",
+					Value: false,
+					Usage: "fail on annotation errors (see 'tg lint')",
+				},
+				&cli.BoolFlag{
+					Name:  "check",
+					Value: false,
+					Usage: "do not write files, fail with diff when generated files are stale",
+				},
 			},
 
 			UsageText:   "tg swagger --include firstIface --exclude secondIface",
@@ -219,6 +448,41 @@ func cmdInit(c *cli.Context) (err error) {
 	return skeleton.GenerateSkeleton(log, module, project, service, "./"+c.Args().First())
 }
 
+func cmdLint(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	return lintServices(c.String("services"))
+}
+
+func lintServices(svcDir string) (err error) {
+
+	var issues []generator.LintIssue
+	if issues, err = generator.Lint(svcDir); err != nil {
+		return
+	}
+	for _, issue := range issues {
+		fmt.Fprintln(os.Stderr, issue)
+	}
+	if len(issues) != 0 {
+		return fmt.Errorf("found %d annotation issues", len(issues))
+	}
+	return
+}
+
+// checkGenerated fails with diff, when generated files differ from files on disk in check mode.
+func checkGenerated(tr generator.Transport) (err error) {
+
+	if diff := tr.Diff(); diff != "" {
+		fmt.Fprint(os.Stderr, diff)
+		return errors.New("generated files are stale")
+	}
+	return
+}
+
 func cmdClient(c *cli.Context) (err error) {
 
 	defer func() {
@@ -226,10 +490,26 @@ func cmdClient(c *cli.Context) (err error) {
 			log.Info("done")
 		}
 	}()
+	if c.Bool("strict") {
+		if err = lintServices(c.String("services")); err != nil {
+			return
+		}
+	}
 	var tr generator.Transport
 	if tr, err = generator.NewTransport(log, Version, c.String("services"), c.StringSlice("ifaces")...); err != nil {
 		return
 	}
+	tr.SetCheck(c.Bool("check"))
+	defer func() {
+		if err == nil {
+			err = checkGenerated(tr)
+		}
+	}()
+	if err = tr.SetAPIVersion(c.String("apiVersion")); err != nil {
+		return
+	}
+	tr.SetZod(c.Bool("zod"))
+	tr.SetKotlinPackage(c.String("kotlinPackage"))
 	if c.Bool("go") {
 		if err = tr.RenderClient(c.String("outPath")); err != nil {
 			return
@@ -251,6 +531,21 @@ func cmdClient(c *cli.Context) (err error) {
 			return
 		}
 	}
+	if c.Bool("py") {
+		if err = tr.RenderClientPy(c.String("outPath")); err != nil {
+			return
+		}
+	}
+	if c.Bool("kotlin") {
+		if err = tr.RenderClientKotlin(c.String("outPath")); err != nil {
+			return
+		}
+	}
+	if c.Bool("swift") {
+		if err = tr.RenderClientSwift(c.String("outPath")); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -261,11 +556,25 @@ func cmdTransport(c *cli.Context) (err error) {
 			log.Info("done")
 		}
 	}()
+	if c.Bool("strict") {
+		if err = lintServices(c.String("services")); err != nil {
+			return
+		}
+	}
 	var tr generator.Transport
 	if tr, err = generator.NewTransport(log, Version, c.String("services"), c.StringSlice("ifaces")...); err != nil {
 		return
 	}
-	outPath, _ := path.Split(c.String("services"))
+	tr.SetCheck(c.Bool("check"))
+	defer func() {
+		if err == nil {
+			err = checkGenerated(tr)
+		}
+	}()
+	if err = tr.SetBackend(c.String("backend")); err != nil {
+		return
+	}
+	outPath, _ := path.Split(generator.ServicesRoot(c.String("services")))
 	outPath = path.Join(outPath, "transport")
 	if c.String("out") != "" {
 		outPath = c.String("out")
@@ -276,12 +585,18 @@ func cmdTransport(c *cli.Context) (err error) {
 	if c.String("outSwagger") != "" {
 		err = tr.RenderSwagger(c.String("outSwagger"))
 	}
-	if c.String("redoc") != "" {
-		var output []byte
-		log.Infof("write to %s", c.String("redoc"))
-		if output, err = exec.Command("redoc-cli", "bundle", c.String("outSwagger"), "-o", c.String("redoc")).Output(); err != nil { // nolint:gosec
-			log.WithError(err).Error(string(output))
-		}
+	if c.String("redoc") != "" && !c.Bool("check") {
+		err = renderRedoc(c.String("outSwagger"), c.String("redoc"))
+	}
+	return
+}
+
+func renderRedoc(swaggerPath, outPath string) (err error) {
+
+	var output []byte
+	log.Infof("write to %s", outPath)
+	if output, err = exec.Command("redoc-cli", "bundle", swaggerPath, "-o", outPath).Output(); err != nil { // nolint:gosec
+		log.WithError(err).Error(string(output))
 	}
 	return
 }
@@ -294,23 +609,33 @@ func cmdSwagger(c *cli.Context) (err error) {
 		}
 	}()
 
+	if c.Bool("strict") {
+		if err = lintServices(c.String("services")); err != nil {
+			return
+		}
+	}
 	var tr generator.Transport
 	if tr, err = generator.NewTransport(log, Version, c.String("services"), c.StringSlice("ifaces")...); err != nil {
 		return
 	}
+	tr.SetCheck(c.Bool("check"))
+	defer func() {
+		if err == nil {
+			err = checkGenerated(tr)
+		}
+	}()
+	if err = tr.SetAPIVersion(c.String("apiVersion")); err != nil {
+		return
+	}
 
-	outPath := path.Join(c.String("services"), "swagger.yaml")
+	outPath := path.Join(generator.ServicesRoot(c.String("services")), "swagger.yaml")
 
 	if c.String("outFile") != "" {
 		outPath = c.String("outFile")
 	}
 	if err = tr.RenderSwagger(outPath, c.StringSlice("ifaces")...); err == nil {
-		if c.String("redoc") != "" {
-			var output []byte
-			log.Infof("write to %s", c.String("redoc"))
-			if output, err = exec.Command("redoc-cli", "bundle", outPath, "-o", c.String("redoc")).Output(); err != nil {
-				log.WithError(err).Error(string(output))
-			}
+		if c.String("redoc") != "" && !c.Bool("check") {
+			err = renderRedoc(outPath, c.String("redoc"))
 		}
 	}
 	return
@@ -327,9 +652,59 @@ func cmdAzure(c *cli.Context) (err error) {
 	if tr, err = generator.NewTransport(log, Version, c.String("services")); err != nil {
 		return
 	}
-	outPath := path.Join(c.String("services"), "azure-fApp")
+	outPath := path.Join(generator.ServicesRoot(c.String("services")), "azure-fApp")
 	if c.String("outPath") != "" {
 		outPath = c.String("outPath")
 	}
 	return tr.RenderAzure(c.String("appName"), c.String("routePrefix"), outPath, c.String("logLevel"), c.Bool("enableHealth"))
 }
+
+func cmdLambda(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	var tr generator.Transport
+	if tr, err = generator.NewTransport(log, Version, c.String("services")); err != nil {
+		return
+	}
+	outPath := path.Join(generator.ServicesRoot(c.String("services")), "lambda")
+	if c.String("outPath") != "" {
+		outPath = c.String("outPath")
+	}
+	return tr.RenderLambda(c.String("appName"), c.String("codeUri"), outPath, c.Int("memorySize"), c.Int("timeout"))
+}
+
+func cmdK8s(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	var tr generator.Transport
+	if tr, err = generator.NewTransport(log, Version, c.String("services")); err != nil {
+		return
+	}
+	outPath := path.Join(generator.ServicesRoot(c.String("services")), "k8s")
+	if c.String("outPath") != "" {
+		outPath = c.String("outPath")
+	}
+	image := c.String("image")
+	if image == "" {
+		image = c.String("appName") + ":latest"
+	}
+	return tr.RenderK8s(generator.K8sConfig{
+		AppName:     c.String("appName"),
+		Image:       image,
+		Namespace:   c.String("namespace"),
+		Host:        c.String("host"),
+		Replicas:    c.Int("replicas"),
+		Port:        c.Int("port"),
+		HealthPort:  c.Int("healthPort"),
+		MetricsPort: c.Int("metricsPort"),
+		MetricsPath: c.String("metricsPath"),
+	}, outPath)
+}
@@ -149,7 +149,12 @@ func constructAliasName(spec *ast.ImportSpec) string {
 var importAliasReplacer = strings.NewReplacer("-", "")
 
 func constructAliasNameString(str string) string {
-	name := path.Base(strings.Trim(str, `"`))
+	importPath := strings.Trim(str, `"`)
+	name := path.Base(importPath)
+	// major version suffix of module is not a package name, e.g. github.com/gofiber/fiber/v2
+	if isMajorVersion(name) && path.Dir(importPath) != "." {
+		name = path.Base(path.Dir(importPath))
+	}
 	name = importAliasReplacer.Replace(name)
 	if types.BuiltinTypes[name] || types.BuiltinFunctions[name] {
 		name = "_" + name
@@ -157,6 +162,14 @@ func constructAliasNameString(str string) string {
 	return name
 }
 
+func isMajorVersion(name string) bool {
+	if len(name) < 2 || name[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(name[1:])
+	return err == nil
+}
+
 func findPackageName(src, path string) string {
 	for _, gopath := range strings.Split(src, ":") {
 		pkgs, err := parser.ParseDir(token.NewFileSet(), filepath.Join(gopath, path), nil, parser.PackageClauseOnly)
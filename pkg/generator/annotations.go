@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type annotationLevel uint8
+
+const (
+	levelPackage annotationLevel = 1 << iota
+	levelInterface
+	levelMethod
+	levelType
+	// levelVariable is argument or result of method, annotated on method level as '<name>.<annotation>'.
+	levelVariable
+)
+
+var levelNames = []struct {
+	level annotationLevel
+	name  string
+}{
+	{levelPackage, "package"},
+	{levelInterface, "interface"},
+	{levelMethod, "method"},
+	{levelType, "type"},
+	{levelVariable, "variable"},
+}
+
+func (level annotationLevel) String() string {
+
+	var names []string
+	for _, item := range levelNames {
+		if level&item.level != 0 {
+			names = append(names, item.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// annotation describes where tag may be declared and what value it accepts.
+type annotation struct {
+	levels annotationLevel
+	// flag annotations have no value.
+	flag bool
+	// syntax is human-readable form of value.
+	syntax string
+	value  *regexp.Regexp
+	// conflicts are annotations, which cannot be applied to the same method.
+	conflicts []string
+	// requires are annotations, which must be applied to the same method (directly or by interface or package).
+	requires []string
+}
+
+var (
+	reVersion      = `[\w.-]+`
+	reAnyValue     = regexp.MustCompile(`^.+$`)
+	reVarMapping   = regexp.MustCompile(`^\w+\|[^,|\s]+(,\w+\|[^,|\s]+)*$`)
+	reGoHandler    = regexp.MustCompile(`^[^\s:]+:\w+$`)
+	reMimeType     = regexp.MustCompile(`^[\w.+*-]+/[\w.+*-]+(\s*;.*)?$`)
+	rePackagePath  = regexp.MustCompile(`^[\w.\-/]+$`)
+	reVersionValue = regexp.MustCompile(`^` + reVersion + `$`)
+)
+
+var annotations = map[string]annotation{
+	tagLogger:              {levels: levelPackage | levelInterface, flag: true},
+	tagTrace:               {levels: levelPackage | levelInterface, flag: true},
+	tagTraceSample:         {levels: levelInterface | levelMethod, syntax: "<ratio 0..1>", value: regexp.MustCompile(`^(0(\.\d+)?|1(\.0+)?)$`)},
+	tagMetrics:             {levels: levelPackage | levelInterface, flag: true},
+	tagLambda:              {levels: levelPackage | levelInterface, flag: true},
+	tagDesc:                {levels: levelPackage | levelInterface | levelMethod | levelType | levelVariable, syntax: "<text>", value: reAnyValue},
+	tagSummary:             {levels: levelMethod, syntax: "<text>", value: reAnyValue},
+	tagTag:                 {levels: levelVariable, syntax: "<tag>:<value>|<tag>:<value>", value: regexp.MustCompile(`^[^:|\s]+:[^|\s]*(\|[^:|\s]+:[^|\s]*)*$`)},
+	tagType:                {levels: levelType | levelVariable, syntax: "<type>", value: reAnyValue},
+	tagEnums:               {levels: levelType | levelVariable, syntax: "<value>,<value>", value: reAnyValue},
+	tagFormat:              {levels: levelType | levelVariable, syntax: "<format>", value: reAnyValue},
+	tagRequired:            {levels: levelType | levelVariable, flag: true},
+	tagExample:             {levels: levelType | levelVariable, syntax: "<value>", value: reAnyValue},
+	tagTests:               {levels: levelInterface, flag: true},
+	tagServerHTTP:          {levels: levelInterface, flag: true},
+	tagServerJsonRPC:       {levels: levelInterface, flag: true},
+	tagEnableClientCB:      {levels: levelInterface, flag: true},
+	tagDisableOmitEmpty:    {levels: levelInterface, flag: true},
+	tagSwaggerTags:         {levels: levelInterface | levelMethod, syntax: "<tag>,<tag>", value: reAnyValue},
+	tagHttpPrefix:          {levels: levelPackage | levelInterface, syntax: "<path>", value: regexp.MustCompile(`^/?[^\s]*$`)},
+	tagHttpPath:            {levels: levelMethod, syntax: "/<path>/:<variable>", value: regexp.MustCompile(`^/[^\s]*$`)},
+	tagHttpArg:             {levels: levelMethod, syntax: "<variable>|<key>,<variable>|<key>", value: reVarMapping, requires: []string{tagMethodHTTP}},
+	tagHttpHeader:          {levels: levelMethod, syntax: "<variable>|<header>,<variable>|<header>", value: reVarMapping},
+	tagHttpCookies:         {levels: levelMethod, syntax: "<variable>|<cookie>,<variable>|<cookie>", value: reVarMapping},
+	tagMethodHTTP:          {levels: levelMethod, syntax: "<VERB>|<VERB>", value: regexp.MustCompile(`^[A-Za-z]+(\|[A-Za-z]+)*$`), requires: []string{tagServerHTTP}},
+	tagHttpSuccess:         {levels: levelMethod, syntax: "<HTTP code>", value: regexp.MustCompile(`^[1-5]\d\d$`), requires: []string{tagMethodHTTP}},
+	tagHttpResponse:        {levels: levelMethod, syntax: "<package>:<function>", value: reGoHandler, conflicts: []string{tagHandler}},
+	tagHandler:             {levels: levelMethod, syntax: "<package>:<function>", value: reGoHandler, conflicts: []string{tagHttpResponse}},
+	tagTraceAttrs:          {levels: levelMethod, syntax: "<variable>,<variable>", value: regexp.MustCompile(`^\w+(,\w+)*$`), requires: []string{tagTrace}},
+	tagMetricsLabels:       {levels: levelMethod, syntax: "<variable>,<variable>", value: regexp.MustCompile(`^\w+(,\w+)*$`), requires: []string{tagMetrics}},
+	tagAudit:               {levels: levelInterface | levelMethod, flag: true},
+	tagAuditArgs:           {levels: levelMethod, syntax: "<variable>,<variable>", value: regexp.MustCompile(`^\w+(,\w+)*$`), requires: []string{tagAudit}},
+	tagLogSkip:             {levels: levelMethod, syntax: "<variable>,<variable>", value: regexp.MustCompile(`^\w+(,\w+)*$`)},
+	tagLogRedact:           {levels: levelType | levelVariable, syntax: "<policy>", value: regexp.MustCompile(`^[\w-]+$`)},
+	tagDeprecated:          {levels: levelMethod, flag: true},
+	tagRequestContentType:  {levels: levelMethod, syntax: "<mime type>", value: reMimeType},
+	tagResponseContentType: {levels: levelMethod, syntax: "<mime type>", value: reMimeType},
+	tagSince:               {levels: levelMethod, syntax: "<API version>", value: reVersionValue, requires: []string{tagAppVersion}},
+	tagUntil:               {levels: levelMethod, syntax: "<API version>", value: reVersionValue, requires: []string{tagAppVersion}},
+	tagDeprecatedVersions:  {levels: levelInterface, syntax: "<API version>|<YYYY-MM-DD>,<API version>", value: regexp.MustCompile(`^` + reVersion + `(\|\d{4}-\d{2}-\d{2})?(,` + reVersion + `(\|\d{4}-\d{2}-\d{2})?)*$`), requires: []string{tagAppVersion}},
+	tagAppVersion:          {levels: levelPackage | levelInterface, syntax: "<version>,<version>", value: regexp.MustCompile(`^` + reVersion + `(,` + reVersion + `)*$`)},
+	tagPackageJSON:         {levels: levelPackage, syntax: "<package>", value: rePackagePath},
+	tagPackageUUID:         {levels: levelPackage, syntax: "<package>", value: rePackagePath},
+	tagSecurity:            {levels: levelPackage, syntax: "<scheme>|<scheme>", value: reAnyValue},
+	tagServers:             {levels: levelPackage, syntax: "<url>;<name>|<url>;<name>", value: reAnyValue},
+	tagTitle:               {levels: levelPackage, syntax: "<text>", value: reAnyValue},
+	tagAuthor:              {levels: levelPackage, syntax: "<text>", value: reAnyValue},
+	tagLicense:             {levels: levelPackage, syntax: "<license>", value: reAnyValue},
+	tagNameNPM:             {levels: levelPackage, syntax: "<package>", value: rePackagePath},
+	tagPrivateNPM:          {levels: levelPackage, syntax: "true|false", value: regexp.MustCompile(`^(true|false)$`)},
+	tagRegistryNPM:         {levels: levelPackage, syntax: "<url>", value: reAnyValue},
+}
+
+// closestAnnotation returns known annotation, which differs from name by case or a couple of letters.
+func closestAnnotation(name string) (closest string) {
+
+	names := make([]string, 0, len(annotations))
+	for key := range annotations {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	bestDistance := 3
+	for _, key := range names {
+		if distance := levenshtein(strings.ToLower(name), strings.ToLower(key)); distance < bestDistance {
+			closest, bestDistance = key, distance
+		}
+	}
+	return
+}
+
+func levenshtein(a, b string) int {
+
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
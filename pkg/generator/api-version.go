@@ -0,0 +1,340 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+const (
+	headerDeprecation = "Deprecation"
+	headerSunset      = "Sunset"
+)
+
+// SetAPIVersion selects API version for generated clients and documentation. By default the latest version of each interface is used.
+func (tr *Transport) SetAPIVersion(version string) (err error) {
+
+	tr.attachServices()
+	if version == "" {
+		return
+	}
+	if !slices.Contains(tr.apiVersions(), version) {
+		return fmt.Errorf("unknown API version '%s' (known: %s)", version, strings.Join(tr.apiVersions(), ", "))
+	}
+	tr.apiVersion = version
+	return
+}
+
+func (tr *Transport) apiVersions() (versions []string) {
+
+	for _, serviceName := range tr.serviceKeys() {
+		for _, version := range tr.services[serviceName].versions {
+			if !slices.Contains(versions, version) {
+				versions = append(versions, version)
+			}
+		}
+	}
+	return
+}
+
+func (tr *Transport) hasVersions() bool {
+	return len(tr.apiVersions()) != 0
+}
+
+func versionFilePath(filePath, version string) string {
+
+	ext := filepath.Ext(filePath)
+	return strings.TrimSuffix(filePath, ext) + "." + version + ext
+}
+
+func (svc *service) parseVersions(svcTags tags.DocTags) {
+
+	for _, version := range strings.Split(svcTags.Value(tagAppVersion), ",") {
+		if version = strings.TrimSpace(version); version != "" {
+			svc.versions = append(svc.versions, version)
+		}
+	}
+	svc.deprecatedVersions = make(map[string]string)
+	for _, item := range strings.Split(svcTags.Value(tagDeprecatedVersions), ",") {
+		tokens := strings.Split(item, "|")
+		version := strings.TrimSpace(tokens[0])
+		if version == "" {
+			continue
+		}
+		if !slices.Contains(svc.versions, version) {
+			svc.log.WithField("svc", svc.Name).WithField("version", version).Warning("deprecated version is not declared")
+			continue
+		}
+		var sunset string
+		if len(tokens) > 1 {
+			date, err := time.Parse(time.DateOnly, strings.TrimSpace(tokens[1]))
+			if err != nil {
+				svc.log.WithField("svc", svc.Name).WithField("version", version).WithError(err).Warning("wrong sunset date")
+			} else {
+				sunset = date.UTC().Format(http.TimeFormat)
+			}
+		}
+		svc.deprecatedVersions[version] = sunset
+	}
+}
+
+// apiVersion returns version used for clients and documentation.
+func (svc *service) apiVersion() string {
+
+	if len(svc.versions) == 0 {
+		return ""
+	}
+	if svc.tr.apiVersion != "" {
+		return svc.tr.apiVersion
+	}
+	return svc.versions[len(svc.versions)-1]
+}
+
+func (svc *service) latestVersion() string {
+
+	if len(svc.versions) == 0 {
+		return ""
+	}
+	return svc.versions[len(svc.versions)-1]
+}
+
+// versions returns interface versions which contain the method according to since/until tags.
+func (m *method) versions() []string {
+
+	since, until := 0, len(m.svc.versions)
+	if version := m.tags.Value(tagSince); version != "" {
+		if since = slices.Index(m.svc.versions, version); since < 0 {
+			m.log.WithField("svc", m.svc.Name).WithField("method", m.Name).WithField("since", version).Warning("unknown version")
+			since = 0
+		}
+	}
+	if version := m.tags.Value(tagUntil); version != "" {
+		if until = slices.Index(m.svc.versions, version); until < 0 {
+			m.log.WithField("svc", m.svc.Name).WithField("method", m.Name).WithField("until", version).Warning("unknown version")
+			until = len(m.svc.versions)
+		}
+	}
+	if since > until {
+		return nil
+	}
+	return m.svc.versions[since:until]
+}
+
+func (m *method) isAvailable(version string) bool {
+	return len(m.svc.versions) == 0 || slices.Contains(m.versions(), version)
+}
+
+// isActual reports whether method belongs to the version selected for clients and documentation.
+func (m *method) isActual() bool {
+	return m.isAvailable(m.svc.apiVersion())
+}
+
+// routeVersions returns versions to serve method routes with. Empty version means path without version prefix,
+// which is served for unversioned interface and, like unversioned JSON-RPC names, while method is in the latest version.
+func (m *method) routeVersions() (versions []string) {
+
+	if len(m.svc.versions) == 0 {
+		return []string{""}
+	}
+	versions = m.versions()
+	if m.isAvailable(m.svc.latestVersion()) {
+		versions = append(versions, "")
+	}
+	return
+}
+
+// deprecation reports whether method calls in version should be marked by Deprecation header and the Sunset value.
+// Empty version is the latest one of versioned interface.
+func (m *method) deprecation(version string) (deprecated bool, sunset string) {
+
+	if version == "" {
+		if version = m.svc.latestVersion(); version == "" {
+			return
+		}
+	}
+	if sunset, deprecated = m.svc.deprecatedVersions[version]; deprecated {
+		return
+	}
+	return m.tags.IsSet(tagUntil), ""
+}
+
+// jsonrpcName returns JSON-RPC method name for clients.
+func (m *method) jsonrpcName() string {
+
+	name := m.svc.lcName() + "." + m.lcName()
+	if version := m.svc.apiVersion(); version != "" {
+		return version + "." + name
+	}
+	return name
+}
+
+// jsonrpcBatchName returns JSON-RPC method name for clients, calling batch path of interface.
+func (m *method) jsonrpcBatchName() string {
+
+	if version := m.svc.apiVersion(); version != "" {
+		return strings.ToLower(version) + "." + m.lcName()
+	}
+	return m.lcName()
+}
+
+// jsonrpcNames returns JSON-RPC method names served for method, mapped to Sunset value for deprecated ones.
+// Unversioned name is served while method is in the latest interface version.
+func (m *method) jsonrpcNames(name string) (names []string, deprecated map[string]string) {
+
+	deprecated = make(map[string]string)
+	if len(m.svc.versions) == 0 {
+		return []string{name}, deprecated
+	}
+	for _, version := range m.versions() {
+		versionName := strings.ToLower(version) + "." + name
+		names = append(names, versionName)
+		if isDeprecated, sunset := m.deprecation(version); isDeprecated {
+			deprecated[versionName] = sunset
+		}
+	}
+	if latest := m.svc.latestVersion(); m.isAvailable(latest) {
+		names = append(names, name)
+		if isDeprecated, sunset := m.deprecation(latest); isDeprecated {
+			deprecated[name] = sunset
+		}
+	}
+	return
+}
+
+// isDeprecated reports whether method is deprecated in documentation by tag or by its API version.
+func (m *method) isDeprecated() bool {
+
+	deprecated, _ := m.deprecation(m.svc.apiVersion())
+	return deprecated || m.tags.IsSet(tagDeprecated)
+}
+
+func (m *method) jsonrpcCase(name string) (names []Code) {
+
+	methodNames, _ := m.jsonrpcNames(name)
+	for _, methodName := range methodNames {
+		names = append(names, Lit(methodName))
+	}
+	return
+}
+
+// routeHandler wraps handler by deprecation headers when method is deprecated in version.
+func (m *method) routeHandler(version string, handler Code) Code {
+
+	if deprecated, sunset := m.deprecation(version); deprecated {
+		return Id("deprecated").Call(Lit(sunset), handler)
+	}
+	return handler
+}
+
+func (tr *Transport) deprecatedJsonRPC() (deprecated map[string]string) {
+
+	deprecated = make(map[string]string)
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		for name, sunset := range svc.deprecatedJsonRPC(svc.lcName() + ".") {
+			deprecated[name] = sunset
+		}
+	}
+	return
+}
+
+func (svc *service) deprecatedJsonRPC(namePrefix string) (deprecated map[string]string) {
+
+	deprecated = make(map[string]string)
+	for _, method := range svc.methods {
+		if !method.isJsonRPC() {
+			continue
+		}
+		_, names := method.jsonrpcNames(namePrefix + method.lcName())
+		for name, sunset := range names {
+			deprecated[name] = sunset
+		}
+	}
+	return
+}
+
+// deprecationJsonRPC returns call marking response by deprecation headers when batch contains deprecated methods.
+func deprecationJsonRPC(header Code, mapName string, deprecated map[string]string) Code {
+
+	if len(deprecated) == 0 {
+		return Null()
+	}
+	return Id("deprecationJsonRPC").Call(header, Id(mapName), Id("requests"))
+}
+
+func (tr *Transport) renderDeprecation(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(packageFiber, "fiber")
+
+	setHeaders := func(set func(name string, value Code) Code) *Statement {
+		return Add(set(headerDeprecation, Lit("true"))).Line().If(Id("sunset").Op("!=").Lit("")).Block(
+			set(headerSunset, Id("sunset")),
+		)
+	}
+	setFiber := func(name string, value Code) Code {
+		return Id(_ctx_).Dot("Set").Call(Lit(name), value)
+	}
+	setNetHTTP := func(name string, value Code) Code {
+		return Id("w").Dot("Header").Call().Dot("Set").Call(Lit(name), value)
+	}
+	if tr.isNetHTTP() {
+		srcFile.Line().Func().Id("deprecated").Params(Id("sunset").String(), Id("handler").Qual(packageHttp, "HandlerFunc")).Params(Qual(packageHttp, "HandlerFunc")).Block(
+			Return(Func().Params(tr.handlerParams()).Block(
+				setHeaders(setNetHTTP),
+				Id("handler").Call(Id("w"), Id("r")),
+			)),
+		)
+	} else {
+		srcFile.Line().Func().Id("deprecated").Params(Id("sunset").String(), Id("handler").Qual(packageFiber, "Handler")).Params(Qual(packageFiber, "Handler")).Block(
+			Return(Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).Block(
+				setHeaders(setFiber),
+				Return(Id("handler").Call(Id(_ctx_))),
+			)),
+		)
+	}
+	if !tr.hasJsonRPC {
+		return srcFile.Save(path.Join(outDir, "deprecation.go"))
+	}
+	if deprecated := tr.deprecatedJsonRPC(); len(deprecated) != 0 {
+		srcFile.Line().Add(deprecatedMap("deprecatedJsonRPC", deprecated))
+	}
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		if deprecated := svc.deprecatedJsonRPC(""); len(deprecated) != 0 {
+			srcFile.Line().Add(deprecatedMap("deprecated"+svc.Name+"JsonRPC", deprecated))
+		}
+	}
+	setHeader, headerParams := setFiber, Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")
+	if tr.isNetHTTP() {
+		setHeader, headerParams = setNetHTTP, Id("w").Qual(packageHttp, "ResponseWriter")
+	}
+	srcFile.Line().Func().Id("deprecationJsonRPC").Params(headerParams, Id("methods").Map(String()).String(), Id("requests").Index().Id("baseJsonRPC")).Block(
+		For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
+			If(List(Id("sunset"), Id("found")).Op(":=").Id("methods").Index(Qual(packageStrings, "ToLower").Call(Id("request").Dot("Method"))).Op(";").Id("found")).Block(
+				setHeaders(setHeader),
+			),
+		),
+	)
+	return srcFile.Save(path.Join(outDir, "deprecation.go"))
+}
+
+func deprecatedMap(name string, deprecated map[string]string) Code {
+
+	return Var().Id(name).Op("=").Map(String()).String().Values(DictFunc(func(dict Dict) {
+		for methodName, sunset := range deprecated {
+			dict[Lit(methodName)] = Lit(sunset)
+		}
+	}))
+}
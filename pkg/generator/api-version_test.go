@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+func testService(svcTags tags.DocTags) (svc *service) {
+
+	svc = &service{
+		Interface: types.Interface{Base: types.Base{Name: "Orders"}},
+		log:       testLog(),
+		tr:        &Transport{services: make(map[string]*service)},
+		tags:      svcTags,
+	}
+	svc.tr.services[svc.Name] = svc
+	svc.parseVersions(svcTags)
+	return
+}
+
+func testMethod(svc *service, methodTags tags.DocTags) *method {
+
+	return &method{
+		Function: &types.Function{Base: types.Base{Name: "List"}},
+		log:      svc.log,
+		svc:      svc,
+		tags:     methodTags.Merge(svc.tags),
+	}
+}
+
+func TestParseVersions(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		tags       tags.DocTags
+		versions   []string
+		deprecated map[string]string
+	}{
+		{
+			name:       "unversioned",
+			tags:       tags.DocTags{},
+			deprecated: map[string]string{},
+		},
+		{
+			name:       "trimmed",
+			tags:       tags.DocTags{tagAppVersion: " v1, ,v2 "},
+			versions:   []string{"v1", "v2"},
+			deprecated: map[string]string{},
+		},
+		{
+			name:       "deprecated with sunset",
+			tags:       tags.DocTags{tagAppVersion: "v1,v2", tagDeprecatedVersions: "v1|2025-01-31"},
+			versions:   []string{"v1", "v2"},
+			deprecated: map[string]string{"v1": "Fri, 31 Jan 2025 00:00:00 GMT"},
+		},
+		{
+			name:       "deprecated without sunset",
+			tags:       tags.DocTags{tagAppVersion: "v1,v2", tagDeprecatedVersions: "v1"},
+			versions:   []string{"v1", "v2"},
+			deprecated: map[string]string{"v1": ""},
+		},
+		{
+			name:       "wrong sunset",
+			tags:       tags.DocTags{tagAppVersion: "v1,v2", tagDeprecatedVersions: "v1|31.01.2025"},
+			versions:   []string{"v1", "v2"},
+			deprecated: map[string]string{"v1": ""},
+		},
+		{
+			name:       "undeclared deprecated",
+			tags:       tags.DocTags{tagAppVersion: "v1", tagDeprecatedVersions: "v0|2025-01-31"},
+			versions:   []string{"v1"},
+			deprecated: map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc := testService(test.tags)
+			if !slices.Equal(svc.versions, test.versions) {
+				t.Errorf("versions = %v, want %v", svc.versions, test.versions)
+			}
+			if !maps.Equal(svc.deprecatedVersions, test.deprecated) {
+				t.Errorf("deprecated = %v, want %v", svc.deprecatedVersions, test.deprecated)
+			}
+		})
+	}
+}
+
+func TestRouteVersions(t *testing.T) {
+
+	versioned := tags.DocTags{tagAppVersion: "v1,v2,v3"}
+	tests := []struct {
+		name     string
+		svcTags  tags.DocTags
+		tags     tags.DocTags
+		versions []string
+	}{
+		{name: "unversioned", svcTags: tags.DocTags{}, tags: tags.DocTags{}, versions: []string{""}},
+		{name: "all versions", svcTags: versioned, tags: tags.DocTags{}, versions: []string{"v1", "v2", "v3", ""}},
+		{name: "since", svcTags: versioned, tags: tags.DocTags{tagSince: "v2"}, versions: []string{"v2", "v3", ""}},
+		{name: "until", svcTags: versioned, tags: tags.DocTags{tagUntil: "v2"}, versions: []string{"v1"}},
+		{name: "since and until", svcTags: versioned, tags: tags.DocTags{tagSince: "v2", tagUntil: "v3"}, versions: []string{"v2"}},
+		{name: "since after until", svcTags: versioned, tags: tags.DocTags{tagSince: "v3", tagUntil: "v2"}, versions: nil},
+		{name: "unknown since", svcTags: versioned, tags: tags.DocTags{tagSince: "v9"}, versions: []string{"v1", "v2", "v3", ""}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := testMethod(testService(test.svcTags), test.tags)
+			if versions := m.routeVersions(); !slices.Equal(versions, test.versions) {
+				t.Errorf("routeVersions() = %q, want %q", versions, test.versions)
+			}
+		})
+	}
+}
+
+func TestJsonrpcNames(t *testing.T) {
+
+	svc := testService(tags.DocTags{tagAppVersion: "v1,v2", tagDeprecatedVersions: "v1|2025-01-31"})
+	names, deprecated := testMethod(svc, tags.DocTags{}).jsonrpcNames("orders.list")
+	if want := []string{"v1.orders.list", "v2.orders.list", "orders.list"}; !slices.Equal(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+	if want := map[string]string{"v1.orders.list": "Fri, 31 Jan 2025 00:00:00 GMT"}; !maps.Equal(deprecated, want) {
+		t.Errorf("deprecated = %v, want %v", deprecated, want)
+	}
+}
+
+func TestRenderVersions(t *testing.T) {
+
+	const sunset = `"Fri, 31 Jan 2025 00:00:00 GMT"`
+	tests := []struct {
+		backend string
+		routes  []string
+		absent  []string
+	}{
+		{
+			backend: backendNetHTTP,
+			routes: []string{
+				`mux.HandleFunc("GET /v1/api/orders/list", deprecated(` + sunset + `, http.serveList))`,
+				`mux.HandleFunc("GET /v2/api/orders/list", http.serveList)`,
+				`mux.HandleFunc("GET /api/orders/list", http.serveList)`,
+				`mux.HandleFunc("POST /v1/api/orders/cancel", deprecated(` + sunset + `, http.serveCancel))`,
+				`mux.HandleFunc("POST /v2/api/orders/archive", http.serveArchive)`,
+				`mux.HandleFunc("POST /api/orders/archive", http.serveArchive)`,
+			},
+			absent: []string{`"POST /api/orders/cancel"`, `"POST /v1/api/orders/archive"`},
+		},
+		{
+			backend: backendFiber,
+			routes: []string{
+				`route.Get("/v1/api/orders/list", deprecated(` + sunset + `, http.serveList))`,
+				`route.Get("/v2/api/orders/list", http.serveList)`,
+				`route.Get("/api/orders/list", http.serveList)`,
+				`route.Post("/v1/api/orders/cancel", deprecated(` + sunset + `, http.serveCancel))`,
+				`route.Post("/v2/api/orders/archive", http.serveArchive)`,
+				`route.Post("/api/orders/archive", http.serveArchive)`,
+			},
+			absent: []string{`"/api/orders/cancel"`, `"/v1/api/orders/archive"`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/versions", test.backend)
+			assertContains(t, files, "orders-http.go", test.routes...)
+			assertNotContains(t, files, "orders-http.go", test.absent...)
+			assertContains(t, files, "deprecation.go",
+				`var deprecatedJsonRPC = map[string]string{"v1.orders.cancel": `+sunset+`}`,
+				`var deprecatedOrdersJsonRPC = map[string]string{"v1.cancel": `+sunset+`}`,
+			)
+		})
+	}
+}
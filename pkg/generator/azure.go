@@ -5,6 +5,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/seniorGolang/tg/v2/pkg/utils"
 )
@@ -38,7 +40,7 @@ func (app *azure) render(appName, routePrefix, outFilePath, logLevel string, ena
 						AuthLevel: "anonymous",
 						Type:      "httpTrigger",
 						Route:     route,
-						Methods:   []string{"head", "options", svcMethod.httpMethod()},
+						Methods:   azureMethods(svcMethod),
 					},
 					{
 						Name:      "res",
@@ -51,7 +53,7 @@ func (app *azure) render(appName, routePrefix, outFilePath, logLevel string, ena
 			if err = os.MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
 				return
 			}
-			if err = os.WriteFile(outFileName, toJSON(fn), 0600); err != nil {
+			if err = generated.WriteFile(outFileName, toJSON(fn), 0600); err != nil {
 				return
 			}
 		}
@@ -93,7 +95,18 @@ func (app *azure) render(appName, routePrefix, outFilePath, logLevel string, ena
 		if err = os.MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
 			return
 		}
-		return os.WriteFile(outFileName, toJSON(host), 0600)
+		return generated.WriteFile(outFileName, toJSON(host), 0600)
+	}
+	return
+}
+
+func azureMethods(svcMethod *method) (methods []string) {
+
+	methods = []string{"head", "options"}
+	for _, verb := range svcMethod.httpMethods() {
+		if verb = strings.ToLower(verb); !slices.Contains(methods, verb) {
+			methods = append(methods, verb)
+		}
 	}
 	return
 }
@@ -10,6 +10,7 @@ import (
 	"strings"
 )
 
+// cleanup removes generated files, which were not rendered during current run.
 func (tr *Transport) cleanup(outDir string) {
 
 	var err error
@@ -23,10 +24,13 @@ func (tr *Transport) cleanup(outDir string) {
 			continue
 		}
 		filePath := path.Join(outDir, file.Name())
+		if generated.isWritten(filePath) {
+			continue
+		}
 		if goFile, err := os.Open(filePath); err == nil {
 			if firstLine, err := bufio.NewReader(goFile).ReadString('\n'); err == nil {
 				if strings.TrimSpace(strings.TrimPrefix(firstLine, "//")) == doNotEdit {
-					if err = os.Remove(filePath); err != nil {
+					if err = generated.remove(filePath); err != nil {
 						tr.log.WithError(err).Warn("cleanup")
 					}
 				}
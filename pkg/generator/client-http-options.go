@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) renderClientHTTPOptions(outDir string) (err error) {
+
+	if err = pkgCopyTo("cb", outDir); err != nil {
+		return err
+	}
+	if err = pkgCopyTo("httpclient", outDir); err != nil {
+		return err
+	}
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	pkgCB := fmt.Sprintf("%s/cb", tr.pkgPath(outDir))
+	pkgHttpClient := fmt.Sprintf("%s/httpclient", tr.pkgPath(outDir))
+	srcFile.ImportName(pkgCB, "cb")
+	srcFile.ImportName(pkgHttpClient, "httpclient")
+
+	srcFile.Line().Comment("DecodeErrorHTTP sets decoder for HTTP responses with unexpected status.")
+	srcFile.Func().Id("DecodeErrorHTTP").Params(Id("decoder").Id("ErrorDecoder")).Params(Qual(pkgHttpClient, "Option")).Block(
+		Return(Qual(pkgHttpClient, "WithErrorDecoder").Call(
+			Func().Params(Id("_").Int(), Id("body").Index().Byte()).Params(Error()).Block(
+				Return(Id("decoder").Call(Id("body"))),
+			),
+		)),
+	)
+	srcFile.Line().Comment("CircuitBreakerHTTP wraps HTTP client calls by circuit breaker with cfg settings.")
+	srcFile.Func().Id("CircuitBreakerHTTP").Params(Id("cfg").Qual(pkgCB, "Settings")).Params(Qual(pkgHttpClient, "Option")).Block(
+		Id("breaker").Op(":=").Qual(pkgCB, "NewCircuitBreaker").Call(Lit(tr.module.Module.Mod.String()), Id("cfg")),
+		Return(Qual(pkgHttpClient, "WithBreaker").Call(
+			Func().Params(Id("call").Func().Params().Error()).Params(Error()).Block(
+				Return(Id("breaker").Dot("Execute").Call(Id("call"))),
+			),
+		)),
+	)
+	srcFile.Line().Comment("MetricsHTTP records metrics of HTTP client calls, their retries and circuit breaker rejections.")
+	srcFile.Func().Id("MetricsHTTP").Params(Id("metrics").Op("*").Id("ClientMetrics")).Params(Qual(pkgHttpClient, "Option")).Block(
+		Return(Qual(pkgHttpClient, "WithObserver").Call(Id("metrics"))),
+	)
+	return srcFile.Save(path.Join(outDir, "http-options.go"))
+}
@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+// renderHTTP adds fetch-based functions of http-server methods to namespace of service.
+func (ts *clientTS) renderHTTP(tsFile *bytesWriter, svc *service) (err error) {
+
+	if ts.zod {
+		tsFile.add("export const HTTP = (options: HttpOptions, validate?: boolean) => {\n")
+		tsFile.add("const check = validate ? validator(ResultSchemas) : undefined;\n")
+	} else {
+		tsFile.add("export const HTTP = (options: HttpOptions) => {\n")
+	}
+	tsFile.add("const client = httpClient(options);\n")
+	tsFile.add("return {\n")
+	for _, method := range svc.methods {
+		if !method.isActual() || !method.isHTTP() {
+			continue
+		}
+		for _, verb := range method.httpMethods() {
+			if err = ts.renderHTTPMethod(tsFile, svc, method, verb); err != nil {
+				return
+			}
+		}
+	}
+	tsFile.add("}\n}\n")
+	return
+}
+
+func (ts *clientTS) renderHTTPMethod(tsFile *bytesWriter, svc *service, method *method, verb string) (err error) {
+
+	successCode := method.tags.ValueInt(tagHttpSuccess, http.StatusOK)
+	retHeaders, retCookies := svc.httpClientResultMaps(method)
+	argHeaders := make(map[string]string)
+	for argName, header := range method.varHeaderMap() {
+		if method.argByName(argName) != nil {
+			argHeaders[argName] = header
+		}
+	}
+	argQuery, argPath, argCookies := method.argParamMap(), method.argPathMap(), method.argCookieMap()
+	var body []string
+	for _, arg := range method.argsWithoutContext() {
+		_, inQuery := argQuery[arg.Name]
+		_, inPath := argPath[arg.Name]
+		_, inHeader := argHeaders[arg.Name]
+		_, inCookie := argCookies[arg.Name]
+		if !inQuery && !inPath && !inHeader && !inCookie {
+			if key, inline, skip := method.bodyKey(arg); inline {
+				body = append(body, fmt.Sprintf("...params.%s", arg.Name))
+			} else if !skip {
+				body = append(body, fmt.Sprintf("%q: params.%s", key, arg.Name))
+			}
+		}
+	}
+	if len(body) != 0 && (verb == http.MethodGet || verb == http.MethodHead) {
+		return fmt.Errorf("%s.%s: %s request can not have body, map arguments with '%s' or '%s' annotation", svc.Name, method.Name, verb, tagHttpArg, tagHttpHeader)
+	}
+	urlTokens := strings.Split(method.httpPath(), "/")
+	for i, token := range urlTokens {
+		if strings.HasPrefix(token, ":") {
+			urlTokens[i] = fmt.Sprintf("${encodeURIComponent(String(params.%s))}", strings.TrimPrefix(token, ":"))
+		}
+	}
+	tsFile.add("%s: async (params: {%s}, signal?: AbortSignal): Promise<{%s}> => {\n",
+		method.httpMethodName(verb),
+		ts.paramsToFuncParams(svc.pkgPath, method.tags, method.argsWithoutContext()),
+		ts.paramsToFuncParams(svc.pkgPath, method.tags, method.resultsWithoutError()),
+	)
+	results := method.resultsWithoutError()
+	if len(results) == 0 {
+		tsFile.add("await client({\n")
+	} else {
+		tsFile.add("const res = await client({\n")
+	}
+	tsFile.add("method: %q,\n", verb)
+	tsFile.add("path: `%s`,\n", strings.Join(urlTokens, "/"))
+	if len(argQuery) != 0 {
+		tsFile.add("query: {%s},\n", tsParamsMap(argQuery))
+	}
+	if len(argHeaders) != 0 {
+		tsFile.add("headers: {%s},\n", tsParamsMap(argHeaders))
+	}
+	if len(argCookies) != 0 {
+		tsFile.add("cookies: {%s},\n", tsParamsMap(argCookies))
+	}
+	if len(body) != 0 {
+		tsFile.add("body: {%s},\n", strings.Join(body, ", "))
+	}
+	tsFile.add("success: %d,\n", successCode)
+	tsFile.add("signal,\n")
+	tsFile.add("});\n")
+	var values []string
+	for _, ret := range results {
+		switch {
+		case retHeaders[ret.Name] != "":
+			values = append(values, fmt.Sprintf("%s: fromString(res.headers.get(%q), %q)", ret.Name, retHeaders[ret.Name], ts.stringKind(svc, method, ret.Name)))
+		case retCookies[ret.Name] != "":
+			values = append(values, fmt.Sprintf("%s: fromString(res.cookies[%q], %q)", ret.Name, retCookies[ret.Name], ts.stringKind(svc, method, ret.Name)))
+		case verb == http.MethodHead:
+			continue
+		case len(results) == 1:
+			values = append(values, fmt.Sprintf("%s: res.body", ret.Name))
+		default:
+			values = append(values, fmt.Sprintf("%s: res.body?.%s", ret.Name, ret.Name))
+		}
+	}
+	if ts.zod {
+		tsFile.add("const result = {%s};\n", strings.Join(values, ", "))
+		tsFile.add("check?.(%q, result);\n", method.httpMethodName(verb))
+		tsFile.add("return result;\n")
+	} else {
+		tsFile.add("return {%s};\n", strings.Join(values, ", "))
+	}
+	tsFile.add("},\n")
+	return
+}
+
+// bodyKey returns key of argument in JSON body of request, as it is tagged in request struct.
+func (m *method) bodyKey(arg types.Variable) (key string, inline, skip bool) {
+
+	key = arg.Name
+	jsonTags := m.varsToFields([]types.Variable{arg}, m.tags)[0].Tags["json"]
+	if len(jsonTags) == 0 {
+		return
+	}
+	switch {
+	case jsonTags[0] == "-":
+		return "", false, true
+	case slices.Contains(jsonTags[1:], "inline"):
+		return "", true, false
+	case jsonTags[0] == "":
+		return utils.ToCamel(arg.Name), false, false
+	}
+	return jsonTags[0], false, false
+}
+
+// stringKind returns kind of value, which result is converted to from header or cookie.
+func (ts *clientTS) stringKind(svc *service, method *method, retName string) string {
+
+	ret := method.resultByName(retName)
+	switch link := ts.walkVariable(ret.Name, svc.pkgPath, ret.Type, method.tags).typeLink(); link {
+	case "number", "boolean":
+		return link
+	default:
+		return "string"
+	}
+}
+
+// tsParamsMap returns object properties of params mapped to keys.
+func tsParamsMap(mapping map[string]string) string {
+
+	var props []string
+	for _, argName := range sortedKeys(mapping) {
+		props = append(props, fmt.Sprintf("%q: params.%s", mapping[argName], argName))
+	}
+	return strings.Join(props, ", ")
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// renderTS generates TypeScript client of services in svcDir and returns generated files by relative paths.
+func renderTS(t *testing.T, svcDir string, zod bool) (files map[string]string) {
+
+	t.Helper()
+	tr, err := NewTransport(testLog(), "test", svcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.SetZod(zod)
+	outDir := filepath.Join(t.TempDir(), "ts")
+	if err = tr.RenderClientTS(outDir); err != nil {
+		t.Fatal(err)
+	}
+	return readFiles(t, outDir)
+}
+
+func TestRenderHTTPClientTS(t *testing.T) {
+
+	files := renderTS(t, "testdata/files", false)
+	assertContains(t, files, "files.ts",
+		`import {httpClient, fromString, HttpOptions} from "./http/http";`,
+		`export const HTTP = (options: HttpOptions) => {`,
+		`Get: async (params: {id: number,token: string,limit: number}, signal?: AbortSignal): Promise<{name: string}> => {`,
+		"path: `/api/files/${encodeURIComponent(String(params.id))}`,",
+		`query: {"limit": params.limit},`,
+		`headers: {"X-Token": params.token},`,
+		`method: "PUT",`,
+		`body: {"name": params.name, "data": params.data},`,
+		`success: 201,`,
+		`UploadPut: async (params: {name: string,data: string}, signal?: AbortSignal): Promise<{id: number}> => {`,
+	)
+	assertNotContains(t, files, "files.ts", `rpcClient`)
+	assertContains(t, files, "http/http.ts",
+		`export class HttpError`,
+		`export function httpClient(`,
+	)
+	if _, found := files["jsonrpc/jsonrpc.ts"]; !found {
+		t.Error("jsonrpc/jsonrpc.ts is not generated")
+	}
+}
+
+func TestRenderHTTPClientTSBodyOfGet(t *testing.T) {
+
+	svcDir := t.TempDir()
+	source := `package svc
+
+import "context"
+
+// @tg http-server
+type Files interface {
+	// @tg http-method=GET
+	// @tg http-path=/files
+	Find(ctx context.Context, name string) (err error)
+}
+`
+	if err := os.WriteFile(filepath.Join(svcDir, "svc.go"), []byte(source), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tr, err := NewTransport(testLog(), "test", svcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tr.RenderClientTS(filepath.Join(t.TempDir(), "ts"))
+	if want := "Files.Find: GET request can not have body, map arguments with 'http-args' or 'http-headers' annotation"; err == nil || err.Error() != want {
+		t.Errorf("error %v, want %q", err, want)
+	}
+}
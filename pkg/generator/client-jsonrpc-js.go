@@ -50,8 +50,9 @@ func (tr *Transport) RenderPackageNPM(outJs, outPkg string) (err error) {
 		Scripts struct {
 			Test string `json:"test"`
 		} `json:"scripts"`
-		Author  string `json:"author"`
-		License string `json:"license"`
+		Author       string            `json:"author"`
+		License      string            `json:"license"`
+		Dependencies map[string]string `json:"dependencies,omitempty"`
 	}
 	modPath, _ := filepath.Abs(tr.modPath)
 	jsPath, _ := filepath.Abs(path.Join(outJs, "jsonrpc-client.js"))
@@ -76,17 +77,19 @@ func (tr *Transport) RenderPackageNPM(outJs, outPkg string) (err error) {
 		Author:  tr.tags.Value(tagAuthor),
 		License: tr.tags.Value(tagLicense),
 	}
+	if tr.zod {
+		data.Dependencies = map[string]string{packageZod: versionZod}
+	}
 	var bytes []byte
 	if bytes, err = json.MarshalIndent(data, "", "    "); err != nil {
 		return
 	}
-	return os.WriteFile(path.Join(outPkg, "package.json"), bytes, 0600)
+	return generated.WriteFile(path.Join(outPkg, "package.json"), bytes, 0600)
 }
 
 func (js *clientJS) render(outDir string) (err error) {
 
 	outFilename := path.Join(outDir, "jsonrpc-client.js")
-	_ = os.Remove(outFilename)
 	if err = os.MkdirAll(outDir, 0777); err != nil {
 		return
 	}
@@ -102,6 +105,9 @@ func (js *clientJS) render(outDir string) (err error) {
 		jsFile.add("this.scheduler = new JSONRPCScheduler(transport);\n")
 		jsFile.add("}\n\n")
 		for _, method := range svc.methods {
+			if !method.isActual() {
+				continue
+			}
 			jsFile.add("/**\n")
 			if comment := method.tags.Value("summary", ""); comment != "" {
 				jsFile.add("* %s\n", comment)
@@ -121,7 +127,7 @@ func (js *clientJS) render(outDir string) (err error) {
 				for _, ret := range method.results() {
 					fields = append(fields, fmt.Sprintf("%s: %s", ret.Name, js.walkVariable(ret.Name, svc.pkgPath, ret.Type, method.tags).typeLink()))
 				}
-				jsFile.add(strings.Join(fields, ",")) // nolint
+				jsFile.add("%s", strings.Join(fields, ","))
 				jsFile.add("}>}\n")
 			}
 			jsFile.add("**/\n")
@@ -134,14 +140,18 @@ func (js *clientJS) render(outDir string) (err error) {
 				}
 				fields = append(fields, prefix+utils.ToLowerCamel(arg.Name))
 			}
-			jsFile.add(strings.Join(fields, ",")) // nolint
+			jsFile.add("%s", strings.Join(fields, ","))
 			jsFile.add(") {\n")
-			jsFile.add("return this.scheduler.__scheduleRequest(\"%s\", {", svc.lccName()+"."+method.lccName())
+			methodName := svc.lccName() + "." + method.lccName()
+			if version := svc.apiVersion(); version != "" {
+				methodName = version + "." + methodName
+			}
+			jsFile.add("return this.scheduler.__scheduleRequest(\"%s\", {", methodName)
 			fields = []string{}
 			for _, arg := range method.arguments() {
 				fields = append(fields, fmt.Sprintf("%[1]s:%[1]s", utils.ToLowerCamel(arg.Name)))
 			}
-			jsFile.add(strings.Join(fields, ",")) // nolint
+			jsFile.add("%s", strings.Join(fields, ","))
 			jsFile.add("}).catch(e => { throw ")
 			jsFile.add("%sConvertError(e)", utils.ToLowerCamel(method.fullName()))
 			jsFile.add("; })\n")
@@ -167,6 +177,9 @@ func (js *clientJS) render(outDir string) (err error) {
 			continue
 		}
 		for _, method := range svc.methods {
+			if !method.isActual() {
+				continue
+			}
 			jsFile.add("function %sConvertError(e) {\n", utils.ToLowerCamel(method.fullName()))
 			jsFile.add("switch(e.code) {\n")
 			jsFile.add("default:\n")
@@ -175,9 +188,9 @@ func (js *clientJS) render(outDir string) (err error) {
 		}
 	}
 	for _, def := range js.typeDef {
-		jsFile.add(def.js()) // nolint
+		jsFile.add("%s", def.js())
 	}
-	return os.WriteFile(outFilename, jsFile.Bytes(), 0600)
+	return generated.WriteFile(outFilename, jsFile.Bytes(), 0600)
 }
 
 type typeDefJs struct {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/seniorGolang/tg/v2/pkg/astra/types"
@@ -38,9 +39,19 @@ func (ts *clientTS) render(outDir string) (err error) {
 	if err = tsCopyTo("jsonrpc", outDir); err != nil {
 		return err
 	}
+	if ts.hasHTTP {
+		if err = tsCopyTo("http", outDir); err != nil {
+			return err
+		}
+	}
+	if ts.zod {
+		if err = tsCopyTo("schema", outDir); err != nil {
+			return err
+		}
+	}
 	for _, name := range ts.serviceKeys() {
 		svc := ts.services[name]
-		if !svc.isJsonRPC() {
+		if !svc.isJsonRPC() && !svc.isHTTP() {
 			continue
 		}
 		if err = ts.renderService(svc, outDir); err != nil {
@@ -55,34 +66,70 @@ func (ts *clientTS) renderService(svc *service, outDir string) (err error) {
 	ts.knownTypes = make(map[string]int)
 	ts.typeDefTs = make(map[string]typeDefTs)
 	outFilename := path.Join(outDir, fmt.Sprintf("%s.ts", svc.lccName()))
-	_ = os.Remove(outFilename)
 	if err = os.MkdirAll(outDir, 0777); err != nil {
 		return
 	}
 	var jsFile bytesWriter
-	jsFile.add("import {rpcClient} from \"./jsonrpc/jsonrpc\";\n\n")
-	jsFile.add("export namespace %sAPI {\n\n", svc.Name)
-	jsFile.add(`export const RPC = (headers?: Record<string, string>) => {
+	if svc.isJsonRPC() {
+		jsFile.add("import {rpcClient, BatchOptions} from \"./jsonrpc/jsonrpc\";\n")
+	}
+	if svc.isHTTP() {
+		jsFile.add("import {httpClient, fromString, HttpOptions} from \"./http/http\";\n")
+	}
+	if ts.zod {
+		jsFile.add("import {z} from \"zod\";\n")
+		jsFile.add("import {validator} from \"./schema/schema\";\n")
+	}
+	jsFile.add("\nexport namespace %sAPI {\n\n", svc.Name)
+	if svc.isJsonRPC() {
+		var validateParam, validateOption string
+		if ts.zod {
+			validateParam = ", validate?: boolean"
+			validateOption = ",\n            validate: validate ? validator(ResultSchemas) : undefined"
+		}
+		jsFile.add(`export const RPC = (headers?: Record<string, string>, batch?: boolean | BatchOptions%s) => {
         return rpcClient<Methods>({
             url: "%s",
-            getHeaders: () => headers
+            getHeaders: () => headers,
+            names: MethodNames,
+            batch%s
         })
     }
-`, svc.batchPath())
-	jsFile.add("export type Methods = {\n")
-	for _, method := range svc.methods {
-		jsFile.add("%s(params: {%s}) : {%s}\n",
-			method.Name,
-			ts.paramsToFuncParams(svc.pkgPath, method.tags, method.argsWithoutContext()),
-			ts.paramsToFuncParams(svc.pkgPath, method.tags, method.resultsWithoutError()),
-		)
+`, validateParam, svc.batchPath(), validateOption)
+		jsFile.add("export const MethodNames: Record<string, string> = {\n")
+		for _, method := range svc.methods {
+			if !method.isActual() || !method.isJsonRPC() {
+				continue
+			}
+			jsFile.add("%s: %q,\n", method.Name, method.jsonrpcBatchName())
+		}
+		jsFile.add("}\n")
+		jsFile.add("export type Methods = {\n")
+		for _, method := range svc.methods {
+			if !method.isActual() || !method.isJsonRPC() {
+				continue
+			}
+			jsFile.add("%s(params: {%s}) : {%s}\n",
+				method.Name,
+				ts.paramsToFuncParams(svc.pkgPath, method.tags, method.argsWithoutContext()),
+				ts.paramsToFuncParams(svc.pkgPath, method.tags, method.resultsWithoutError()),
+			)
+		}
+		jsFile.add("}\n")
 	}
-	jsFile.add("}\n")
-	for _, def := range ts.typeDefTs {
-		jsFile.add(def.ts()) // nolint
+	if svc.isHTTP() {
+		if err = ts.renderHTTP(&jsFile, svc); err != nil {
+			return
+		}
+	}
+	if ts.zod {
+		ts.renderZod(&jsFile, svc)
+	}
+	for _, typeName := range ts.typeNames() {
+		jsFile.add("%s", ts.typeDefTs[typeName].ts())
 	}
 	jsFile.add("}\n\n")
-	return os.WriteFile(outFilename, jsFile.Bytes(), 0600)
+	return generated.WriteFile(outFilename, jsFile.Bytes(), 0600)
 }
 
 func (ts *clientTS) paramsToFuncParams(pkgPath string, tags tags.DocTags, vars []types.Variable) string {
@@ -101,6 +148,10 @@ type typeDefTs struct {
 	nullable   bool
 	value      interface{}
 	properties map[string]typeDefTs
+	// tags are annotations of struct field.
+	tags tags.DocTags
+	// origin is Go type of scalar.
+	origin string
 }
 
 func (def typeDefTs) def() (prop string) {
@@ -128,13 +179,13 @@ func (def typeDefTs) ts() (js string) {
 		if len(def.properties) > 1 {
 			if def.typeName == "iota" {
 				var cnt int
-				for key := range def.properties {
+				for _, key := range def.propertyNames() {
 					js += fmt.Sprintf("export const %s = %d;\n", key, cnt)
 					cnt++
 				}
 			} else {
 				js += "export enum " + def.typeName + " {\n"
-				for key := range def.properties {
+				for _, key := range def.propertyNames() {
 					js += fmt.Sprintf("%s,\n", key)
 				}
 				js += "}\n"
@@ -144,7 +195,8 @@ func (def typeDefTs) ts() (js string) {
 		}
 	case "struct":
 		js += "export interface " + def.name + " {\n"
-		for name, property := range def.properties {
+		for _, name := range def.propertyNames() {
+			property := def.properties[name]
 			var pNullable string
 			if property.nullable {
 				pNullable = "?"
@@ -156,6 +208,16 @@ func (def typeDefTs) ts() (js string) {
 	return
 }
 
+// propertyNames returns sorted names of properties.
+func (def typeDefTs) propertyNames() (names []string) {
+
+	for name := range def.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
 func (def typeDefTs) typeLink() (link string) {
 
 	switch def.kind {
@@ -181,6 +243,7 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 	if newType := castTypeTs(varType.String()); newType != varType.String() {
 		schema.kind = "scalar"
 		schema.typeName = newType
+		schema.origin = varType.String()
 		return
 	}
 	switch vType := varType.(type) {
@@ -234,6 +297,7 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 			if fieldName, inline := jsonName(field); fieldName != "-" {
 				embed := ts.walkVariable(field.Name, pkgPath, field.Type, tags.ParseTags(field.Docs))
 				if !inline {
+					embed.tags = tags.ParseTags(field.Docs)
 					schema.properties[fieldName] = embed
 					continue
 				}
@@ -289,6 +353,40 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 	return
 }
 
+// typeNames returns sorted names of walked types.
+func (ts *clientTS) typeNames() (names []string) {
+
+	for typeName := range ts.typeDefTs {
+		names = append(names, typeName)
+	}
+	sort.Strings(names)
+	return
+}
+
+// structNames returns sorted names of walked structures.
+func (ts *clientTS) structNames() (names []string) {
+
+	unique := make(map[string]bool)
+	for _, def := range ts.typeDefTs {
+		if def.kind == "struct" && !unique[def.name] {
+			unique[def.name] = true
+			names = append(names, def.name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func (ts *clientTS) structDef(name string) (def typeDefTs) {
+
+	for _, def = range ts.typeDefTs {
+		if def.kind == "struct" && def.name == name {
+			return
+		}
+	}
+	return
+}
+
 func (ts *clientTS) knownCount(typeName string) int {
 	if _, found := ts.knownTypes[typeName]; !found {
 		return 0
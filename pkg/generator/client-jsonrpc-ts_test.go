@@ -0,0 +1,24 @@
+package generator
+
+import "testing"
+
+func TestRenderClientTSBatch(t *testing.T) {
+
+	files := renderTS(t, "testdata/trace", false)
+	assertContains(t, files, "orders.ts",
+		`import {rpcClient, BatchOptions} from "./jsonrpc/jsonrpc";`,
+		`export const RPC = (headers?: Record<string, string>, batch?: boolean | BatchOptions) => {`,
+		`url: "/orders",`,
+		`names: MethodNames,`,
+		`Cancel: "cancel",`,
+		`Archive(params: {id: number}) : {}`,
+		`export const HTTP = (options: HttpOptions) => {`,
+	)
+	assertContains(t, files, "jsonrpc/jsonrpc.ts",
+		`export type BatchOptions = {`,
+		`export type BatchCall<R> = {`,
+		`export type BatchResult<R> =`,
+		`export function rpcClient<T extends object>(options: RpcClientOptions) {`,
+		`const batching = options.batch ?`,
+	)
+}
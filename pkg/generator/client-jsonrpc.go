@@ -45,7 +45,8 @@ func (tr *Transport) renderClientJsonRPC(outDir string) (err error) {
 				dict[Id("errorDecoder")] = Id("defaultErrorDecoder")
 			}))
 			bg.Id("cli").Dot("applyOpts").Call(Id("opts"))
-			bg.Id("cli").Dot("rpc").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "NewClient").Call(Id("endpoint"), Id("cli").Dot("rpcOpts").Op("..."))
+			bg.Id("rpcOpts").Op(":=").Append(Index().Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "Option").Values(Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "WithRedactor").Call(Id("redactor").Values())), Id("cli").Dot("rpcOpts").Op("..."))
+			bg.Id("cli").Dot("rpc").Op("=").Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "NewClient").Call(Id("endpoint"), Id("rpcOpts").Op("..."))
 			bg.Id("cli").Dot("cb").Op("=").Qual(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "NewCircuitBreaker").Call(Lit(tr.module.Module.Mod.String()), Id("cli").Dot("cbCfg"))
 			bg.Return()
 		})
@@ -59,6 +60,14 @@ func (tr *Transport) renderClientJsonRPC(outDir string) (err error) {
 			)
 		}
 	}
+	srcFile.Line().Comment("Health fails, while circuit breaker of client is open, e.g. for readiness probe of service, which uses client.")
+	srcFile.Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("Health").Params(Id("_").Qual(packageContext, "Context")).Params(Error()).Block(
+		Line(),
+		If(Id("cli").Dot("cb").Dot("State").Call().Op("==").Qual(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "StateOpen")).Block(
+			Return(Qual(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "ErrOpenState")),
+		),
+		Return(Nil()),
+	)
 	srcFile.Line().Add(tr.jsonrpcClientProceedResponseFunc(outDir))
 	return srcFile.Save(path.Join(outDir, "jsonrpc.go"))
 }
@@ -70,6 +79,7 @@ func (tr *Transport) jsonrpcClientProceedResponseFunc(outDir string) Code {
 		Id("proceedResponse").
 		Params(
 			Id(_ctx_).Qual(packageContext, "Context"),
+			Id("method").String(),
 			Id("callMethod").Func().Params(Id("request").Any()).Params(Id("response").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "ResponseRPC"), Err().Error()),
 			Id("request").Any(),
 			Id("fallbackCheck").Func().Params(Error()).Bool(),
@@ -78,6 +88,7 @@ func (tr *Transport) jsonrpcClientProceedResponseFunc(outDir string) Code {
 
 		bg.Line()
 		bg.List(Id("cacheKey"), Id("_")).Op(":=").Qual(fmt.Sprintf("%s/hasher", tr.pkgPath(outDir)), "Hash").Call(Id("request"))
+		bg.Var().Id("rejected").Bool()
 		bg.Err().Op("=").Id("cli").Dot("cb").Dot("Execute").CallFunc(func(cg *Group) {
 			cg.Func().Params().Params(Err().Error()).Block(
 				Var().Id("rpcResponse").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "ResponseRPC"),
@@ -108,15 +119,21 @@ func (tr *Transport) jsonrpcClientProceedResponseFunc(outDir string) Code {
 			)
 			cg.Id("cb").Dot("Fallback").Call(
 				Func().Params(Err().Error()).Params(Error()).Block(
+					Id("rejected").Op("=").Qual("errors", "Is").Call(Err(), Qual(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "ErrOpenState")),
 					If(Id("cli").Dot("cache").Op("!=").Nil().Op("&&").Id("cacheKey").Op("!=").Lit(0)).Block(
-						List(Id("_"), Id("_"), Err()).Op("=").Id("cli").Dot("cache").Dot("GetTTL").Call(
+						If(List(Id("_"), Id("_"), Err()).Op("=").Id("cli").Dot("cache").Dot("GetTTL").Call(
 							Id(_ctx_), Qual(packageStrconv, "FormatUint").Call(Id("cacheKey"), Lit(10)), Op("&").Id("methodResponse"),
+						).Op(";").Err().Op("==").Nil()).Block(
+							Id("cli").Dot("metrics").Dot("fallbackHit").Call(Id("method")),
 						),
 					),
 					Return(Err()),
 				),
 			)
 		})
+		bg.If(Id("rejected").Op("||").Qual("errors", "Is").Call(Err(), Qual(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "ErrTooManyRequests"))).Block(
+			Id("cli").Dot("metrics").Dot("rejected").Call(Id("method")),
+		)
 		bg.Return()
 	})
 }
@@ -139,5 +156,6 @@ func (tr *Transport) jsonrpcClientStructFunc(outDir string) Code {
 			}
 		}
 		sg.Line().Id("errorDecoder").Id("ErrorDecoder")
+		sg.Id("metrics").Op("*").Id("ClientMetrics")
 	})
 }
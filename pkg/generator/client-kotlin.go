@@ -0,0 +1,306 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true, "false": true, "for": true,
+	"fun": true, "if": true, "in": true, "interface": true, "is": true, "null": true, "object": true, "package": true,
+	"return": true, "super": true, "this": true, "throw": true, "true": true, "try": true, "typealias": true,
+	"typeof": true, "val": true, "var": true, "when": true, "while": true,
+}
+
+var notKotlinPackage = regexp.MustCompile(`[^a-z0-9]+`)
+
+// clientKotlin walks types by TS client and renders them as kotlinx-serialization classes.
+type clientKotlin struct {
+	*clientTS
+	usesTime bool
+}
+
+// SetKotlinPackage sets package of Kotlin client, by default it is built from module path.
+func (tr *Transport) SetKotlinPackage(name string) {
+	tr.kotlinPackage = name
+}
+
+func (tr *Transport) RenderClientKotlin(outDir string) (err error) {
+	return newClientKotlin(tr).render(outDir)
+}
+
+func newClientKotlin(tr *Transport) (kt *clientKotlin) {
+	return &clientKotlin{clientTS: newClientTS(tr)}
+}
+
+func (kt *clientKotlin) render(outDir string) (err error) {
+
+	if err = os.MkdirAll(outDir, 0777); err != nil {
+		return
+	}
+	header := fmt.Sprintf("// %s\npackage %s\n\n", doNotEdit, kt.packageName())
+	if err = clientCopyTo("kotlin", outDir, header); err != nil {
+		return
+	}
+	var services []*service
+	for _, name := range kt.serviceKeys() {
+		if svc := kt.services[name]; svc.isJsonRPC() {
+			services = append(services, svc)
+		}
+	}
+	var body, models bytesWriter
+	for _, svc := range services {
+		kt.renderService(&body, svc)
+	}
+	kt.renderClient(&body, services)
+	for _, name := range kt.structNames() {
+		def := kt.structDef(name)
+		if len(def.properties) == 0 {
+			models.add("\n@Serializable\nclass %s\n", def.name)
+			continue
+		}
+		models.add("\n@Serializable\ndata class %s(\n", def.name)
+		for _, fieldName := range def.propertyNames() {
+			property := def.properties[fieldName]
+			kt.renderField(&models, fieldName, property, property.tags)
+		}
+		models.add(")\n")
+	}
+	var clientFile bytesWriter
+	clientFile.add("%s%s", header, kt.imports("kotlinx.serialization.json.Json", "kotlinx.serialization.json.buildJsonObject"))
+	clientFile.add("%s", body.String())
+	if err = generated.WriteFile(path.Join(outDir, "Client.kt"), clientFile.Bytes(), 0600); err != nil {
+		return
+	}
+	var modelsFile bytesWriter
+	modelsFile.add("%s%s", header, kt.imports())
+	modelsFile.add("%s", models.String())
+	return generated.WriteFile(path.Join(outDir, "Models.kt"), modelsFile.Bytes(), 0600)
+}
+
+func (kt *clientKotlin) imports(extra ...string) string {
+
+	imports := []string{"kotlinx.serialization.SerialName", "kotlinx.serialization.Serializable", "kotlinx.serialization.json.JsonElement"}
+	if kt.usesTime {
+		imports = append(imports, "kotlinx.datetime.Instant")
+	}
+	imports = append(imports, extra...)
+	return "import " + strings.Join(imports, "\nimport ") + "\n"
+}
+
+func (kt *clientKotlin) renderService(ktFile *bytesWriter, svc *service) {
+
+	var methods []*method
+	for _, method := range svc.methods {
+		if method.isActual() && method.isJsonRPC() {
+			methods = append(methods, method)
+		}
+	}
+	for _, method := range methods {
+		if results := method.resultsWithoutError(); len(results) > 1 {
+			ktFile.add("\n@Serializable\ndata class %s(\n", kt.resultName(svc, method))
+			for _, ret := range results {
+				kt.renderField(ktFile, ret.Name, kt.walkVariable(ret.Name, svc.pkgPath, ret.Type, method.tags), method.tags.Sub(ret.Name))
+			}
+			ktFile.add(")\n")
+		}
+	}
+	ktFile.add("\n")
+	if desc := svc.tags.Value(tagDesc); desc != "" {
+		ktFile.add("/** %s */\n", kotlinDoc(desc))
+	}
+	ktFile.add("class %sClient(private val rpc: JsonRpcClient) {\n", svc.Name)
+	for _, method := range methods {
+		var args, params []string
+		for _, arg := range method.argsWithoutContext() {
+			argType, _ := kt.varType(svc, method, arg)
+			args = append(args, fmt.Sprintf("%s: %s", kotlinName(arg.Name), argType))
+			params = append(params, fmt.Sprintf("            put(%q, rpc.encode(%s))\n", arg.Name, kotlinName(arg.Name)))
+		}
+		ktFile.add("\n")
+		kt.renderMethodDoc(ktFile, method)
+		call := fmt.Sprintf("rpc.call(%q, buildJsonObject {\n%s        })", method.jsonrpcName(), strings.Join(params, ""))
+		if len(params) == 0 {
+			call = fmt.Sprintf("rpc.call(%q, buildJsonObject {})", method.jsonrpcName())
+		}
+		switch results := method.resultsWithoutError(); len(results) {
+		case 0:
+			ktFile.add("    suspend fun %s(%s) {\n", kotlinName(method.Name), strings.Join(args, ", "))
+			ktFile.add("        %s\n", call)
+		case 1:
+			retType, zero := kt.varType(svc, method, results[0])
+			ktFile.add("    suspend fun %s(%s): %s {\n", kotlinName(method.Name), strings.Join(args, ", "), retType)
+			ktFile.add("        val result = %s\n", call)
+			ktFile.add("        return rpc.decode<%s>(result[%q], %s)\n", retType, results[0].Name, zero)
+		default:
+			ktFile.add("    suspend fun %s(%s): %s {\n", kotlinName(method.Name), strings.Join(args, ", "), kt.resultName(svc, method))
+			ktFile.add("        val result = %s\n", call)
+			ktFile.add("        return rpc.decode(result, %s())\n", kt.resultName(svc, method))
+		}
+		ktFile.add("    }\n")
+	}
+	ktFile.add("}\n")
+}
+
+func (kt *clientKotlin) renderClient(ktFile *bytesWriter, services []*service) {
+
+	ktFile.add("\n/** Client of JSON-RPC services. */\n")
+	ktFile.add("class Client(transport: Transport, json: Json = JsonRpcClient.defaultJson) {\n\n")
+	ktFile.add("    constructor(endpoint: String, headers: () -> Map<String, String> = { emptyMap() }) : this(HttpTransport(endpoint, headers))\n\n")
+	ktFile.add("    private val rpc = JsonRpcClient(transport, json)\n")
+	for _, svc := range services {
+		ktFile.add("    val %s = %sClient(rpc)\n", kotlinName(svc.Name), svc.Name)
+	}
+	ktFile.add("}\n")
+}
+
+func (kt *clientKotlin) renderMethodDoc(ktFile *bytesWriter, method *method) {
+
+	var lines []string
+	if desc := method.tags.Value(tagDesc, method.tags.Value(tagSummary)); desc != "" {
+		lines = append(lines, kotlinDoc(desc))
+	}
+	for _, arg := range method.argsWithoutContext() {
+		if desc := method.tags.Sub(arg.Name).Value(tagDesc); desc != "" {
+			lines = append(lines, fmt.Sprintf("@param %s %s", kotlinName(arg.Name), kotlinDoc(desc)))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	ktFile.add("    /**\n")
+	for _, line := range lines {
+		ktFile.add("     * %s\n", line)
+	}
+	ktFile.add("     */\n")
+}
+
+// renderField adds property of data class with zero value of Go type by default.
+func (kt *clientKotlin) renderField(ktFile *bytesWriter, jsonName string, def typeDefTs, fieldTags tags.DocTags) {
+
+	fieldType, zero := kt.fieldType(def)
+	if desc := fieldTags.Value(tagDesc); desc != "" {
+		ktFile.add("    /** %s */\n", kotlinDoc(desc))
+	}
+	var serialName string
+	if name := kotlinName(jsonName); name != jsonName {
+		serialName = fmt.Sprintf("@SerialName(%q) ", jsonName)
+	}
+	ktFile.add("    %sval %s: %s = %s,\n", serialName, kotlinName(jsonName), fieldType, zero)
+}
+
+func (kt *clientKotlin) resultName(svc *service, method *method) string {
+	return svc.Name + method.Name + "Result"
+}
+
+func (kt *clientKotlin) varType(svc *service, method *method, variable types.Variable) (typeName, zero string) {
+	return kt.fieldType(kt.walkVariable(variable.Name, svc.pkgPath, variable.Type, method.tags))
+}
+
+// fieldType returns type and its zero value. Types without zero value are nullable.
+func (kt *clientKotlin) fieldType(def typeDefTs) (typeName, zero string) {
+
+	typeName = kt.typeLink(def)
+	switch {
+	case def.nullable:
+	case typeName == "Long":
+		return typeName, "0L"
+	case typeName == "Int":
+		return typeName, "0"
+	case typeName == "Double":
+		return typeName, "0.0"
+	case typeName == "Boolean":
+		return typeName, "false"
+	case typeName == "String":
+		return typeName, `""`
+	case kt.structDef(typeName).kind == "struct":
+		return typeName, typeName + "()"
+	}
+	return typeName + "?", "null"
+}
+
+func (kt *clientKotlin) typeLink(def typeDefTs) string {
+
+	switch def.kind {
+	case "map":
+		return fmt.Sprintf("Map<String, %s>", kt.typeLink(def.properties["value"]))
+	case "array":
+		for _, item := range def.properties {
+			return fmt.Sprintf("List<%s>", kt.typeLink(item))
+		}
+		return "List<JsonElement>"
+	case "struct":
+		return def.name
+	case "scalar":
+		if def.origin != "" {
+			return kt.castType(def.origin)
+		}
+		if typeName := kt.castType(def.typeName); typeName != "JsonElement" {
+			return typeName
+		}
+		if next, found := kt.typeDefTs[def.typeName]; found && next.typeName != def.typeName {
+			return kt.typeLink(next)
+		}
+		return "JsonElement"
+	default:
+		return kt.castType(def.kind)
+	}
+}
+
+func (kt *clientKotlin) castType(originName string) (typeName string) {
+
+	switch originName {
+	case "bool", "boolean":
+		return "Boolean"
+	case "int", "int64", "uint", "uint32", "uint64", "time.Duration":
+		return "Long"
+	case "byte", "rune", "int8", "int16", "int32", "uint8", "uint16":
+		return "Int"
+	case "float32", "float64", "number":
+		return "Double"
+	case "string", "[]byte":
+		return "String"
+	case "time.Time", "gorm.DeletedAt", "Date":
+		kt.usesTime = true
+		return "Instant"
+	}
+	switch {
+	case strings.HasSuffix(originName, "NullTime"):
+		kt.usesTime = true
+		return "Instant"
+	case strings.HasSuffix(originName, "UUID"):
+		return "String"
+	case strings.HasSuffix(originName, "Decimal"):
+		return "Double"
+	}
+	return "JsonElement"
+}
+
+// packageName returns package of Kotlin client.
+func (kt *clientKotlin) packageName() string {
+
+	if kt.kotlinPackage != "" {
+		return kt.kotlinPackage
+	}
+	return strings.Trim(notKotlinPackage.ReplaceAllString(strings.ToLower(kt.module.Module.Mod.String()), "."), ".")
+}
+
+// kotlinName returns lowerCamelCase name, escaped when it is a keyword of Kotlin.
+func kotlinName(name string) string {
+
+	if name = utils.ToLowerCamel(name); kotlinKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+func kotlinDoc(text string) string {
+	return strings.ReplaceAll(text, "*/", "*&#47;")
+}
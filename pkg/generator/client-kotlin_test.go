@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderClientKotlin(t *testing.T) {
+
+	tr, err := NewTransport(testLog(), "test", "testdata/redact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.SetKotlinPackage("com.example.users")
+	outDir := filepath.Join(t.TempDir(), "kotlin")
+	if err = tr.RenderClientKotlin(outDir); err != nil {
+		t.Fatal(err)
+	}
+	files := readFiles(t, outDir)
+	assertContains(t, files, "Client.kt",
+		"package com.example.users\n",
+		`suspend fun create(user: User, token: String): Long {`,
+		`val result = rpc.call("users.create", buildJsonObject {`,
+		`put("token", rpc.encode(token))`,
+		`return rpc.decode<Long>(result["id"], 0L)`,
+		`val users = UsersClient(rpc)`,
+	)
+	assertContains(t, files, "Models.kt",
+		`@SerialName("Email") val email: String = "",`,
+		`@SerialName("Friends") val friends: List<User>? = null,`,
+		`@SerialName("Password") val password: Password = Password(),`,
+	)
+	assertContains(t, files, "JsonRpc.kt",
+		"package com.example.users\n",
+		`coerceInputValues = true`,
+	)
+}
@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) renderClientMetrics(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	pkgCB := fmt.Sprintf("%s/cb", tr.pkgPath(outDir))
+	srcFile.ImportName(pkgCB, "cb")
+	srcFile.ImportName("errors", "errors")
+	srcFile.ImportName(packagePrometheus, "prometheus")
+
+	srcFile.Line().Comment("ClientMetrics records requests, latency, retries, fallback cache hits and circuit breaker rejections")
+	srcFile.Comment("of client calls by 'service.method'.")
+	srcFile.Type().Id("ClientMetrics").Struct(
+		Id("requests").Op("*").Qual(packagePrometheus, "CounterVec"),
+		Id("latency").Op("*").Qual(packagePrometheus, "HistogramVec"),
+		Id("retries").Op("*").Qual(packagePrometheus, "CounterVec"),
+		Id("fallbacks").Op("*").Qual(packagePrometheus, "CounterVec"),
+		Id("rejections").Op("*").Qual(packagePrometheus, "CounterVec"),
+	)
+
+	counter := func(name, help string, labels ...string) Code {
+		return Qual(packagePrometheus, "NewCounterVec").Call(Qual(packagePrometheus, "CounterOpts").Values(Dict{
+			Id("Namespace"): Id("namespace"),
+			Id("Subsystem"): Lit("client"),
+			Id("Name"):      Lit(name),
+			Id("Help"):      Lit(help),
+		}), Index().String().ValuesFunc(func(g *Group) {
+			for _, label := range labels {
+				g.Lit(label)
+			}
+		}))
+	}
+	register := func(field string, collector Code) Code {
+		return If(List(Id("m").Dot(field), Err()).Op("=").Id("registerCollector").Call(Id("registerer"), collector).Op(";").Err().Op("!=").Nil()).Block(
+			Return(Nil(), Err()),
+		)
+	}
+	srcFile.Line().Comment("NewClientMetrics registers client metrics on registerer. Buckets of latency histogram are in seconds,")
+	srcFile.Comment("prometheus.DefBuckets by default. Metrics already registered by other client are shared with it.")
+	srcFile.Func().Id("NewClientMetrics").
+		Params(Id("registerer").Qual(packagePrometheus, "Registerer"), Id("namespace").String(), Id("buckets").Op("...").Float64()).
+		Params(Id("m").Op("*").Id("ClientMetrics"), Err().Error()).Block(
+		Line(),
+		If(Len(Id("buckets")).Op("==").Lit(0)).Block(
+			Id("buckets").Op("=").Qual(packagePrometheus, "DefBuckets"),
+		),
+		Id("m").Op("=").Op("&").Id("ClientMetrics").Values(),
+		register("requests", counter("requests_total", "Number of client requests", "method", "success", "errCode")),
+		register("latency", Qual(packagePrometheus, "NewHistogramVec").Call(Qual(packagePrometheus, "HistogramOpts").Values(Dict{
+			Id("Namespace"): Id("namespace"),
+			Id("Subsystem"): Lit("client"),
+			Id("Name"):      Lit("request_duration_seconds"),
+			Id("Help"):      Lit("Duration of client requests in seconds"),
+			Id("Buckets"):   Id("buckets"),
+		}), Index().String().Values(Lit("method"), Lit("success")))),
+		register("retries", counter("retries_total", "Number of retries of client requests", "method")),
+		register("fallbacks", counter("fallback_hits_total", "Number of responses taken from fallback cache", "method")),
+		register("rejections", counter("breaker_rejections_total", "Number of requests rejected by circuit breaker", "method")),
+		Return(),
+	)
+
+	srcFile.Line().Func().Id("registerCollector").Types(Id("C").Qual(packagePrometheus, "Collector")).
+		Params(Id("registerer").Qual(packagePrometheus, "Registerer"), Id("collector").Id("C")).Params(Id("C"), Error()).Block(
+		Line(),
+		If(Err().Op(":=").Id("registerer").Dot("Register").Call(Id("collector")).Op(";").Err().Op("!=").Nil()).Block(
+			Var().Id("registered").Qual(packagePrometheus, "AlreadyRegisteredError"),
+			If(Qual("errors", "As").Call(Err(), Op("&").Id("registered"))).Block(
+				If(List(Id("existing"), Id("ok")).Op(":=").Id("registered").Dot("ExistingCollector").Assert(Id("C")).Op(";").Id("ok")).Block(
+					Return(Id("existing"), Nil()),
+				),
+			),
+			Return(Id("collector"), Err()),
+		),
+		Return(Id("collector"), Nil()),
+	)
+
+	srcFile.Line().Comment("Observe records call of method, code is recorded for failed calls only.")
+	srcFile.Comment("Calls rejected by circuit breaker are counted as rejections only.")
+	srcFile.Func().Params(Id("m").Op("*").Id("ClientMetrics")).Id("Observe").
+		Params(Id("_").Qual(packageContext, "Context"), Id("method").String(), Id("duration").Qual(packageTime, "Duration"), Id("code").Int(), Err().Error()).Block(
+		Line(),
+		If(Id("m").Op("==").Nil()).Block(
+			Return(),
+		),
+		If(Qual("errors", "Is").Call(Err(), Qual(pkgCB, "ErrOpenState")).Op("||").Qual("errors", "Is").Call(Err(), Qual(pkgCB, "ErrTooManyRequests"))).Block(
+			Id("m").Dot("rejections").Dot("WithLabelValues").Call(Id("method")).Dot("Inc").Call(),
+			Return(),
+		),
+		If(Err().Op("==").Nil()).Block(
+			Id("code").Op("=").Lit(0),
+		),
+		Id("success").Op(":=").Qual(packageStrconv, "FormatBool").Call(Err().Op("==").Nil()),
+		Id("m").Dot("requests").Dot("WithLabelValues").Call(Id("method"), Id("success"), Qual(packageStrconv, "Itoa").Call(Id("code"))).Dot("Inc").Call(),
+		Id("m").Dot("latency").Dot("WithLabelValues").Call(Id("method"), Id("success")).Dot("Observe").Call(Id("duration").Dot("Seconds").Call()),
+	)
+
+	srcFile.Line().Comment("Retry records repeated attempt of call of method.")
+	srcFile.Func().Params(Id("m").Op("*").Id("ClientMetrics")).Id("Retry").
+		Params(Id("_").Qual(packageContext, "Context"), Id("method").String(), Id("_").Int()).Block(
+		Line(),
+		If(Id("m").Op("==").Nil()).Block(
+			Return(),
+		),
+		Id("m").Dot("retries").Dot("WithLabelValues").Call(Id("method")).Dot("Inc").Call(),
+	)
+
+	srcFile.Line().Func().Params(Id("m").Op("*").Id("ClientMetrics")).Id("fallbackHit").Params(Id("method").String()).Block(
+		Line(),
+		If(Id("m").Op("==").Nil()).Block(
+			Return(),
+		),
+		Id("m").Dot("fallbacks").Dot("WithLabelValues").Call(Id("method")).Dot("Inc").Call(),
+	)
+
+	srcFile.Line().Func().Params(Id("m").Op("*").Id("ClientMetrics")).Id("rejected").Params(Id("method").String()).Block(
+		Line(),
+		If(Id("m").Op("==").Nil()).Block(
+			Return(),
+		),
+		Id("m").Dot("rejections").Dot("WithLabelValues").Call(Id("method")).Dot("Inc").Call(),
+	)
+	return srcFile.Save(path.Join(outDir, "metrics.go"))
+}
@@ -0,0 +1,31 @@
+package generator
+
+import "testing"
+
+func TestRenderClientMetrics(t *testing.T) {
+
+	files := renderClient(t, "testdata/trace")
+	assertContains(t, files, "metrics.go",
+		`func NewClientMetrics(registerer prometheus.Registerer, namespace string, buckets ...float64) (m *ClientMetrics, err error) {`,
+		`Name:      "requests_total",`,
+		`Name:      "request_duration_seconds",`,
+		`Name:      "retries_total",`,
+		`Name:      "fallback_hits_total",`,
+		`Name:      "breaker_rejections_total",`,
+		`var registered prometheus.AlreadyRegisteredError`,
+		`if errors.Is(err, cb.ErrOpenState) || errors.Is(err, cb.ErrTooManyRequests) {`,
+	)
+	assertContains(t, files, "options.go",
+		`func Metrics(metrics *ClientMetrics) Option {`,
+		`cli.rpcOpts = append(cli.rpcOpts, jsonrpc.WithObserver(metrics))`,
+	)
+	assertContains(t, files, "http-options.go",
+		`func MetricsHTTP(metrics *ClientMetrics) httpclient.Option {`,
+		`return httpclient.WithObserver(metrics)`,
+	)
+	assertContains(t, files, "jsonrpc.go",
+		`cli.metrics.fallbackHit(method)`,
+		`if rejected || errors.Is(err, cb.ErrTooManyRequests) {`,
+		`cli.metrics.rejected(method)`,
+	)
+}
@@ -79,5 +79,22 @@ func (tr *Transport) renderClientOptions(outDir string) (err error) {
 			Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "LogOnError").Call()),
 		),
 	)
+	srcFile.Line().Func().Id("TracerProvider").Params(Id("provider").Qual(packageTrace, "TracerProvider")).Params(Id("Option")).Block(
+		Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+			Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "TracerProvider").Call(Id("provider"))),
+		),
+	)
+	srcFile.Line().Func().Id("Propagator").Params(Id("propagator").Qual(packagePropagation, "TextMapPropagator")).Params(Id("Option")).Block(
+		Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+			Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "Propagator").Call(Id("propagator"))),
+		),
+	)
+	srcFile.Line().Comment("Metrics records metrics of calls, fallback cache hits and circuit breaker rejections.")
+	srcFile.Func().Id("Metrics").Params(Id("metrics").Op("*").Id("ClientMetrics")).Params(Id("Option")).Block(
+		Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+			Id("cli").Dot("metrics").Op("=").Id("metrics"),
+			Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "WithObserver").Call(Id("metrics"))),
+		),
+	)
 	return srcFile.Save(path.Join(outDir, "options.go"))
 }
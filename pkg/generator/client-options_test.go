@@ -0,0 +1,24 @@
+package generator
+
+import "testing"
+
+func TestRenderClientTracing(t *testing.T) {
+
+	files := renderClient(t, "testdata/trace")
+	assertContains(t, files, "options.go",
+		`func TracerProvider(provider trace.TracerProvider) Option {`,
+		`cli.rpcOpts = append(cli.rpcOpts, jsonrpc.TracerProvider(provider))`,
+		`func Propagator(propagator propagation.TextMapPropagator) Option {`,
+	)
+	// request of batch is linked to span of caller
+	assertContains(t, files, "orders-jsonrpc.go", `}).WithContext(ctx)}`)
+	// span of HTTP call is named by method of service
+	assertContains(t, files, "orders-http-client.go",
+		`http.NewRequestWithContext(httpclient.WithOperation(ctx, "orders.get"), "GET"`,
+	)
+	for _, fileName := range []string{"jsonrpc/tracing_test.go", "httpclient/tracing_test.go"} {
+		if _, found := files[fileName]; found {
+			t.Errorf("test %s is copied to client", fileName)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+const pyHeader = `# ` + doNotEdit + `
+from __future__ import annotations
+
+import dataclasses
+import datetime
+from typing import Any, Dict, List, Optional
+`
+
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true, "await": true,
+	"break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true, "else": true, "except": true,
+	"finally": true, "for": true, "from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true, "return": true, "try": true,
+	"while": true, "with": true, "yield": true, "self": true,
+}
+
+// clientPy walks types by TS client and renders them as Python dataclasses.
+type clientPy struct {
+	*clientTS
+}
+
+func (tr *Transport) RenderClientPy(outDir string) (err error) {
+	return newClientPy(tr).render(outDir)
+}
+
+func newClientPy(tr *Transport) (py *clientPy) {
+	return &clientPy{clientTS: newClientTS(tr)}
+}
+
+func (py *clientPy) render(outDir string) (err error) {
+
+	if err = os.MkdirAll(outDir, 0777); err != nil {
+		return
+	}
+	if err = clientCopyTo("py", outDir, ""); err != nil {
+		return
+	}
+	var services []*service
+	for _, name := range py.serviceKeys() {
+		if svc := py.services[name]; svc.isJsonRPC() {
+			services = append(services, svc)
+		}
+	}
+	var body bytesWriter
+	for _, svc := range services {
+		py.renderService(&body, svc)
+	}
+	py.renderClient(&body, services)
+	models := py.structNames()
+	var clientFile bytesWriter
+	clientFile.add(pyHeader)
+	clientFile.add("\nfrom .jsonrpc import RpcBatch, RpcClient, Transport, encode, result_as, result_of\n")
+	if len(models) != 0 {
+		clientFile.add("from .models import %s\n", strings.Join(models, ", "))
+	}
+	clientFile.add("%s", body.String())
+	if err = generated.WriteFile(path.Join(outDir, "client.py"), clientFile.Bytes(), 0600); err != nil {
+		return
+	}
+	if err = py.renderModels(outDir, models); err != nil {
+		return
+	}
+	var initFile bytesWriter
+	initFile.add("# %s\n", doNotEdit)
+	clients := []string{"Batch", "Client"}
+	for _, svc := range services {
+		clients = append(clients, svc.Name+"Client")
+	}
+	initFile.add("from .client import %s\n", strings.Join(clients, ", "))
+	initFile.add("from .jsonrpc import BatchCall, HttpTransport, InternalError, InvalidParamsError, InvalidRequestError, MethodNotFoundError, ParseError, RpcError, ServerError, TransportError\n")
+	if len(models) != 0 {
+		initFile.add("from .models import %s\n", strings.Join(models, ", "))
+	}
+	return generated.WriteFile(path.Join(outDir, "__init__.py"), initFile.Bytes(), 0600)
+}
+
+func (py *clientPy) renderService(pyFile *bytesWriter, svc *service) {
+
+	var methods []*method
+	for _, method := range svc.methods {
+		if method.isActual() && method.isJsonRPC() {
+			methods = append(methods, method)
+		}
+	}
+	for _, method := range methods {
+		if results := method.resultsWithoutError(); len(results) > 1 {
+			pyFile.add("\n\n@dataclasses.dataclass\nclass %s:\n", py.resultName(svc, method))
+			for _, ret := range results {
+				py.renderField(pyFile, ret.Name, py.walkVariable(ret.Name, svc.pkgPath, ret.Type, method.tags), method.tags.Sub(ret.Name))
+			}
+		}
+	}
+	pyFile.add("\n\nclass %sClient:\n", svc.Name)
+	if desc := svc.tags.Value(tagDesc); desc != "" {
+		pyFile.add("    \"\"\"%s\"\"\"\n\n", pyDoc(desc))
+	}
+	pyFile.add("    def __init__(self, caller: Any):\n")
+	pyFile.add("        self._caller = caller\n")
+	for _, method := range methods {
+		var args, params []string
+		for _, arg := range method.argsWithoutContext() {
+			argName := pyName(arg.Name)
+			args = append(args, fmt.Sprintf("%s: %s", argName, py.varType(svc, method, arg)))
+			params = append(params, fmt.Sprintf("%q: encode(%s)", arg.Name, argName))
+		}
+		var returns, decoder string
+		switch results := method.resultsWithoutError(); len(results) {
+		case 0:
+			returns, decoder = "None", "result_as(Any)"
+		case 1:
+			returns = py.varType(svc, method, results[0])
+			decoder = fmt.Sprintf("result_of(%s, %q)", returns, results[0].Name)
+		default:
+			returns = py.resultName(svc, method)
+			decoder = fmt.Sprintf("result_as(%s)", returns)
+		}
+		pyFile.add("\n    def %s(self%s) -> %s:\n", pyName(method.Name), strings.Join(append([]string{""}, args...), ", "), returns)
+		if doc := py.methodDoc(method); doc != "" {
+			pyFile.add("        \"\"\"%s\"\"\"\n", doc)
+		}
+		pyFile.add("        return self._caller.call(%q, {%s}, %s)\n", method.jsonrpcName(), strings.Join(params, ", "), decoder)
+	}
+}
+
+func (py *clientPy) renderClient(pyFile *bytesWriter, services []*service) {
+
+	pyFile.add("\n\nclass Client(RpcClient):\n")
+	pyFile.add("    \"\"\"Client of JSON-RPC services.\"\"\"\n\n")
+	pyFile.add("    def __init__(self, url: str = \"\", headers: Optional[Dict[str, str]] = None, timeout: float = 30.0,\n")
+	pyFile.add("                 transport: Optional[Transport] = None):\n")
+	pyFile.add("        super().__init__(url, headers, timeout, transport)\n")
+	for _, svc := range services {
+		pyFile.add("        self.%s = %sClient(self)\n", pyName(svc.Name), svc.Name)
+	}
+	pyFile.add("\n    def batch(self) -> Batch:\n")
+	pyFile.add("        \"\"\"Returns batch, its methods return BatchCall and are sent by execute() or on exit of with block.\"\"\"\n")
+	pyFile.add("        return Batch(self.transport)\n")
+	pyFile.add("\n\nclass Batch(RpcBatch):\n\n")
+	pyFile.add("    def __init__(self, transport: Transport):\n")
+	pyFile.add("        super().__init__(transport)\n")
+	for _, svc := range services {
+		pyFile.add("        self.%s = %sClient(self)\n", pyName(svc.Name), svc.Name)
+	}
+}
+
+func (py *clientPy) renderModels(outDir string, models []string) (err error) {
+
+	var modelsFile bytesWriter
+	modelsFile.add(pyHeader)
+	for _, name := range models {
+		def := py.structDef(name)
+		modelsFile.add("\n\n@dataclasses.dataclass\nclass %s:\n", def.name)
+		if len(def.properties) == 0 {
+			modelsFile.add("    pass\n")
+		}
+		for _, fieldName := range def.propertyNames() {
+			property := def.properties[fieldName]
+			py.renderField(&modelsFile, fieldName, property, property.tags)
+		}
+	}
+	return generated.WriteFile(path.Join(outDir, "models.py"), modelsFile.Bytes(), 0600)
+}
+
+// renderField adds field of dataclass with zero value of Go type by default.
+func (py *clientPy) renderField(pyFile *bytesWriter, jsonName string, def typeDefTs, fieldTags tags.DocTags) {
+
+	fieldType := py.typeLink(def)
+	var value string
+	switch {
+	case def.nullable:
+		fieldType, value = pyOptional(fieldType), "None"
+	case fieldType == "int":
+		value = "0"
+	case fieldType == "float":
+		value = "0.0"
+	case fieldType == "str":
+		value = `""`
+	case fieldType == "bool":
+		value = "False"
+	case strings.HasPrefix(fieldType, "List["):
+		value = "dataclasses.field(default_factory=list)"
+	case strings.HasPrefix(fieldType, "Dict["):
+		value = "dataclasses.field(default_factory=dict)"
+	default:
+		fieldType, value = pyOptional(fieldType), "None"
+	}
+	name := pyName(jsonName)
+	if name != jsonName {
+		if strings.HasPrefix(value, "dataclasses.field(") {
+			value = fmt.Sprintf("%s, metadata={\"json\": %q})", strings.TrimSuffix(value, ")"), jsonName)
+		} else {
+			value = fmt.Sprintf("dataclasses.field(default=%s, metadata={\"json\": %q})", value, jsonName)
+		}
+	}
+	pyFile.add("    %s: %s = %s\n", name, fieldType, value)
+	if desc := fieldTags.Value(tagDesc); desc != "" {
+		pyFile.add("    \"\"\"%s\"\"\"\n", pyDoc(desc))
+	}
+}
+
+func (py *clientPy) resultName(svc *service, method *method) string {
+	return svc.Name + method.Name + "Result"
+}
+
+func (py *clientPy) varType(svc *service, method *method, variable types.Variable) string {
+
+	def := py.walkVariable(variable.Name, svc.pkgPath, variable.Type, method.tags)
+	if def.nullable {
+		return pyOptional(py.typeLink(def))
+	}
+	return py.typeLink(def)
+}
+
+// methodDoc returns docstring of method by its desc and desc of its arguments.
+func (py *clientPy) methodDoc(method *method) string {
+
+	var lines []string
+	if desc := method.tags.Value(tagDesc, method.tags.Value(tagSummary)); desc != "" {
+		lines = append(lines, pyDoc(desc))
+	}
+	var args []string
+	for _, arg := range method.argsWithoutContext() {
+		if desc := method.tags.Sub(arg.Name).Value(tagDesc); desc != "" {
+			args = append(args, fmt.Sprintf("            %s: %s", pyName(arg.Name), pyDoc(desc)))
+		}
+	}
+	if len(args) != 0 {
+		if len(lines) != 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "        Args:")
+		lines = append(lines, args...)
+		lines = append(lines, "        ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (py *clientPy) typeLink(def typeDefTs) string {
+
+	switch def.kind {
+	case "map":
+		return fmt.Sprintf("Dict[str, %s]", py.typeLink(def.properties["value"]))
+	case "array":
+		for _, item := range def.properties {
+			return fmt.Sprintf("List[%s]", py.typeLink(item))
+		}
+		return "List[Any]"
+	case "struct":
+		return def.name
+	case "scalar":
+		if def.origin != "" {
+			return castTypePy(def.origin)
+		}
+		if typeName := castTypePy(def.typeName); typeName != "Any" {
+			return typeName
+		}
+		if next, found := py.typeDefTs[def.typeName]; found && next.typeName != def.typeName {
+			return py.typeLink(next)
+		}
+		return "Any"
+	default:
+		return castTypePy(def.kind)
+	}
+}
+
+func castTypePy(originName string) (typeName string) {
+
+	switch originName {
+	case "bool", "boolean":
+		return "bool"
+	case "byte", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "time.Duration":
+		return "int"
+	case "float32", "float64", "number":
+		return "float"
+	case "string", "[]byte", "rune":
+		return "str"
+	case "time.Time", "gorm.DeletedAt", "Date":
+		return "datetime.datetime"
+	}
+	switch {
+	case strings.HasSuffix(originName, "NullTime"):
+		return "datetime.datetime"
+	case strings.HasSuffix(originName, "UUID"):
+		return "str"
+	case strings.HasSuffix(originName, "Decimal"):
+		return "float"
+	}
+	return "Any"
+}
+
+func pyOptional(typeName string) string {
+
+	if typeName == "Any" || strings.HasPrefix(typeName, "Optional[") {
+		return typeName
+	}
+	return fmt.Sprintf("Optional[%s]", typeName)
+}
+
+// pyName returns snake_case name, which is not a keyword of Python.
+func pyName(name string) string {
+
+	if name = utils.ToSnake(name); pyKeywords[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// pyDoc escapes text for docstring.
+func pyDoc(text string) string {
+
+	text = strings.ReplaceAll(strings.ReplaceAll(text, `\`, `\\`), `"""`, `\"\"\"`)
+	if strings.HasSuffix(text, `"`) {
+		text = strings.TrimSuffix(text, `"`) + `\"`
+	}
+	return text
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderClientPy(t *testing.T) {
+
+	tr, err := NewTransport(testLog(), "test", "testdata/redact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "py")
+	if err = tr.RenderClientPy(outDir); err != nil {
+		t.Fatal(err)
+	}
+	files := readFiles(t, outDir)
+	assertContains(t, files, "client.py",
+		`from .models import Password, User`,
+		`def create(self, user: User, token: str) -> int:`,
+		`return self._caller.call("users.create", {"user": encode(user), "token": encode(token)}, result_of(int, "id"))`,
+		`self.users = UsersClient(self)`,
+	)
+	assertContains(t, files, "models.py",
+		`email: str = dataclasses.field(default="", metadata={"json": "Email"})`,
+		`friends: Optional[List[User]] = dataclasses.field(default=None, metadata={"json": "Friends"})`,
+		`password: Optional[Password] = dataclasses.field(default=None, metadata={"json": "Password"})`,
+	)
+	assertContains(t, files, "__init__.py", `from .client import Batch, Client, UsersClient`)
+
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not found")
+	}
+	// client is called through fake transport, which answers by id of every request
+	script := `
+from py import Client, MethodNotFoundError, User
+
+sent = []
+
+def transport(payload):
+    sent.append(payload)
+    requests = payload if isinstance(payload, list) else [payload]
+    responses = []
+    for req in requests:
+        if req["params"]["token"] == "bad":
+            responses.append({"jsonrpc": "2.0", "id": req["id"], "error": {"code": -32601, "message": "not found"}})
+        else:
+            responses.append({"jsonrpc": "2.0", "id": req["id"], "result": {"id": 7}})
+    return responses if isinstance(payload, list) else responses[0]
+
+client = Client(transport=transport)
+assert client.users.create(User(email="a@b.c"), "jwt") == 7
+assert sent[0]["params"]["user"]["Email"] == "a@b.c", sent[0]
+assert sent[0]["params"]["token"] == "jwt", sent[0]
+
+with client.batch() as batch:
+    first = batch.users.create(User(), "one")
+    second = batch.users.create(User(), "two")
+assert len(sent[1]) == 2, sent[1]
+assert first.result == 7 and second.result == 7
+
+try:
+    client.users.create(User(), "bad")
+    assert False, "error is not raised"
+except MethodNotFoundError as err:
+    assert err.code == -32601, err
+`
+	cmd := exec.Command(python, "-c", script)
+	cmd.Dir = filepath.Dir(outDir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, output)
+	}
+}
@@ -0,0 +1,334 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+var swiftKeywords = map[string]bool{
+	"Any": true, "as": true, "associatedtype": true, "break": true, "case": true, "catch": true, "class": true,
+	"continue": true, "default": true, "defer": true, "deinit": true, "do": true, "else": true, "enum": true,
+	"extension": true, "fallthrough": true, "false": true, "fileprivate": true, "for": true, "func": true, "guard": true,
+	"if": true, "import": true, "in": true, "init": true, "inout": true, "internal": true, "is": true, "let": true,
+	"nil": true, "open": true, "operator": true, "private": true, "protocol": true, "public": true, "repeat": true,
+	"return": true, "self": true, "static": true, "struct": true, "subscript": true, "super": true, "switch": true,
+	"throw": true, "throws": true, "true": true, "try": true, "typealias": true, "var": true, "where": true, "while": true,
+}
+
+// clientSwift walks types by TS client and renders them as Codable structures.
+type clientSwift struct {
+	*clientTS
+}
+
+// swiftField is property of Codable structure.
+type swiftField struct {
+	name     string
+	jsonName string
+	typeName string
+	zero     string
+	desc     string
+}
+
+func (tr *Transport) RenderClientSwift(outDir string) (err error) {
+	return newClientSwift(tr).render(outDir)
+}
+
+func newClientSwift(tr *Transport) (sw *clientSwift) {
+	return &clientSwift{clientTS: newClientTS(tr)}
+}
+
+func (sw *clientSwift) render(outDir string) (err error) {
+
+	if err = os.MkdirAll(outDir, 0777); err != nil {
+		return
+	}
+	header := fmt.Sprintf("// %s\n", doNotEdit)
+	if err = clientCopyTo("swift", outDir, header); err != nil {
+		return
+	}
+	var services []*service
+	for _, name := range sw.serviceKeys() {
+		if svc := sw.services[name]; svc.isJsonRPC() {
+			services = append(services, svc)
+		}
+	}
+	var clientFile bytesWriter
+	clientFile.add("%simport Foundation\n", header)
+	for _, svc := range services {
+		sw.renderService(&clientFile, svc)
+	}
+	sw.renderClient(&clientFile, services)
+	if err = generated.WriteFile(path.Join(outDir, "Client.swift"), clientFile.Bytes(), 0600); err != nil {
+		return
+	}
+	var modelsFile bytesWriter
+	modelsFile.add("%simport Foundation\n", header)
+	for _, name := range sw.structNames() {
+		def := sw.structDef(name)
+		var fields []swiftField
+		for _, fieldName := range def.propertyNames() {
+			property := def.properties[fieldName]
+			fields = append(fields, sw.field(fieldName, property, property.tags))
+		}
+		sw.renderStruct(&modelsFile, "", def.name, "Codable", fields)
+	}
+	return generated.WriteFile(path.Join(outDir, "Models.swift"), modelsFile.Bytes(), 0600)
+}
+
+func (sw *clientSwift) renderService(swFile *bytesWriter, svc *service) {
+
+	var methods []*method
+	for _, method := range svc.methods {
+		if method.isActual() && method.isJsonRPC() {
+			methods = append(methods, method)
+		}
+	}
+	for _, method := range methods {
+		if results := method.resultsWithoutError(); len(results) > 1 {
+			sw.renderStruct(swFile, "", sw.resultName(svc, method), "Decodable", sw.fields(svc, method, results))
+		}
+	}
+	swFile.add("\n")
+	if desc := svc.tags.Value(tagDesc); desc != "" {
+		swFile.add("%s", swiftDoc("", desc))
+	}
+	swFile.add("public final class %sClient {\n", svc.Name)
+	swFile.add("    private let rpc: JSONRPCClient\n\n")
+	swFile.add("    public init(rpc: JSONRPCClient) {\n")
+	swFile.add("        self.rpc = rpc\n")
+	swFile.add("    }\n")
+	for _, method := range methods {
+		args := method.argsWithoutContext()
+		params := "EmptyRPC()"
+		if len(args) != 0 {
+			argFields := sw.fields(svc, method, args)
+			sw.renderStruct(swFile, "    ", method.Name+"Params", "Encodable", argFields)
+			var values []string
+			for _, field := range argFields {
+				values = append(values, fmt.Sprintf("%s: %s", strings.Trim(field.name, "`"), field.name))
+			}
+			params = fmt.Sprintf("%sParams(%s)", method.Name, strings.Join(values, ", "))
+		}
+		var signature []string
+		for _, field := range sw.fields(svc, method, args) {
+			signature = append(signature, fmt.Sprintf("%s: %s", field.name, field.typeName))
+		}
+		results := method.resultsWithoutError()
+		if len(results) == 1 {
+			sw.renderStruct(swFile, "    ", method.Name+"Result", "Decodable", sw.fields(svc, method, results))
+		}
+		swFile.add("\n")
+		sw.renderMethodDoc(swFile, method)
+		call := fmt.Sprintf("rpc.call(%q, params: %s", method.jsonrpcName(), params)
+		switch len(results) {
+		case 0:
+			swFile.add("    public func %s(%s) async throws {\n", swiftName(method.Name), strings.Join(signature, ", "))
+			swFile.add("        _ = try await %s, as: EmptyRPC.self)\n", call)
+		case 1:
+			result := sw.field(results[0].Name, sw.walkVariable(results[0].Name, svc.pkgPath, results[0].Type, method.tags), nil)
+			swFile.add("    public func %s(%s) async throws -> %s {\n", swiftName(method.Name), strings.Join(signature, ", "), result.typeName)
+			swFile.add("        try await %s, as: %sResult.self).%s\n", call, method.Name, result.name)
+		default:
+			swFile.add("    public func %s(%s) async throws -> %s {\n", swiftName(method.Name), strings.Join(signature, ", "), sw.resultName(svc, method))
+			swFile.add("        try await %s, as: %s.self)\n", call, sw.resultName(svc, method))
+		}
+		swFile.add("    }\n")
+	}
+	swFile.add("}\n")
+}
+
+func (sw *clientSwift) renderClient(swFile *bytesWriter, services []*service) {
+
+	swFile.add("\n/// Client of JSON-RPC services.\n")
+	swFile.add("public final class Client {\n")
+	for _, svc := range services {
+		swFile.add("    public let %s: %sClient\n", swiftName(svc.Name), svc.Name)
+	}
+	swFile.add("\n    public init(transport: Transport) {\n")
+	swFile.add("        let rpc = JSONRPCClient(transport: transport)\n")
+	for _, svc := range services {
+		swFile.add("        %s = %sClient(rpc: rpc)\n", swiftName(svc.Name), svc.Name)
+	}
+	swFile.add("    }\n\n")
+	swFile.add("    public convenience init(endpoint: URL, headers: @escaping () -> [String: String] = { [:] }) {\n")
+	swFile.add("        self.init(transport: HTTPTransport(endpoint: endpoint, headers: headers))\n")
+	swFile.add("    }\n")
+	swFile.add("}\n")
+}
+
+func (sw *clientSwift) renderMethodDoc(swFile *bytesWriter, method *method) {
+
+	if desc := method.tags.Value(tagDesc, method.tags.Value(tagSummary)); desc != "" {
+		swFile.add("%s", swiftDoc("    ", desc))
+	}
+	for _, arg := range method.argsWithoutContext() {
+		if desc := method.tags.Sub(arg.Name).Value(tagDesc); desc != "" {
+			swFile.add("%s", swiftDoc("    ", fmt.Sprintf("- Parameter %s: %s", strings.Trim(swiftName(arg.Name), "`"), desc)))
+		}
+	}
+}
+
+// renderStruct adds structure with coding keys by JSON names. Decoding uses zero values of Go types for absent fields.
+func (sw *clientSwift) renderStruct(swFile *bytesWriter, indent, name, protocol string, fields []swiftField) {
+
+	access := "public "
+	if indent != "" {
+		access = ""
+	}
+	swFile.add("\n%s%sstruct %s: %s {\n", indent, access, name, protocol)
+	if len(fields) == 0 {
+		swFile.add("%s    %sinit() {}\n", indent, access)
+		swFile.add("%s}\n", indent)
+		return
+	}
+	for _, field := range fields {
+		if field.desc != "" {
+			swFile.add("%s", swiftDoc(indent+"    ", field.desc))
+		}
+		swFile.add("%s    %svar %s: %s\n", indent, access, field.name, field.typeName)
+	}
+	swFile.add("\n%s    enum CodingKeys: String, CodingKey {\n", indent)
+	for _, field := range fields {
+		swFile.add("%s        case %s = %q\n", indent, field.name, field.jsonName)
+	}
+	swFile.add("%s    }\n", indent)
+	if protocol == "Codable" {
+		var params []string
+		for _, field := range fields {
+			params = append(params, fmt.Sprintf("%s: %s = %s", field.name, field.typeName, field.zero))
+		}
+		swFile.add("\n%s    %sinit(%s) {\n", indent, access, strings.Join(params, ", "))
+		for _, field := range fields {
+			swFile.add("%s        self.%s = %s\n", indent, field.name, field.name)
+		}
+		swFile.add("%s    }\n", indent)
+	}
+	if protocol != "Encodable" {
+		swFile.add("\n%s    %sinit(from decoder: Decoder) throws {\n", indent, access)
+		swFile.add("%s        let container = try decoder.container(keyedBy: CodingKeys.self)\n", indent)
+		for _, field := range fields {
+			if strings.HasSuffix(field.typeName, "?") {
+				swFile.add("%s        %s = try container.decodeIfPresent(%s.self, forKey: .%s)\n", indent, field.name, strings.TrimSuffix(field.typeName, "?"), strings.Trim(field.name, "`"))
+				continue
+			}
+			swFile.add("%s        %s = try container.decodeIfPresent(%s.self, forKey: .%s) ?? %s\n", indent, field.name, field.typeName, strings.Trim(field.name, "`"), field.zero)
+		}
+		swFile.add("%s    }\n", indent)
+	}
+	swFile.add("%s}\n", indent)
+}
+
+func (sw *clientSwift) fields(svc *service, method *method, vars []types.Variable) (fields []swiftField) {
+
+	for _, variable := range vars {
+		def := sw.walkVariable(variable.Name, svc.pkgPath, variable.Type, method.tags)
+		fields = append(fields, sw.field(variable.Name, def, method.tags.Sub(variable.Name)))
+	}
+	return
+}
+
+// field returns property with zero value of Go type. Types without zero value are optional.
+func (sw *clientSwift) field(jsonName string, def typeDefTs, fieldTags tags.DocTags) (field swiftField) {
+
+	field = swiftField{name: swiftName(jsonName), jsonName: jsonName, typeName: sw.typeLink(def), desc: fieldTags.Value(tagDesc)}
+	switch {
+	case def.nullable:
+	case field.typeName == "Int":
+		field.zero = "0"
+		return
+	case field.typeName == "Double":
+		field.zero = "0"
+		return
+	case field.typeName == "Bool":
+		field.zero = "false"
+		return
+	case field.typeName == "String":
+		field.zero = `""`
+		return
+	case sw.structDef(field.typeName).kind == "struct":
+		field.zero = field.typeName + "()"
+		return
+	}
+	field.typeName, field.zero = field.typeName+"?", "nil"
+	return
+}
+
+func (sw *clientSwift) resultName(svc *service, method *method) string {
+	return svc.Name + method.Name + "Result"
+}
+
+func (sw *clientSwift) typeLink(def typeDefTs) string {
+
+	switch def.kind {
+	case "map":
+		return fmt.Sprintf("[String: %s]", sw.typeLink(def.properties["value"]))
+	case "array":
+		for _, item := range def.properties {
+			return fmt.Sprintf("[%s]", sw.typeLink(item))
+		}
+		return "[JSONValue]"
+	case "struct":
+		return def.name
+	case "scalar":
+		if def.origin != "" {
+			return castTypeSwift(def.origin)
+		}
+		if typeName := castTypeSwift(def.typeName); typeName != "JSONValue" {
+			return typeName
+		}
+		if next, found := sw.typeDefTs[def.typeName]; found && next.typeName != def.typeName {
+			return sw.typeLink(next)
+		}
+		return "JSONValue"
+	default:
+		return castTypeSwift(def.kind)
+	}
+}
+
+func castTypeSwift(originName string) (typeName string) {
+
+	switch originName {
+	case "bool", "boolean":
+		return "Bool"
+	case "byte", "rune", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "time.Duration":
+		return "Int"
+	case "float32", "float64", "number":
+		return "Double"
+	case "string", "[]byte":
+		return "String"
+	case "time.Time", "gorm.DeletedAt", "Date":
+		return "Date"
+	}
+	switch {
+	case strings.HasSuffix(originName, "NullTime"):
+		return "Date"
+	case strings.HasSuffix(originName, "UUID"):
+		return "String"
+	case strings.HasSuffix(originName, "Decimal"):
+		return "Double"
+	}
+	return "JSONValue"
+}
+
+// swiftName returns lowerCamelCase name, escaped when it is a keyword of Swift.
+func swiftName(name string) string {
+
+	if name = utils.ToLowerCamel(name); swiftKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+func swiftDoc(indent, text string) (doc string) {
+
+	for _, line := range strings.Split(text, "\n") {
+		doc += indent + "/// " + line + "\n"
+	}
+	return
+}
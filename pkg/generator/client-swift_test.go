@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderClientSwift(t *testing.T) {
+
+	tr, err := NewTransport(testLog(), "test", "testdata/redact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "swift")
+	if err = tr.RenderClientSwift(outDir); err != nil {
+		t.Fatal(err)
+	}
+	files := readFiles(t, outDir)
+	assertContains(t, files, "Client.swift",
+		`public func create(user: User, token: String) async throws -> Int {`,
+		`try await rpc.call("users.create", params: CreateParams(user: user, token: token), as: CreateResult.self).id`,
+		`id = try container.decodeIfPresent(Int.self, forKey: .id) ?? 0`,
+		`public let users: UsersClient`,
+	)
+	assertContains(t, files, "Models.swift",
+		`case email = "Email"`,
+		`public var friends: [User]?`,
+		`password = try container.decodeIfPresent(Password.self, forKey: .password) ?? Password()`,
+	)
+	if _, found := files["JSONRPC.swift"]; !found {
+		t.Error("JSONRPC.swift is not generated")
+	}
+}
@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+const packageZod = "zod"
+const versionZod = "^3.23.0"
+
+// SetZod enables generation of zod schemas for types of TS client.
+func (tr *Transport) SetZod(enabled bool) {
+	tr.zod = enabled
+}
+
+// renderZod adds schemas of types, arguments and results of methods to namespace of service.
+func (ts *clientTS) renderZod(tsFile *bytesWriter, svc *service) {
+
+	// schemas of methods are built first, as they register types of arguments and results
+	var params, results []string
+	for _, method := range svc.methods {
+		if !method.isActual() || (!method.isJsonRPC() && !method.isHTTP()) {
+			continue
+		}
+		paramSchema := fmt.Sprintf("z.object({%s})", ts.zodVars(svc, method, method.argsWithoutContext(), true))
+		if method.isJsonRPC() {
+			params = append(params, fmt.Sprintf("%s: %s", method.Name, paramSchema))
+			results = append(results, fmt.Sprintf("%s: z.object({%s})", method.Name, ts.zodVars(svc, method, method.resultsWithoutError(), false)))
+			continue
+		}
+		// every verb of HTTP method is a separate function of client
+		resultSchema := fmt.Sprintf("z.object({%s})", ts.zodHTTPResults(svc, method))
+		for _, verb := range method.httpMethods() {
+			params = append(params, fmt.Sprintf("%s: %s", method.httpMethodName(verb), paramSchema))
+			results = append(results, fmt.Sprintf("%s: %s", method.httpMethodName(verb), resultSchema))
+		}
+	}
+	names := make([]string, 0, len(ts.typeDefTs))
+	for name, def := range ts.typeDefTs {
+		if def.kind == "struct" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		def := ts.typeDefTs[name]
+		tsFile.add("export const %sSchema: z.ZodTypeAny = z.lazy(() => z.object({%s}));\n", def.name, ts.zodProperties(def.properties))
+	}
+	tsFile.add("export const ParamSchemas = {%s};\n", strings.Join(params, ", "))
+	tsFile.add("export const ResultSchemas = {%s};\n", strings.Join(results, ", "))
+}
+
+func (ts *clientTS) zodProperties(properties map[string]typeDefTs) string {
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var props []string
+	for _, name := range names {
+		property := properties[name]
+		props = append(props, fmt.Sprintf("%q: %s", name, ts.zodField(property, property.tags, property.nullable, !property.tags.IsSet(tagRequired))))
+	}
+	return strings.Join(props, ", ")
+}
+
+// zodVars returns properties of method arguments or results. Results are optional, as exchange structures omit empty fields.
+func (ts *clientTS) zodVars(svc *service, method *method, vars []types.Variable, isArgs bool) string {
+
+	var props []string
+	for _, variable := range vars {
+		varTags := method.tags.Sub(variable.Name)
+		def := ts.walkVariable(variable.Name, svc.pkgPath, variable.Type, varTags)
+		optional := !isArgs && !varTags.IsSet(tagRequired)
+		props = append(props, fmt.Sprintf("%q: %s", variable.Name, ts.zodField(def, varTags, def.nullable, optional)))
+	}
+	return strings.Join(props, ", ")
+}
+
+// zodHTTPResults returns properties of results of http-server method. Results from headers and cookies are converted from string by HTTP client.
+func (ts *clientTS) zodHTTPResults(svc *service, method *method) string {
+
+	retHeaders, retCookies := svc.httpClientResultMaps(method)
+	var props []string
+	for _, ret := range method.resultsWithoutError() {
+		if retHeaders[ret.Name] == "" && retCookies[ret.Name] == "" {
+			props = append(props, ts.zodVars(svc, method, []types.Variable{ret}, false))
+			continue
+		}
+		props = append(props, fmt.Sprintf("%q: %s.optional()", ret.Name, ts.zodScalar(ts.stringKind(svc, method, ret.Name))))
+	}
+	return strings.Join(props, ", ")
+}
+
+// zodField returns schema of value with refinements by enums, format and desc annotations.
+func (ts *clientTS) zodField(def typeDefTs, fieldTags tags.DocTags, nullable, optional bool) (schema string) {
+
+	schema = ts.zodLink(def)
+	isString := schema == "z.string()"
+	if enums := fieldTags.Value(tagEnums); enums != "" {
+		values := strings.Split(enums, ",")
+		quoted := make([]string, 0, len(values))
+		for _, value := range values {
+			quoted = append(quoted, fmt.Sprintf("%q", strings.TrimSpace(value)))
+		}
+		if isString {
+			schema = fmt.Sprintf("z.enum([%s])", strings.Join(quoted, ", "))
+		} else {
+			schema += fmt.Sprintf(".refine((value) => [%s].includes(String(value)), {message: \"must be one of %s\"})", strings.Join(quoted, ", "), enums)
+		}
+	}
+	if isString {
+		switch fieldTags.Value(tagFormat) {
+		case "uuid":
+			schema += ".uuid()"
+		case "email":
+			schema += ".email()"
+		case "uri", "url":
+			schema += ".url()"
+		case "date-time":
+			schema += ".datetime({offset: true})"
+		case "ipv4":
+			schema += ".ip({version: \"v4\"})"
+		case "ipv6":
+			schema += ".ip({version: \"v6\"})"
+		}
+	}
+	if desc := fieldTags.Value(tagDesc); desc != "" {
+		schema += fmt.Sprintf(".describe(%q)", desc)
+	}
+	if nullable {
+		schema += ".nullable()"
+	}
+	if optional {
+		schema += ".optional()"
+	}
+	return
+}
+
+// zodLink returns schema of type definition, referencing schemas of named structures.
+func (ts *clientTS) zodLink(def typeDefTs) string {
+
+	switch def.kind {
+	case "map":
+		return fmt.Sprintf("z.record(%s)", ts.zodLink(def.properties["value"]))
+	case "array":
+		return fmt.Sprintf("z.array(%s)", ts.zodLink(def.properties["item"]))
+	case "struct":
+		return def.name + "Schema"
+	case "scalar":
+		return ts.zodScalar(def.typeName)
+	default:
+		return ts.zodScalar(castTypeTs(def.name))
+	}
+}
+
+func (ts *clientTS) zodScalar(typeName string) string {
+
+	switch castTypeTs(typeName) {
+	case "string":
+		return "z.string()"
+	case "number":
+		return "z.number()"
+	case "boolean":
+		return "z.boolean()"
+	case "Date":
+		return "z.coerce.date()"
+	}
+	if def, found := ts.typeDefTs[typeName]; found && def.kind == "struct" {
+		return def.name + "Schema"
+	}
+	return "z.any()"
+}
@@ -0,0 +1,34 @@
+package generator
+
+import "testing"
+
+func TestRenderZod(t *testing.T) {
+
+	files := renderTS(t, "testdata/files", true)
+	assertContains(t, files, "files.ts",
+		`import {z} from "zod";`,
+		`import {validator} from "./schema/schema";`,
+		`export const HTTP = (options: HttpOptions, validate?: boolean) => {`,
+		`const check = validate ? validator(ResultSchemas) : undefined;`,
+		`check?.("UploadPut", result);`,
+		`export const ParamSchemas = {Get: z.object({"id": z.number(), "token": z.string(), "limit": z.number()}), Upload: z.object({"name": z.string(), "data": z.string()}), UploadPut: z.object({"name": z.string(), "data": z.string()})};`,
+		`export const ResultSchemas = {Get: z.object({"name": z.string().optional()}), Upload: z.object({"id": z.number().optional()}), UploadPut: z.object({"id": z.number().optional()})};`,
+	)
+	if _, found := files["schema/schema.ts"]; !found {
+		t.Error("schema/schema.ts is not generated")
+	}
+
+	files = renderTS(t, "testdata/trace", true)
+	assertContains(t, files, "orders.ts",
+		`export const RPC = (headers?: Record<string, string>, batch?: boolean | BatchOptions, validate?: boolean) => {`,
+		`validate: validate ? validator(ResultSchemas) : undefined`,
+		`export const ParamSchemas = {Get: z.object({"id": z.number()}), Cancel: z.object({"id": z.number()}), Archive: z.object({"id": z.number()})};`,
+		`export const ResultSchemas = {Get: z.object({"status": z.string().optional()}), Cancel: z.object({}), Archive: z.object({})};`,
+	)
+
+	files = renderTS(t, "testdata/files", false)
+	assertNotContains(t, files, "files.ts", `zod`, `ResultSchemas`)
+	if _, found := files["schema/schema.ts"]; found {
+		t.Error("schema/schema.ts is generated without zod")
+	}
+}
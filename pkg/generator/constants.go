@@ -7,15 +7,18 @@ const (
 	syncHeader            = "X-Sync-On"
 	packageOS             = "os"
 	packageIO             = "io"
+	packageBytes          = "bytes"
 	_ctx_                 = "ctx"
 	packageFmt            = "fmt"
 	packageTLS            = "crypto/tls"
 	packageTime           = "time"
 	_next_                = "next"
 	packageSync           = "sync"
+	packageSyncAtomic     = "sync/atomic"
 	packageTesting        = "testing"
 	packageReflect        = "reflect"
 	packageHttp           = "net/http"
+	packageURL            = "net/url"
 	packageContext        = "context"
 	packageStrconv        = "strconv"
 	packageStrings        = "strings"
@@ -31,9 +34,9 @@ const (
 	packageAttributeOTEL  = "go.opentelemetry.io/otel/attribute"
 	packageOTEL           = "go.opentelemetry.io/otel"
 	packageTrace          = "go.opentelemetry.io/otel/trace"
-	packageFasthttp       = "github.com/valyala/fasthttp"
+	packagePropagation    = "go.opentelemetry.io/otel/propagation"
+	packageMetricOTEL     = "go.opentelemetry.io/otel/metric"
 	packagePrometheus     = "github.com/prometheus/client_golang/prometheus"
-	packagePrometheusAuto = "github.com/prometheus/client_golang/prometheus/promauto"
 	packagePrometheusHttp = "github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -14,7 +14,9 @@ func (tr *Transport) renderContext(outDir string) (err error) {
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.Add(typeMethodCallMeta())
-
+	if tr.isNetHTTP() {
+		tr.renderMethodCallHolder(srcFile)
+	}
 	return srcFile.Save(path.Join(outDir, "context.go"))
 }
 
@@ -28,3 +30,36 @@ func typeMethodCallMeta() Code {
 		tg.Id("Err").Error()
 	})
 }
+
+// renderMethodCallHolder renders request-scoped holder of MethodCallMeta, which net/http middlewares read after next handler.
+func (tr *Transport) renderMethodCallHolder(srcFile goFile) {
+
+	srcFile.Line().Type().Id("methodCallKey").Struct()
+	srcFile.Line().Type().Id("methodCallHolder").Struct(
+		Id("lock").Qual(packageSync, "Mutex"),
+		Id("meta").Id("MethodCallMeta"),
+		Id("found").Bool(),
+	)
+	srcFile.Line().Comment("MethodCall returns meta of method, called while handling request, e.g. in middleware after next handler.")
+	srcFile.Func().Id("MethodCall").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("meta").Id("MethodCallMeta"), Id("found").Bool()).Block(
+		Line(),
+		If(List(Id("holder"), Id("ok")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("methodCallKey").Values()).Assert(Op("*").Id("methodCallHolder")).Op(";").Id("ok")).Block(
+			Id("holder").Dot("lock").Dot("Lock").Call(),
+			Defer().Id("holder").Dot("lock").Dot("Unlock").Call(),
+			Return(Id("holder").Dot("meta"), Id("holder").Dot("found")),
+		),
+		Return(),
+	)
+	srcFile.Line().Func().Id("withMethodCall").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Qual(packageContext, "Context")).Block(
+		Return(Qual(packageContext, "WithValue").Call(Id(_ctx_), Id("methodCallKey").Values(), Op("&").Id("methodCallHolder").Values())),
+	)
+	srcFile.Line().Func().Id("setMethodCall").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("meta").Id("MethodCallMeta")).Block(
+		Line(),
+		If(List(Id("holder"), Id("ok")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("methodCallKey").Values()).Assert(Op("*").Id("methodCallHolder")).Op(";").Id("ok")).Block(
+			Id("holder").Dot("lock").Dot("Lock").Call(),
+			Defer().Id("holder").Dot("lock").Dot("Unlock").Call(),
+			Id("holder").Dot("meta").Op("=").Id("meta"),
+			Id("holder").Dot("found").Op("=").True(),
+		),
+	)
+}
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ContractChange is a difference between two versions of services API.
+type ContractChange struct {
+	Breaking bool
+	Service  string
+	Method   string
+	Message  string
+}
+
+func (change ContractChange) String() string {
+
+	level := "compatible"
+	if change.Breaking {
+		level = "BREAKING"
+	}
+	name := change.Service
+	if change.Method != "" {
+		name += "." + change.Method
+	}
+	return fmt.Sprintf("%-10s %s: %s", level, name, change.Message)
+}
+
+type contractDiff struct {
+	service string
+	method  string
+	changes []ContractChange
+}
+
+// CompareContracts returns changes of current API against base one. Change is breaking,
+// when clients of base version or code, which uses regenerated clients, may stop working.
+func CompareContracts(base, current Contract) (changes []ContractChange) {
+
+	for _, name := range sortedNames(base.services, current.services) {
+		diff := &contractDiff{service: name}
+		baseSvc, inBase := base.services[name]
+		curSvc, inCurrent := current.services[name]
+		switch {
+		case !inCurrent:
+			diff.add(true, "service removed")
+		case !inBase:
+			diff.add(false, "service added")
+		default:
+			diff.compareService(baseSvc, curSvc)
+		}
+		changes = append(changes, diff.changes...)
+	}
+	return
+}
+
+func (diff *contractDiff) add(breaking bool, format string, args ...any) {
+	diff.changes = append(diff.changes, ContractChange{
+		Breaking: breaking,
+		Service:  diff.service,
+		Method:   diff.method,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (diff *contractDiff) compareService(base, current contractService) {
+
+	if base.noOmitEmpty != current.noOmitEmpty {
+		diff.add(true, "%s changed from %t to %t, empty fields of responses are encoded differently", tagDisableOmitEmpty, base.noOmitEmpty, current.noOmitEmpty)
+	}
+	for _, name := range sortedNames(base.methods, current.methods) {
+		diff.method = name
+		baseMethod, inBase := base.methods[name]
+		curMethod, inCurrent := current.methods[name]
+		switch {
+		case !inCurrent:
+			diff.add(true, "method removed")
+		case !inBase:
+			diff.add(false, "method added")
+		default:
+			diff.compareMethod(baseMethod, curMethod)
+		}
+	}
+	diff.method = ""
+}
+
+func (diff *contractDiff) compareMethod(base, current contractMethod) {
+
+	switch {
+	case base.jsonRPC != "" && current.jsonRPC == "":
+		diff.add(true, "JSON-RPC method removed")
+	case base.jsonRPC == "" && current.jsonRPC != "":
+		diff.add(false, "JSON-RPC method added")
+	case base.jsonRPC != current.jsonRPC:
+		diff.add(true, "JSON-RPC method changed from '%s' to '%s'", base.jsonRPC, current.jsonRPC)
+	}
+	for _, route := range base.routes {
+		if !slices.Contains(current.routes, route) {
+			diff.add(true, "HTTP route '%s' removed", route)
+		}
+	}
+	for _, route := range current.routes {
+		if !slices.Contains(base.routes, route) {
+			diff.add(false, "HTTP route '%s' added", route)
+		}
+	}
+	if base.success != "" && current.success != "" && base.success != current.success {
+		diff.add(true, "HTTP success code changed from %s to %s", base.success, current.success)
+	}
+	diff.compareVars("argument", true, base.args, current.args)
+	diff.compareVars("result", false, base.results, current.results)
+}
+
+// compareVars compares arguments or results of method, they change signatures of generated clients.
+func (diff *contractDiff) compareVars(kind string, request bool, base, current map[string]contractField) {
+
+	for _, key := range sortedNames(base, current) {
+		baseVar, inBase := base[key]
+		curVar, inCurrent := current[key]
+		switch {
+		case !inCurrent:
+			diff.add(true, "%s '%s' removed", baseVar.kind(kind), baseVar.name)
+		case !inBase:
+			diff.add(true, "%s '%s' added", curVar.kind(kind), curVar.name)
+		default:
+			diff.compareField(curVar.kind(kind), curVar.name, request, baseVar, curVar)
+		}
+	}
+}
+
+func (diff *contractDiff) compareField(kind, fieldPath string, request bool, base, current contractField) {
+
+	title := fmt.Sprintf("%s '%s'", kind, fieldPath)
+	if base.typeName != current.typeName {
+		diff.add(true, "%s type changed from %s to %s", title, base.typeName, current.typeName)
+		return
+	}
+	if base.required != current.required {
+		diff.add(request && current.required, "%s required changed from %t to %t", title, base.required, current.required)
+	}
+	if base.nullable != current.nullable {
+		// requests may not accept null anymore, responses may return null
+		diff.add(request != current.nullable, "%s nullable changed from %t to %t", title, base.nullable, current.nullable)
+	}
+	if base.enums != current.enums {
+		baseEnums, curEnums := splitEnums(base.enums), splitEnums(current.enums)
+		narrowed := len(curEnums) != 0 && (len(baseEnums) == 0 || slices.ContainsFunc(baseEnums, func(value string) bool { return !slices.Contains(curEnums, value) }))
+		widened := len(baseEnums) != 0 && (len(curEnums) == 0 || slices.ContainsFunc(curEnums, func(value string) bool { return !slices.Contains(baseEnums, value) }))
+		// requests may not accept removed values, responses may return added ones
+		diff.add((request && narrowed) || (!request && widened), "%s enums changed from [%s] to [%s]", title, base.enums, current.enums)
+	}
+	for _, name := range sortedNames(base.fields, current.fields) {
+		baseField, inBase := base.fields[name]
+		curField, inCurrent := current.fields[name]
+		nestedPath := fieldPath + "." + name
+		switch {
+		case !inCurrent:
+			diff.add(true, "%s '%s' removed", kind, nestedPath)
+		case !inBase:
+			diff.add(request && curField.required, "%s '%s' added", kind, nestedPath)
+		default:
+			diff.compareField(kind, nestedPath, request, baseField, curField)
+		}
+	}
+}
+
+// kind returns kind of variable with its place in HTTP request, when it is not a body.
+func (field contractField) kind(kind string) string {
+
+	if field.place == placeBody {
+		return kind
+	}
+	return field.place + " " + kind
+}
+
+func splitEnums(enums string) (values []string) {
+
+	for _, value := range strings.Split(enums, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return
+}
+
+// sortedNames returns sorted union of keys of both maps.
+func sortedNames[V any](base, current map[string]V) (names []string) {
+
+	for name := range base {
+		names = append(names, name)
+	}
+	for name := range current {
+		if _, found := base[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
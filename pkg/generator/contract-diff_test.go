@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareContracts(t *testing.T) {
+
+	orders := func(methods map[string]contractMethod) Contract {
+		return Contract{services: map[string]contractService{"Orders": {methods: methods}}}
+	}
+	list := func(args, results map[string]contractField) Contract {
+		return orders(map[string]contractMethod{"List": {jsonRPC: "orders.list", routes: []string{"GET /orders"}, success: "200", args: args, results: results}})
+	}
+	field := func(typeName string) contractField {
+		return contractField{place: placeBody, name: "status", typeName: typeName}
+	}
+	status := func(modify func(field *contractField)) map[string]contractField {
+		status := field("string")
+		modify(&status)
+		return map[string]contractField{"status": status}
+	}
+	nested := func(fields map[string]contractField) map[string]contractField {
+		filter := contractField{place: placeBody, name: "filter", typeName: "Filter", fields: fields}
+		return map[string]contractField{"filter": filter}
+	}
+	base := list(nil, nil)
+	change := func(breaking bool, method, message string) ContractChange {
+		return ContractChange{Breaking: breaking, Service: "Orders", Method: method, Message: message}
+	}
+	tests := []struct {
+		name    string
+		base    Contract
+		current Contract
+		changes []ContractChange
+	}{
+		{name: "equal", base: base, current: list(nil, nil)},
+		{
+			name:    "service removed",
+			base:    base,
+			current: Contract{},
+			changes: []ContractChange{{Breaking: true, Service: "Orders", Message: "service removed"}},
+		},
+		{
+			name:    "service added",
+			base:    Contract{},
+			current: base,
+			changes: []ContractChange{{Service: "Orders", Message: "service added"}},
+		},
+		{
+			name:    "omitempty",
+			base:    base,
+			current: Contract{services: map[string]contractService{"Orders": {noOmitEmpty: true, methods: base.services["Orders"].methods}}},
+			changes: []ContractChange{{Breaking: true, Service: "Orders", Message: tagDisableOmitEmpty + " changed from false to true, empty fields of responses are encoded differently"}},
+		},
+		{
+			name:    "method removed and added",
+			base:    base,
+			current: orders(map[string]contractMethod{"Find": base.services["Orders"].methods["List"]}),
+			changes: []ContractChange{change(false, "Find", "method added"), change(true, "List", "method removed")},
+		},
+		{
+			name:    "transport",
+			base:    base,
+			current: orders(map[string]contractMethod{"List": {jsonRPC: "orders.find", routes: []string{"POST /orders"}, success: "201"}}),
+			changes: []ContractChange{
+				change(true, "List", "JSON-RPC method changed from 'orders.list' to 'orders.find'"),
+				change(true, "List", "HTTP route 'GET /orders' removed"),
+				change(false, "List", "HTTP route 'POST /orders' added"),
+				change(true, "List", "HTTP success code changed from 200 to 201"),
+			},
+		},
+		{
+			name:    "jsonrpc removed",
+			base:    base,
+			current: orders(map[string]contractMethod{"List": {routes: []string{"GET /orders"}, success: "200"}}),
+			changes: []ContractChange{change(true, "List", "JSON-RPC method removed")},
+		},
+		{
+			name:    "argument added",
+			base:    base,
+			current: list(map[string]contractField{"status": {place: placeQuery, name: "status", typeName: "string"}}, nil),
+			changes: []ContractChange{change(true, "List", "query argument 'status' added")},
+		},
+		{
+			name:    "result removed",
+			base:    list(nil, status(func(*contractField) {})),
+			current: base,
+			changes: []ContractChange{change(true, "List", "result 'status' removed")},
+		},
+		{
+			name:    "type changed",
+			base:    list(status(func(*contractField) {}), nil),
+			current: list(map[string]contractField{"status": field("number")}, nil),
+			changes: []ContractChange{change(true, "List", "argument 'status' type changed from string to number")},
+		},
+		{
+			name:    "argument required",
+			base:    list(status(func(*contractField) {}), nil),
+			current: list(status(func(f *contractField) { f.required = true }), nil),
+			changes: []ContractChange{change(true, "List", "argument 'status' required changed from false to true")},
+		},
+		{
+			name:    "result required",
+			base:    list(nil, status(func(*contractField) {})),
+			current: list(nil, status(func(f *contractField) { f.required = true })),
+			changes: []ContractChange{change(false, "List", "result 'status' required changed from false to true")},
+		},
+		{
+			name:    "argument not nullable",
+			base:    list(status(func(f *contractField) { f.nullable = true }), nil),
+			current: list(status(func(*contractField) {}), nil),
+			changes: []ContractChange{change(true, "List", "argument 'status' nullable changed from true to false")},
+		},
+		{
+			name:    "result nullable",
+			base:    list(nil, status(func(*contractField) {})),
+			current: list(nil, status(func(f *contractField) { f.nullable = true })),
+			changes: []ContractChange{change(true, "List", "result 'status' nullable changed from false to true")},
+		},
+		{
+			name:    "argument enums narrowed",
+			base:    list(status(func(f *contractField) { f.enums = "new,done" }), nil),
+			current: list(status(func(f *contractField) { f.enums = "new" }), nil),
+			changes: []ContractChange{change(true, "List", "argument 'status' enums changed from [new,done] to [new]")},
+		},
+		{
+			name:    "argument enums widened",
+			base:    list(status(func(f *contractField) { f.enums = "new" }), nil),
+			current: list(status(func(f *contractField) { f.enums = "new, done" }), nil),
+			changes: []ContractChange{change(false, "List", "argument 'status' enums changed from [new] to [new, done]")},
+		},
+		{
+			name:    "result enums widened",
+			base:    list(nil, status(func(f *contractField) { f.enums = "new" })),
+			current: list(nil, status(func(f *contractField) { f.enums = "new,done" })),
+			changes: []ContractChange{change(true, "List", "result 'status' enums changed from [new] to [new,done]")},
+		},
+		{
+			name:    "result enums narrowed",
+			base:    list(nil, status(func(f *contractField) { f.enums = "new,done" })),
+			current: list(nil, status(func(f *contractField) { f.enums = "new" })),
+			changes: []ContractChange{change(false, "List", "result 'status' enums changed from [new,done] to [new]")},
+		},
+		{
+			name:    "nested fields",
+			base:    list(nested(map[string]contractField{"name": field("string")}), nil),
+			current: list(nested(map[string]contractField{"owner": {typeName: "string", required: true}, "limit": {typeName: "number"}}), nil),
+			changes: []ContractChange{
+				change(false, "List", "argument 'filter.limit' added"),
+				change(true, "List", "argument 'filter.name' removed"),
+				change(true, "List", "argument 'filter.owner' added"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if changes := CompareContracts(test.base, test.current); !slices.Equal(changes, test.changes) {
+				t.Errorf("changes:\n%v\nwant:\n%v", changes, test.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+const contractDepth = 8
+
+const (
+	placeBody   = "body"
+	placePath   = "path"
+	placeQuery  = "query"
+	placeHeader = "header"
+	placeCookie = "cookie"
+)
+
+// Contract is a snapshot of services API, which two versions are compared by CompareContracts.
+type Contract struct {
+	services map[string]contractService
+}
+
+type contractService struct {
+	noOmitEmpty bool
+	methods     map[string]contractMethod
+}
+
+type contractMethod struct {
+	jsonRPC string
+	routes  []string
+	success string
+	args    map[string]contractField
+	results map[string]contractField
+}
+
+// contractField is a value as it is seen by clients: place in request, JSON name and type.
+type contractField struct {
+	place    string
+	name     string
+	typeName string
+	required bool
+	nullable bool
+	enums    string
+	fields   map[string]contractField
+}
+
+// Contract builds snapshot of services API. Types are resolved relative to current directory.
+func (tr *Transport) Contract() (contract Contract) {
+
+	tr.attachServices()
+	ts := newClientTS(tr)
+	contract.services = make(map[string]contractService)
+	for _, name := range tr.serviceKeys() {
+		svc := tr.services[name]
+		cs := contractService{
+			noOmitEmpty: svc.tags.IsSet(tagDisableOmitEmpty),
+			methods:     make(map[string]contractMethod),
+		}
+		for _, method := range svc.methods {
+			if !method.isActual() {
+				continue
+			}
+			cm := contractMethod{
+				args:    make(map[string]contractField),
+				results: make(map[string]contractField),
+			}
+			if method.isJsonRPC() {
+				cm.jsonRPC = fmt.Sprintf("%s %s", svc.batchPath(), method.jsonrpcName())
+			}
+			if method.isHTTP() {
+				for _, verb := range method.httpMethods() {
+					cm.routes = append(cm.routes, fmt.Sprintf("%s %s", verb, method.httpPath()))
+				}
+				sort.Strings(cm.routes)
+				cm.success = method.tags.Value(tagHttpSuccess, "200")
+			}
+			for _, arg := range method.fieldsArgument() {
+				place, key := method.argPlace(arg.Name)
+				if field, ok := ts.contractVar(svc, method, arg, place, key); ok {
+					cm.args[field.place+":"+field.name] = field
+				}
+			}
+			for _, ret := range method.fieldsResult() {
+				place, key := method.retPlace(ret.Name)
+				if field, ok := ts.contractVar(svc, method, ret, place, key); ok {
+					cm.results[field.place+":"+field.name] = field
+				}
+			}
+			cs.methods[method.Name] = cm
+		}
+		contract.services[name] = cs
+	}
+	return
+}
+
+func (m *method) argPlace(name string) (place, key string) {
+
+	if !m.isHTTP() {
+		return placeBody, ""
+	}
+	if _, found := m.argPathMap()[name]; found {
+		return placePath, name
+	}
+	if param, found := m.argParamMap()[name]; found {
+		return placeQuery, param
+	}
+	if header, found := m.varHeaderMap()[name]; found {
+		return placeHeader, header
+	}
+	if cookie, found := m.varCookieMap()[name]; found {
+		return placeCookie, cookie
+	}
+	return placeBody, ""
+}
+
+func (m *method) retPlace(name string) (place, key string) {
+
+	if !m.isHTTP() {
+		return placeBody, ""
+	}
+	if header, found := m.varHeaderMap()[name]; found {
+		return placeHeader, header
+	}
+	if cookie, found := m.retCookieMap()[name]; found {
+		return placeCookie, cookie
+	}
+	return placeBody, ""
+}
+
+func (ts *clientTS) contractVar(svc *service, method *method, variable types.StructField, place, key string) (field contractField, ok bool) {
+
+	name := variable.Name
+	if jsonTags := variable.Tags["json"]; len(jsonTags) != 0 && jsonTags[0] != "" {
+		name = jsonTags[0]
+	}
+	if name == "-" {
+		return
+	}
+	if key != "" {
+		name = key
+	}
+	varTags := method.tags.Sub(variable.Name)
+	field = ts.contractField(ts.walkVariable(variable.Name, svc.pkgPath, variable.Type, varTags), varTags, 0)
+	field.place, field.name = place, name
+	return field, true
+}
+
+func (ts *clientTS) contractField(def typeDefTs, fieldTags tags.DocTags, depth int) (field contractField) {
+
+	field.required = fieldTags.IsSet(tagRequired)
+	field.enums = fieldTags.Value(tagEnums)
+	field.nullable = def.nullable
+	field.typeName = def.kind
+	switch def.kind {
+	case "struct":
+		field.typeName = "object"
+		if depth > contractDepth {
+			return
+		}
+		field.fields = make(map[string]contractField)
+		for _, name := range def.propertyNames() {
+			property := def.properties[name]
+			nested := ts.contractField(property, property.tags, depth+1)
+			nested.place, nested.name = placeBody, name
+			field.fields[name] = nested
+		}
+	case "map", "array":
+		if depth > contractDepth {
+			return
+		}
+		field.fields = make(map[string]contractField)
+		for name, property := range def.properties {
+			if def.kind == "array" {
+				name = "item"
+			}
+			nested := ts.contractField(property, nil, depth+1)
+			nested.place, nested.name = placeBody, name
+			field.fields[name] = nested
+		}
+	case "scalar":
+		field.typeName = def.typeName
+		if def.origin != "" {
+			field.typeName = def.origin
+			return
+		}
+		if next, found := ts.typeDefTs[def.typeName]; found && next.typeName != def.typeName && depth <= contractDepth {
+			resolved := ts.contractField(next, fieldTags, depth+1)
+			resolved.nullable = resolved.nullable || def.nullable
+			return resolved
+		}
+	}
+	return
+}
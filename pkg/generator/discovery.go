@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const recursivePattern = "..."
+
+// ServicesRoot returns directory of services pattern, './interfaces/...' is rooted at './interfaces'.
+func ServicesRoot(pattern string) string {
+
+	if root, found := strings.CutSuffix(pattern, recursivePattern); found {
+		if root = strings.TrimSuffix(root, "/"); root == "" {
+			return "."
+		}
+		return root
+	}
+	return pattern
+}
+
+// ServiceDirs returns directories with Go files, matched by services pattern.
+// Pattern './interfaces/...' matches directory and all its subdirectories, like go tool does.
+func ServiceDirs(pattern string) (dirs []string, err error) {
+
+	root := ServicesRoot(pattern)
+	if root == pattern {
+		return []string{pattern}, nil
+	}
+	err = filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		if name := entry.Name(); dir != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		if hasGoFiles(dir) {
+			dirs = append(dirs, dir)
+		}
+		return nil
+	})
+	return
+}
+
+func hasGoFiles(dir string) bool {
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".go") {
+			return true
+		}
+	}
+	return false
+}
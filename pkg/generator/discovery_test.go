@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestServicesRoot(t *testing.T) {
+
+	tests := []struct {
+		pattern string
+		root    string
+	}{
+		{pattern: "./interfaces", root: "./interfaces"},
+		{pattern: "./interfaces/...", root: "./interfaces"},
+		{pattern: "interfaces...", root: "interfaces"},
+		{pattern: "./...", root: "."},
+		{pattern: "...", root: "."},
+	}
+	for _, test := range tests {
+		if root := ServicesRoot(test.pattern); root != test.root {
+			t.Errorf("root of %s is %s, want %s", test.pattern, root, test.root)
+		}
+	}
+}
+
+func TestServiceDirs(t *testing.T) {
+
+	root := t.TempDir()
+	for _, fileName := range []string{
+		"api.go",
+		"users/users.go",
+		"billing/invoices/invoices.go",
+		"billing/readme.md",
+		"docs/readme.md",
+		"testdata/broken.go",
+		"vendor/lib/lib.go",
+		".cache/cache.go",
+		"_old/old.go",
+	} {
+		filePath := filepath.Join(root, fileName)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dirs, err := ServiceDirs(root + "/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{root, filepath.Join(root, "billing", "invoices"), filepath.Join(root, "users")}
+	if !slices.Equal(dirs, want) {
+		t.Errorf("dirs %q, want %q", dirs, want)
+	}
+	if dirs, err = ServiceDirs(root); err != nil || !slices.Equal(dirs, []string{root}) {
+		t.Errorf("dirs %q, error %v, want only root", dirs, err)
+	}
+}
+
+func TestRenderDiscovery(t *testing.T) {
+
+	files := renderServer(t, "testdata/discovery/...", backendNetHTTP)
+	// http-prefix of root package is default for all services, nested package overrides it for own services
+	assertContains(t, files, "users-http.go", `mux.HandleFunc("POST /api/users/get", http.serveGet)`)
+	assertContains(t, files, "invoices-http.go",
+		`"github.com/seniorGolang/tg/v2/pkg/generator/testdata/discovery/billing"`,
+		`mux.HandleFunc("GET /billing/invoices/{id}", http.serveGet)`,
+	)
+	for _, fileName := range []string{"users-logger.go", "invoices-logger.go"} {
+		if _, found := files[fileName]; !found {
+			t.Errorf("file %s is not generated", fileName)
+		}
+	}
+
+	svcDir := t.TempDir()
+	for _, pkgName := range []string{"first", "second"} {
+		source := "package " + pkgName + "\n\n// @tg jsonRPC-server\ntype Users interface {\n}\n"
+		if err := os.MkdirAll(filepath.Join(svcDir, pkgName), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(svcDir, pkgName, "users.go"), []byte(source), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := NewTransport(testLog(), "test", svcDir+"/...")
+	if err == nil || !strings.HasPrefix(err.Error(), "interface Users is declared in packages ") {
+		t.Errorf("error %v, want duplicated interface", err)
+	}
+}
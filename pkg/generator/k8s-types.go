@@ -0,0 +1,128 @@
+package generator
+
+// K8sConfig describes deployment of the service binary to Kubernetes.
+type K8sConfig struct {
+	AppName     string
+	Image       string
+	Namespace   string
+	Host        string
+	Replicas    int
+	Port        int
+	HealthPort  int
+	MetricsPort int
+	MetricsPath string
+}
+
+type k8sMeta struct {
+	Name        string            `yaml:"name,omitempty"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type k8sDeployment struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMeta           `yaml:"metadata"`
+	Spec       k8sDeploymentSpec `yaml:"spec"`
+}
+
+type k8sDeploymentSpec struct {
+	Replicas int            `yaml:"replicas"`
+	Selector k8sSelector    `yaml:"selector"`
+	Template k8sPodTemplate `yaml:"template"`
+}
+
+type k8sSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type k8sPodTemplate struct {
+	Metadata k8sMeta    `yaml:"metadata"`
+	Spec     k8sPodSpec `yaml:"spec"`
+}
+
+type k8sPodSpec struct {
+	Containers []k8sContainer `yaml:"containers"`
+}
+
+type k8sContainer struct {
+	Name           string             `yaml:"name"`
+	Image          string             `yaml:"image"`
+	Ports          []k8sContainerPort `yaml:"ports"`
+	LivenessProbe  *k8sProbe          `yaml:"livenessProbe,omitempty"`
+	ReadinessProbe *k8sProbe          `yaml:"readinessProbe,omitempty"`
+}
+
+type k8sContainerPort struct {
+	Name          string `yaml:"name"`
+	ContainerPort int    `yaml:"containerPort"`
+}
+
+type k8sProbe struct {
+	HTTPGet             k8sHTTPGet `yaml:"httpGet"`
+	InitialDelaySeconds int        `yaml:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int        `yaml:"periodSeconds,omitempty"`
+}
+
+type k8sHTTPGet struct {
+	Path string `yaml:"path"`
+	Port string `yaml:"port"`
+}
+
+type k8sService struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   k8sMeta        `yaml:"metadata"`
+	Spec       k8sServiceSpec `yaml:"spec"`
+}
+
+type k8sServiceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []k8sServicePort  `yaml:"ports"`
+}
+
+type k8sServicePort struct {
+	Name       string `yaml:"name"`
+	Port       int    `yaml:"port"`
+	TargetPort string `yaml:"targetPort"`
+}
+
+type k8sIngress struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   k8sMeta        `yaml:"metadata"`
+	Spec       k8sIngressSpec `yaml:"spec"`
+}
+
+type k8sIngressSpec struct {
+	Rules []k8sIngressRule `yaml:"rules"`
+}
+
+type k8sIngressRule struct {
+	Host string             `yaml:"host,omitempty"`
+	HTTP k8sIngressRuleHTTP `yaml:"http"`
+}
+
+type k8sIngressRuleHTTP struct {
+	Paths []k8sIngressPath `yaml:"paths"`
+}
+
+type k8sIngressPath struct {
+	Path     string            `yaml:"path"`
+	PathType string            `yaml:"pathType"`
+	Backend  k8sIngressBackend `yaml:"backend"`
+}
+
+type k8sIngressBackend struct {
+	Service k8sIngressService `yaml:"service"`
+}
+
+type k8sIngressService struct {
+	Name string                `yaml:"name"`
+	Port k8sIngressServicePort `yaml:"port"`
+}
+
+type k8sIngressServicePort struct {
+	Name string `yaml:"name"`
+}
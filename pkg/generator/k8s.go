@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	k8sPortHTTP    = "http"
+	k8sPortHealth  = "health"
+	k8sPortMetrics = "metrics"
+)
+
+type k8s struct {
+	*Transport
+}
+
+func newK8s(tr *Transport) (doc *k8s) {
+
+	doc = &k8s{Transport: tr}
+	return
+}
+
+func (app *k8s) render(cfg K8sConfig, outFilePath string) (err error) {
+
+	outFilePath, _ = filepath.Abs(outFilePath)
+	if err = os.MkdirAll(outFilePath, 0777); err != nil {
+		return
+	}
+	labels := map[string]string{"app": cfg.AppName}
+	meta := k8sMeta{Name: cfg.AppName, Namespace: cfg.Namespace, Labels: labels}
+	livenessProbe := &k8sProbe{
+		HTTPGet:             k8sHTTPGet{Path: "/livez", Port: k8sPortHealth},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+	}
+	readinessProbe := &k8sProbe{
+		HTTPGet:             k8sHTTPGet{Path: "/readyz", Port: k8sPortHealth},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+	}
+	deployment := k8sDeployment{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata:   meta,
+		Spec: k8sDeploymentSpec{
+			Replicas: cfg.Replicas,
+			Selector: k8sSelector{MatchLabels: labels},
+			Template: k8sPodTemplate{
+				Metadata: k8sMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   strconv.Itoa(cfg.MetricsPort),
+						"prometheus.io/path":   cfg.MetricsPath,
+					},
+				},
+				Spec: k8sPodSpec{
+					Containers: []k8sContainer{
+						{
+							Name:  cfg.AppName,
+							Image: cfg.Image,
+							Ports: []k8sContainerPort{
+								{Name: k8sPortHTTP, ContainerPort: cfg.Port},
+								{Name: k8sPortHealth, ContainerPort: cfg.HealthPort},
+								{Name: k8sPortMetrics, ContainerPort: cfg.MetricsPort},
+							},
+							LivenessProbe:  livenessProbe,
+							ReadinessProbe: readinessProbe,
+						},
+					},
+				},
+			},
+		},
+	}
+	service := k8sService{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   meta,
+		Spec: k8sServiceSpec{
+			Selector: labels,
+			Ports: []k8sServicePort{
+				{Name: k8sPortHTTP, Port: cfg.Port, TargetPort: k8sPortHTTP},
+				{Name: k8sPortMetrics, Port: cfg.MetricsPort, TargetPort: k8sPortMetrics},
+			},
+		},
+	}
+	ingress := k8sIngress{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Metadata:   meta,
+		Spec: k8sIngressSpec{
+			Rules: []k8sIngressRule{
+				{
+					Host: cfg.Host,
+					HTTP: k8sIngressRuleHTTP{Paths: app.ingressPaths(cfg.AppName)},
+				},
+			},
+		},
+	}
+	manifests := map[string]interface{}{
+		"deployment.yaml": deployment,
+		"service.yaml":    service,
+		"ingress.yaml":    ingress,
+	}
+	for fileName, manifest := range manifests {
+		var data []byte
+		if data, err = yaml.Marshal(manifest); err != nil {
+			return
+		}
+		if err = generated.WriteFile(path.Join(outFilePath, fileName), data, 0600); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ingressPaths returns exact paths of static routes and prefixes of routes with path parameters.
+func (app *k8s) ingressPaths(serviceName string) (paths []k8sIngressPath) {
+
+	known := make(map[string]bool)
+	for _, route := range app.routes() {
+		routePath, pathType := route.path, "Exact"
+		if idx := strings.Index(routePath, "{"); idx >= 0 {
+			routePath, pathType = path.Dir(routePath[:idx+1]), "Prefix"
+		}
+		if known[pathType+routePath] {
+			continue
+		}
+		known[pathType+routePath] = true
+		paths = append(paths, k8sIngressPath{
+			Path:     routePath,
+			PathType: pathType,
+			Backend: k8sIngressBackend{
+				Service: k8sIngressService{Name: serviceName, Port: k8sIngressServicePort{Name: k8sPortHTTP}},
+			},
+		})
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].Path == paths[j].Path {
+			return paths[i].PathType < paths[j].PathType
+		}
+		return paths[i].Path < paths[j].Path
+	})
+	return
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderK8s(t *testing.T) {
+
+	tr, err := NewTransport(testLog(), "test", "testdata/files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "k8s")
+	cfg := K8sConfig{
+		AppName:     "files",
+		Image:       "registry/files:1.0",
+		Namespace:   "apps",
+		Host:        "files.example.com",
+		Replicas:    2,
+		Port:        9000,
+		HealthPort:  9091,
+		MetricsPort: 9090,
+		MetricsPath: "/metrics",
+	}
+	if err = tr.RenderK8s(cfg, outDir); err != nil {
+		t.Fatal(err)
+	}
+	files := readFiles(t, outDir)
+	for fileName, content := range files {
+		var manifest map[string]any
+		if err = yaml.Unmarshal([]byte(content), &manifest); err != nil {
+			t.Errorf("%s is invalid: %v", fileName, err)
+		}
+	}
+	assertContains(t, files, "deployment.yaml",
+		"replicas: 2",
+		"image: registry/files:1.0",
+		"prometheus.io/port: \"9090\"",
+		"path: /livez",
+		"path: /readyz",
+		"port: health",
+	)
+	assertContains(t, files, "service.yaml", "port: 9000", "targetPort: metrics")
+	assertContains(t, files, "ingress.yaml",
+		"host: files.example.com",
+		"- path: /api/files\n",
+		"pathType: Prefix",
+		"- path: /api/files/upload\n",
+		"pathType: Exact",
+	)
+}
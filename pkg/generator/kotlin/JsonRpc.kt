@@ -0,0 +1,108 @@
+import java.net.HttpURLConnection
+import java.net.URL
+import java.util.UUID
+import kotlinx.coroutines.Dispatchers
+import kotlinx.coroutines.withContext
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.json.Json
+import kotlinx.serialization.json.JsonElement
+import kotlinx.serialization.json.JsonNull
+import kotlinx.serialization.json.JsonObject
+import kotlinx.serialization.json.decodeFromJsonElement
+import kotlinx.serialization.json.encodeToJsonElement
+
+/** Error of JSON-RPC call, returned by server. */
+class RPCError(val code: Int, override val message: String, val data: JsonElement? = null) : Exception() {
+    override fun toString(): String = "$code: $message"
+}
+
+/** HTTP request failed with status code. */
+class HTTPError(val code: Int, override val message: String) : Exception() {
+    override fun toString(): String = "$code: $message"
+}
+
+/** Transport sends JSON-RPC request and returns response body. */
+interface Transport {
+    suspend fun send(body: String): String
+}
+
+/** Transport by HTTP POST to endpoint. */
+class HttpTransport(
+    private val endpoint: String,
+    private val headers: () -> Map<String, String> = { emptyMap() },
+    private val timeoutMillis: Int = 30_000,
+) : Transport {
+
+    override suspend fun send(body: String): String = withContext(Dispatchers.IO) {
+        val connection = URL(endpoint).openConnection() as HttpURLConnection
+        try {
+            connection.requestMethod = "POST"
+            connection.doOutput = true
+            connection.connectTimeout = timeoutMillis
+            connection.readTimeout = timeoutMillis
+            connection.setRequestProperty("Accept", "application/json")
+            connection.setRequestProperty("Content-Type", "application/json")
+            headers().forEach { (key, value) -> connection.setRequestProperty(key, value) }
+            connection.outputStream.use { it.write(body.toByteArray()) }
+            val code = connection.responseCode
+            if (code !in 200..299) {
+                throw HTTPError(code, connection.responseMessage ?: "")
+            }
+            connection.inputStream.bufferedReader().use { it.readText() }
+        } finally {
+            connection.disconnect()
+        }
+    }
+}
+
+@Serializable
+internal data class RequestRPC(
+    val id: String,
+    val method: String,
+    val params: JsonObject,
+    val jsonrpc: String = "2.0",
+)
+
+@Serializable
+internal data class ErrorRPC(
+    val code: Int,
+    val message: String,
+    val data: JsonElement? = null,
+)
+
+@Serializable
+internal data class ResponseRPC(
+    val id: String? = null,
+    val jsonrpc: String = "2.0",
+    val error: ErrorRPC? = null,
+    val result: JsonElement? = null,
+)
+
+/** Client of JSON-RPC 2.0 protocol. */
+class JsonRpcClient(private val transport: Transport, val json: Json = defaultJson) {
+
+    suspend fun call(method: String, params: JsonObject): JsonObject {
+        val request = RequestRPC(UUID.randomUUID().toString(), method, params)
+        val body = transport.send(json.encodeToString(RequestRPC.serializer(), request))
+        val response = json.decodeFromString(ResponseRPC.serializer(), body)
+        response.error?.let { throw RPCError(it.code, it.message, it.data) }
+        return response.result as? JsonObject ?: JsonObject(emptyMap())
+    }
+
+    inline fun <reified T> encode(value: T): JsonElement = json.encodeToJsonElement(value)
+
+    /** Decodes value or returns default, when value is absent, as exchange structures omit empty fields. */
+    inline fun <reified T> decode(value: JsonElement?, default: T): T =
+        if (value == null || value is JsonNull) default else json.decodeFromJsonElement(value)
+
+    companion object {
+        val defaultJson = Json {
+            ignoreUnknownKeys = true
+            explicitNulls = false
+            // null of Go pointer is decoded to default value of not nullable property
+            coerceInputValues = true
+            encodeDefaults = true
+        }
+    }
+}
@@ -0,0 +1,40 @@
+package generator
+
+type samTemplate struct {
+	AWSTemplateFormatVersion string                 `yaml:"AWSTemplateFormatVersion"`
+	Transform                string                 `yaml:"Transform"`
+	Description              string                 `yaml:"Description,omitempty"`
+	Resources                map[string]samResource `yaml:"Resources"`
+	Outputs                  map[string]samOutput   `yaml:"Outputs,omitempty"`
+}
+
+type samResource struct {
+	Type       string            `yaml:"Type"`
+	Metadata   map[string]string `yaml:"Metadata,omitempty"`
+	Properties samFunction       `yaml:"Properties"`
+}
+
+type samFunction struct {
+	CodeURI       string              `yaml:"CodeUri"`
+	Handler       string              `yaml:"Handler"`
+	Runtime       string              `yaml:"Runtime"`
+	Architectures []string            `yaml:"Architectures,omitempty"`
+	MemorySize    int                 `yaml:"MemorySize,omitempty"`
+	Timeout       int                 `yaml:"Timeout,omitempty"`
+	Events        map[string]samEvent `yaml:"Events,omitempty"`
+}
+
+type samEvent struct {
+	Type       string          `yaml:"Type"`
+	Properties samHttpAPIEvent `yaml:"Properties"`
+}
+
+type samHttpAPIEvent struct {
+	Path   string `yaml:"Path"`
+	Method string `yaml:"Method"`
+}
+
+type samOutput struct {
+	Description string            `yaml:"Description,omitempty"`
+	Value       map[string]string `yaml:"Value"`
+}
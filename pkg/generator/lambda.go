@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+type lambda struct {
+	*Transport
+}
+
+func newLambda(tr *Transport) (doc *lambda) {
+
+	doc = &lambda{Transport: tr}
+	return
+}
+
+func (app *lambda) render(appName, codeURI, outFilePath string, memorySize, timeout int) (err error) {
+
+	outFilePath, _ = filepath.Abs(outFilePath)
+
+	events := make(map[string]samEvent)
+	for _, route := range app.routes() {
+		events[route.name] = samEvent{
+			Type: "HttpApi",
+			Properties: samHttpAPIEvent{
+				Path:   route.path,
+				Method: route.method,
+			},
+		}
+	}
+	functionName := utils.ToCamel(appName) + "Function"
+	template := samTemplate{
+		AWSTemplateFormatVersion: "2010-09-09",
+		Transform:                "AWS::Serverless-2016-10-31",
+		Description:              app.tags.Value(tagTitle, appName),
+		Resources: map[string]samResource{
+			functionName: {
+				Type:     "AWS::Serverless::Function",
+				Metadata: map[string]string{"BuildMethod": "go1.x"},
+				Properties: samFunction{
+					CodeURI:       codeURI,
+					Handler:       "bootstrap",
+					Runtime:       "provided.al2023",
+					Architectures: []string{"arm64"},
+					MemorySize:    memorySize,
+					Timeout:       timeout,
+					Events:        events,
+				},
+			},
+		},
+		Outputs: map[string]samOutput{
+			"ApiEndpoint": {
+				Description: "API Gateway endpoint URL",
+				Value:       map[string]string{"Fn::Sub": "https://${ServerlessHttpApi}.execute-api.${AWS::Region}.${AWS::URLSuffix}/"},
+			},
+		},
+	}
+	var data []byte
+	if data, err = yaml.Marshal(template); err != nil {
+		return
+	}
+	outFileName := path.Join(outFilePath, "template.yaml")
+	if err = os.MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
+		return
+	}
+	return generated.WriteFile(outFileName, data, 0600)
+}
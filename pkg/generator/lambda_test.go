@@ -0,0 +1,34 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderLambda(t *testing.T) {
+
+	tr, err := NewTransport(testLog(), "test", "testdata/files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "lambda")
+	if err = tr.RenderLambda("files", "./cmd/lambda", outDir, 256, 30); err != nil {
+		t.Fatal(err)
+	}
+	files := readFiles(t, outDir)
+	var template map[string]any
+	if err = yaml.Unmarshal([]byte(files["template.yaml"]), &template); err != nil {
+		t.Fatalf("template.yaml is invalid: %v", err)
+	}
+	// every verb of method gets its own event, path parameters are in API Gateway form
+	assertContains(t, files, "template.yaml",
+		"CodeUri: ./cmd/lambda",
+		"MemorySize: 256",
+		"Timeout: 30",
+		"FilesGet:\n                    Type: HttpApi\n                    Properties:\n                        Path: /api/files/{id}\n                        Method: GET",
+		"FilesUpload:\n                    Type: HttpApi\n                    Properties:\n                        Path: /api/files/upload\n                        Method: POST",
+		"FilesUploadPut:\n                    Type: HttpApi\n                    Properties:\n                        Path: /api/files/upload\n                        Method: PUT",
+	)
+}
@@ -0,0 +1,364 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// LintIssue is annotation error found in services package.
+type LintIssue struct {
+	Pos     token.Position
+	Message string
+}
+
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", issue.Pos, issue.Message)
+}
+
+type lintTag struct {
+	key   string
+	value string
+	pos   token.Position
+}
+
+type lintMethod struct {
+	name string
+	pos  token.Position
+	tags []lintTag
+	// args are types of method arguments by names.
+	args map[string]ast.Expr
+}
+
+type lintInterface struct {
+	name string
+	pos  token.Position
+	tags []lintTag
+	// pkgTags are annotations of package, which are applied to every interface of it.
+	pkgTags []lintTag
+	// pkgTypes are declarations of package types by names.
+	pkgTypes map[string]ast.Expr
+	methods  []lintMethod
+}
+
+type linter struct {
+	fset     *token.FileSet
+	issues   []LintIssue
+	attached map[*ast.CommentGroup]bool
+}
+
+// Lint checks annotations of services packages against registry of known annotations.
+func Lint(svcDir string) (issues []LintIssue, err error) {
+
+	var dirs []string
+	if dirs, err = ServiceDirs(svcDir); err != nil {
+		return
+	}
+	l := &linter{fset: token.NewFileSet(), attached: make(map[*ast.CommentGroup]bool)}
+	var ifaces []lintInterface
+	for _, dir := range dirs {
+		var pkgTags []lintTag
+		var pkgIfaces []lintInterface
+		pkgTypes := make(map[string]ast.Expr)
+		var files []os.DirEntry
+		if files, err = os.ReadDir(dir); err != nil {
+			return
+		}
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") {
+				continue
+			}
+			var fileAst *ast.File
+			if fileAst, err = parser.ParseFile(l.fset, path.Join(dir, file.Name()), nil, parser.ParseComments); err != nil {
+				return
+			}
+			pkgTags = append(pkgTags, l.annotations(levelPackage, nil, fileAst.Doc)...)
+			pkgIfaces = append(pkgIfaces, l.declarations(fileAst, pkgTypes)...)
+			l.unattached(fileAst)
+		}
+		for _, iface := range pkgIfaces {
+			iface.pkgTags = pkgTags
+			iface.pkgTypes = pkgTypes
+			ifaces = append(ifaces, iface)
+		}
+	}
+	for _, iface := range ifaces {
+		l.checkInterface(iface)
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		a, b := l.issues[i].Pos, l.issues[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.issues, nil
+}
+
+func (l *linter) report(pos token.Position, format string, args ...any) {
+	l.issues = append(l.issues, LintIssue{Pos: pos, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) declarations(file *ast.File, pkgTypes map[string]ast.Expr) (ifaces []lintInterface) {
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		var declDoc *ast.CommentGroup
+		if len(genDecl.Specs) == 1 {
+			declDoc = genDecl.Doc
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			pkgTypes[typeSpec.Name.Name] = typeSpec.Type
+			switch typ := typeSpec.Type.(type) {
+			case *ast.InterfaceType:
+				iface := lintInterface{
+					name: typeSpec.Name.Name,
+					pos:  l.fset.Position(typeSpec.Name.Pos()),
+					tags: l.annotations(levelInterface, nil, declDoc, typeSpec.Doc, typeSpec.Comment),
+				}
+				for _, field := range typ.Methods.List {
+					fn, isFunc := field.Type.(*ast.FuncType)
+					if !isFunc || len(field.Names) == 0 {
+						continue
+					}
+					iface.methods = append(iface.methods, lintMethod{
+						name: field.Names[0].Name,
+						pos:  l.fset.Position(field.Names[0].Pos()),
+						tags: l.annotations(levelMethod, funcVarNames(fn), field.Doc, field.Comment),
+						args: funcArgTypes(fn),
+					})
+				}
+				ifaces = append(ifaces, iface)
+			case *ast.StructType:
+				l.annotations(levelType, nil, declDoc, typeSpec.Doc, typeSpec.Comment)
+				for _, field := range typ.Fields.List {
+					l.annotations(levelType, nil, field.Doc, field.Comment)
+				}
+			default:
+				l.annotations(levelType, nil, declDoc, typeSpec.Doc, typeSpec.Comment)
+			}
+		}
+	}
+	return
+}
+
+// annotations checks every annotation of comment groups on level. vars are names of method arguments and results.
+func (l *linter) annotations(level annotationLevel, vars []string, groups ...*ast.CommentGroup) (found []lintTag) {
+
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		l.attached[group] = true
+		for _, comment := range group.List {
+			values, isTag, err := tags.ParseLine(comment.Text)
+			if !isTag {
+				continue
+			}
+			pos := l.fset.Position(comment.Slash)
+			if err != nil {
+				l.report(pos, "invalid annotation: %s", err)
+			}
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				tag := lintTag{key: key, value: values[key], pos: pos}
+				if idx := strings.Index(comment.Text, key); idx > 0 {
+					tag.pos.Column += idx
+				}
+				if l.checkTag(tag, level, vars) {
+					found = append(found, tag)
+				}
+			}
+		}
+	}
+	return
+}
+
+func (l *linter) checkTag(tag lintTag, level annotationLevel, vars []string) bool {
+
+	key := tag.key
+	if varName, subKey, isVar := strings.Cut(tag.key, "."); isVar && level == levelMethod {
+		if !slices.Contains(vars, varName) {
+			l.report(tag.pos, "annotation %q refers to unknown variable %q", tag.key, varName)
+			return false
+		}
+		key, level = subKey, levelVariable
+	}
+	spec, known := annotations[key]
+	if !known {
+		if closest := closestAnnotation(key); closest != "" {
+			l.report(tag.pos, "unknown annotation %q, did you mean %q?", key, closest)
+			return false
+		}
+		l.report(tag.pos, "unknown annotation %q", key)
+		return false
+	}
+	if spec.levels&level == 0 {
+		l.report(tag.pos, "annotation %q is not allowed at %s level (allowed: %s)", key, level, spec.levels)
+		return false
+	}
+	switch {
+	case spec.flag && tag.value != "":
+		l.report(tag.pos, "annotation %q is a flag and takes no value", key)
+	case !spec.flag && tag.value == "":
+		l.report(tag.pos, "annotation %q requires value: %s=%s", key, key, spec.syntax)
+	case !spec.flag && !spec.value.MatchString(tag.value):
+		l.report(tag.pos, "invalid value %q of annotation %q, expected %s=%s", tag.value, key, key, spec.syntax)
+	}
+	return true
+}
+
+func (l *linter) checkInterface(iface lintInterface) {
+
+	if len(iface.tags) == 0 {
+		return
+	}
+	hasHTTP, hasJsonRPC := hasLintTag(iface.tags, tagServerHTTP), hasLintTag(iface.tags, tagServerJsonRPC)
+	if !hasHTTP && !hasJsonRPC {
+		l.report(iface.pos, "interface %q has no transport, add %q or %q annotation", iface.name, tagServerHTTP, tagServerJsonRPC)
+	}
+	l.checkRelations(iface.tags, iface.pkgTags)
+	scope := append(slices.Clone(iface.pkgTags), iface.tags...)
+	for _, method := range iface.methods {
+		l.checkRelations(method.tags, scope)
+		l.checkMetricsLabels(method, iface.pkgTypes)
+		isHTTP := hasLintTag(method.tags, tagMethodHTTP)
+		if hasHTTP && !hasJsonRPC && !isHTTP {
+			l.report(method.pos, "method %q is not served, add %q annotation to method or %q to interface %q", method.name, tagMethodHTTP, tagServerJsonRPC, iface.name)
+		}
+	}
+}
+
+// checkRelations checks requirements and conflicts of own annotations. Annotations of scope are applied by enclosing interface or package.
+func (l *linter) checkRelations(own, scope []lintTag) {
+
+	for _, tag := range own {
+		spec, known := annotations[tag.key]
+		if !known {
+			continue
+		}
+		for _, required := range spec.requires {
+			if !hasLintTag(own, required) && !hasLintTag(scope, required) {
+				l.report(tag.pos, "annotation %q requires %q annotation", tag.key, required)
+			}
+		}
+		for _, conflict := range spec.conflicts {
+			if tag.key < conflict && hasLintTag(own, conflict) {
+				l.report(tag.pos, "annotation %q conflicts with %q annotation", tag.key, conflict)
+			}
+		}
+	}
+}
+
+// checkMetricsLabels reports arguments of metrics-labels annotation, which are not string, integer or bool.
+// Types of other packages are checked by generator.
+func (l *linter) checkMetricsLabels(method lintMethod, pkgTypes map[string]ast.Expr) {
+
+	for _, tag := range method.tags {
+		if tag.key != tagMetricsLabels {
+			continue
+		}
+		for _, name := range strings.Split(tag.value, ",") {
+			argType, found := method.args[name]
+			if !found {
+				l.report(tag.pos, "annotation %q refers to unknown argument %q", tag.key, name)
+				continue
+			}
+			if !isScalarExpr(argType, pkgTypes, make(map[string]bool)) {
+				l.report(tag.pos, "argument %q of annotation %q must be string, integer or bool", name, tag.key)
+			}
+		}
+	}
+}
+
+// unattached reports annotations, which are not attached to any declaration and therefore ignored by generator.
+func (l *linter) unattached(file *ast.File) {
+
+	for _, group := range file.Comments {
+		if l.attached[group] {
+			continue
+		}
+		for _, comment := range group.List {
+			if _, isTag, _ := tags.ParseLine(comment.Text); isTag {
+				l.report(l.fset.Position(comment.Slash), "annotation is not attached to package, interface, method or type")
+				break
+			}
+		}
+	}
+}
+
+func hasLintTag(list []lintTag, key string) bool {
+
+	for _, tag := range list {
+		if tag.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func funcVarNames(fn *ast.FuncType) (names []string) {
+
+	for _, fields := range []*ast.FieldList{fn.Params, fn.Results} {
+		if fields == nil {
+			continue
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	return
+}
+
+func funcArgTypes(fn *ast.FuncType) (args map[string]ast.Expr) {
+
+	args = make(map[string]ast.Expr)
+	for _, field := range fn.Params.List {
+		for _, name := range field.Names {
+			args[name.Name] = field.Type
+		}
+	}
+	return
+}
+
+func isScalarExpr(expr ast.Expr, pkgTypes map[string]ast.Expr, visited map[string]bool) bool {
+
+	switch typ := expr.(type) {
+	case *ast.Ident:
+		nextType, declared := pkgTypes[typ.Name]
+		if !declared {
+			return scalarTypes[typ.Name]
+		}
+		if visited[typ.Name] {
+			return false
+		}
+		visited[typ.Name] = true
+		return isScalarExpr(nextType, pkgTypes, visited)
+	case *ast.SelectorExpr:
+		return true
+	case *ast.ParenExpr:
+		return isScalarExpr(typ.X, pkgTypes, visited)
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		source string
+		issues []string
+	}{
+		{
+			name: "valid",
+			source: `package svc
+
+import "context"
+
+// @tg http-server jsonRPC-server
+// @tg http-prefix=api
+type Files interface {
+	// @tg http-method=GET|HEAD
+	// @tg http-success=200
+	Find(ctx context.Context, limit int) (names []string, err error)
+	Remove(ctx context.Context, name string) (err error)
+}
+`,
+		},
+		{
+			name: "unknown annotation",
+			source: `package svc
+
+import "context"
+
+// @tg jsonRPC-server
+// @tg loger
+// @tg no-such-thing
+type Files interface {
+	Find(ctx context.Context) (err error)
+}
+`,
+			issues: []string{
+				`unknown annotation "loger", did you mean "log"?`,
+				`unknown annotation "no-such-thing"`,
+			},
+		},
+		{
+			name: "misplaced annotation",
+			source: `// @tg http-path=/files
+package svc
+
+import "context"
+
+// @tg jsonRPC-server
+type Files interface {
+	// @tg http-prefix=api
+	Find(ctx context.Context) (err error)
+}
+`,
+			issues: []string{
+				`annotation "http-path" is not allowed at package level (allowed: method)`,
+				`annotation "http-prefix" is not allowed at method level (allowed: package, interface)`,
+			},
+		},
+		{
+			name: "flag and value",
+			source: `package svc
+
+import "context"
+
+// @tg jsonRPC-server=true
+// @tg http-prefix
+type Files interface {
+	// @tg http-success=600
+	Find(ctx context.Context) (err error)
+}
+`,
+			issues: []string{
+				`annotation "jsonRPC-server" is a flag and takes no value`,
+				`annotation "http-prefix" requires value: http-prefix=<path>`,
+				`invalid value "600" of annotation "http-success", expected http-success=<HTTP code>`,
+				`annotation "http-success" requires "http-method" annotation`,
+			},
+		},
+		{
+			name: "requires and conflicts",
+			source: `package svc
+
+import "context"
+
+// @tg jsonRPC-server
+type Files interface {
+	// @tg since=v2
+	// @tg handler=files:Find http-response=files:Find
+	Find(ctx context.Context) (err error)
+	// @tg trace-attrs=name
+	Remove(ctx context.Context, name string) (err error)
+}
+`,
+			issues: []string{
+				`annotation "since" requires "version" annotation`,
+				`annotation "handler" conflicts with "http-response" annotation`,
+				`annotation "trace-attrs" requires "trace" annotation`,
+			},
+		},
+		{
+			name: "variables",
+			source: `package svc
+
+import "context"
+
+// @tg jsonRPC-server
+type Files interface {
+	// @tg name.desc=` + "`file name`" + `
+	// @tg size.desc=` + "`file size`" + `
+	Remove(ctx context.Context, name string) (err error)
+}
+`,
+			issues: []string{
+				`annotation "size.desc" refers to unknown variable "size"`,
+			},
+		},
+		{
+			name: "transport",
+			source: `package svc
+
+import "context"
+
+// @tg log
+type Files interface {
+	Find(ctx context.Context) (err error)
+}
+
+// @tg http-server
+type Dirs interface {
+	// @tg http-method=GET
+	List(ctx context.Context) (err error)
+	Remove(ctx context.Context) (err error)
+}
+`,
+			issues: []string{
+				`interface "Files" has no transport, add "http-server" or "jsonRPC-server" annotation`,
+				`method "Remove" is not served, add "http-method" annotation to method or "jsonRPC-server" to interface "Dirs"`,
+			},
+		},
+		{
+			name: "unattached",
+			source: `package svc
+
+import "context"
+
+// @tg jsonRPC-server
+type Files interface {
+	Find(ctx context.Context) (err error)
+}
+
+// @tg log
+`,
+			issues: []string{
+				`annotation is not attached to package, interface, method or type`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if issues := lintSource(t, test.source); !slices.Equal(issues, test.issues) {
+				t.Errorf("issues:\n%q\nwant:\n%q", issues, test.issues)
+			}
+		})
+	}
+}
+
+// lintSource lints package of single file with source and returns messages of found issues.
+func lintSource(t *testing.T, source string) (messages []string) {
+
+	t.Helper()
+	svcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(svcDir, "svc.go"), []byte(source), 0600); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := Lint(svcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return
+}
+
+func TestLintMetricsLabels(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		labels string
+		issues []string
+	}{
+		{name: "scalars", labels: "name,limit,status,force"},
+		{name: "imported", labels: "timeout"},
+		{name: "struct", labels: "filter", issues: []string{`argument "filter" of annotation "metrics-labels" must be string, integer or bool`}},
+		{name: "slice", labels: "tags", issues: []string{`argument "tags" of annotation "metrics-labels" must be string, integer or bool`}},
+		{name: "unknown", labels: "owner", issues: []string{`annotation "metrics-labels" refers to unknown argument "owner"`}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := `package svc
+
+import (
+	"context"
+	"time"
+)
+
+type Status uint8
+
+type Filter struct{ Name string }
+
+// @tg jsonRPC-server metrics
+type Files interface {
+	// @tg metrics-labels=` + test.labels + `
+	Find(ctx context.Context, name string, limit int, status Status, force bool, timeout time.Duration, filter Filter, tags []string) (err error)
+}
+`
+			if issues := lintSource(t, source); !slices.Equal(issues, test.issues) {
+				t.Errorf("issues:\n%q\nwant:\n%q", issues, test.issues)
+			}
+		})
+	}
+}
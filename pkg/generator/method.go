@@ -5,7 +5,9 @@ package generator
 
 import (
 	"fmt"
+	"net/http"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -71,16 +73,24 @@ func (m *method) responseStructName() string {
 }
 
 func (m *method) httpPath(withoutPrefix ...bool) string {
+	return m.httpPathVersion(m.svc.apiVersion(), withoutPrefix...)
+}
+
+func (m *method) httpPathVersion(version string, withoutPrefix ...bool) string {
 	var elements []string
 	if len(withoutPrefix) == 0 {
 		elements = append(elements, "/")
 	}
 	prefix := m.svc.tags.Value(tagHttpPrefix)
 	urlPath := m.tags.Value(tagHttpPath, path.Join("/", m.svc.lccName(), m.lccName()))
-	return path.Join(append(elements, prefix, urlPath)...)
+	return path.Join(append(elements, version, prefix, urlPath)...)
 }
 
 func (m *method) httpPathSwagger(withoutPrefix ...bool) string {
+	return m.httpPathSwaggerVersion(m.svc.apiVersion(), withoutPrefix...)
+}
+
+func (m *method) httpPathSwaggerVersion(version string, withoutPrefix ...bool) string {
 	var elements []string
 	if len(withoutPrefix) == 0 {
 		elements = append(elements, "/")
@@ -97,35 +107,71 @@ func (m *method) httpPathSwagger(withoutPrefix ...bool) string {
 		pathTokens = append(pathTokens, pathItem)
 	}
 	urlPath = strings.Join(pathTokens, "/")
-	return path.Join(append(elements, prefix, urlPath)...)
+	return path.Join(append(elements, version, prefix, urlPath)...)
 }
 
 func (m *method) jsonrpcPath(withoutPrefix ...bool) string {
+	return m.jsonrpcPathVersion(m.svc.apiVersion(), withoutPrefix...)
+}
+
+func (m *method) jsonrpcPathVersion(version string, withoutPrefix ...bool) string {
 	var elements []string
 	if len(withoutPrefix) == 0 {
 		elements = append(elements, "/")
 	}
 	prefix := m.svc.tags.Value(tagHttpPrefix)
 	urlPath := formatPathURL(m.tags.Value(tagHttpPath, path.Join("/", m.svc.lccName(), m.lccName())))
-	return path.Join(append(elements, prefix, urlPath)...)
+	return path.Join(append(elements, version, prefix, urlPath)...)
+}
+
+// httpMethods returns upper-cased verbs of http-method tag, which may list several of them (e.g. GET|HEAD).
+func (m *method) httpMethods() (methods []string) {
+
+	for _, verb := range strings.Split(m.tags.Value(tagMethodHTTP), "|") {
+		if verb = strings.ToUpper(strings.TrimSpace(verb)); verb != "" && !slices.Contains(methods, verb) {
+			methods = append(methods, verb)
+		}
+	}
+	if len(methods) == 0 {
+		return []string{http.MethodPost}
+	}
+	return
 }
 
+// httpMethod returns the primary (first) verb in lower case.
 func (m *method) httpMethod() string {
+	return strings.ToLower(m.httpMethods()[0])
+}
 
-	switch strings.ToUpper(m.tags.Value(tagMethodHTTP)) {
-	case "GET":
-		return "get"
-	case "PUT":
-		return "put"
-	case "PATCH":
-		return "patch"
-	case "DELETE":
-		return "delete"
-	case "OPTIONS":
-		return "options"
-	default:
-		return "post"
+// routeMethods returns verbs to register routes with. HEAD is served by GET route when both are listed.
+func (m *method) routeMethods() (methods []string) {
+
+	for _, verb := range m.httpMethods() {
+		if verb == http.MethodHead && slices.Contains(m.httpMethods(), http.MethodGet) {
+			continue
+		}
+		methods = append(methods, verb)
 	}
+	return
+}
+
+// httpMethodName returns name of client function for verb. Primary verb keeps the method name.
+func (m *method) httpMethodName(verb string) string {
+
+	if strings.EqualFold(verb, m.httpMethod()) {
+		return m.Name
+	}
+	return m.Name + utils.ToCamel(strings.ToLower(verb))
+}
+
+func isStandardHTTPMethod(verb string) bool {
+
+	switch verb {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
 }
 
 func formatPathURL(url string) string {
@@ -200,6 +246,9 @@ func (m *method) urlArgs(errStatement func(arg, header string) *Statement) (g *S
 
 	return m.argFromString("urlParam", m.argPathMap(),
 		func(srcName string) Code {
+			if m.svc.tr.isNetHTTP() {
+				return Id("r").Dot("PathValue").Call(Lit(srcName))
+			}
 			return Id(_ctx_).Dot("Params").Call(Lit(srcName))
 		},
 		errStatement,
@@ -210,6 +259,9 @@ func (m *method) urlParams(errStatement func(arg, header string) *Statement) (bl
 
 	return m.argFromString("urlParam", m.argParamMap(),
 		func(srcName string) Code {
+			if m.svc.tr.isNetHTTP() {
+				return Id("r").Dot("URL").Dot("Query").Call().Dot("Get").Call(Lit(srcName))
+			}
 			return Id(_ctx_).Dot("Query").Call(Lit(srcName))
 		},
 		errStatement,
@@ -221,6 +273,9 @@ func (m *method) httpArgHeaders(errStatement func(arg, header string) *Statement
 	return m.argFromString("header", m.varHeaderMap(),
 		func(srcName string) Code {
 			srcName = strings.TrimPrefix(srcName, "!")
+			if m.svc.tr.isNetHTTP() {
+				return Id("r").Dot("Header").Dot("Get").Call(Lit(srcName))
+			}
 			return String().Call(Id(_ctx_).Dot("Request").Call().Dot("Header").Dot("Peek").Call(Lit(srcName)))
 		},
 		errStatement,
@@ -232,6 +287,9 @@ func (m *method) httpCookies(errStatement func(arg, header string) *Statement) (
 	return m.argFromString("cookie", m.varCookieMap(),
 		func(srcName string) Code {
 			srcName = strings.TrimPrefix(srcName, "!")
+			if m.svc.tr.isNetHTTP() {
+				return Id("cookieValue").Call(Id("r"), Lit(srcName))
+			}
 			return Id(_ctx_).Dot("Cookies").Call(Lit(srcName))
 		},
 		errStatement,
@@ -301,6 +359,10 @@ func (m *method) httpRetHeaders() (block *Statement) {
 				}
 				continue
 			}
+			if m.svc.tr.isNetHTTP() {
+				block.Id("w").Dot("Header").Call().Dot("Set").Call(Lit(header), Qual(packageFmt, "Sprint").Call(Id("response").Dot(utils.ToCamel(ret))))
+				continue
+			}
 			block.Id(_ctx_).Dot("Set").Call(Lit(header), Qual(packageFmt, "Sprint").Call(Id("response").Dot(utils.ToCamel(ret))))
 		}
 	}
@@ -451,6 +513,22 @@ func (m *method) resultByName(retName string) (variable *types.Variable) {
 	return
 }
 
+// varNames returns names of arguments and results listed by annotation, unknown names are reported and skipped.
+func (m *method) varNames(tagName string) (names []string) {
+
+	for _, name := range strings.Split(m.tags.Value(tagName), ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if m.argByName(name) == nil && m.resultByName(name) == nil {
+			m.log.Warnf("%s: '%s' refers to unknown variable '%s'", m.fullName(), tagName, name)
+			continue
+		}
+		names = append(names, name)
+	}
+	return
+}
+
 func (m *method) fieldsResult() []types.StructField {
 	return m.resultFields
 }
@@ -590,6 +668,9 @@ func (m *method) varsToFields(vars []types.Variable, tags tags.DocTags, excludes
 				}
 			}
 		}
+		if policy := tags.Sub(variable.Name).Value(tagLogRedact); policy != "" {
+			field.Tags["dumper"] = []string{policy}
+		}
 		fields = append(fields, field)
 	}
 	return
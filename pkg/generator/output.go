@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const diffContext = 3
+
+// outputFiles writes generated files only when content is changed.
+// In check mode files are not written, differences are collected instead.
+type outputFiles struct {
+	lock    sync.Mutex
+	check   bool
+	written map[string]bool
+	diffs   map[string]string
+}
+
+var generated = &outputFiles{written: make(map[string]bool), diffs: make(map[string]string)}
+
+// SetCheck enables check mode, in which generated files are compared with files on disk, but not written.
+func (tr *Transport) SetCheck(enabled bool) {
+	generated.check = enabled
+}
+
+// Diff returns differences between generated and existing files, found in check mode.
+func (tr *Transport) Diff() string {
+
+	generated.lock.Lock()
+	defer generated.lock.Unlock()
+	names := make([]string, 0, len(generated.diffs))
+	for name := range generated.diffs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var diff strings.Builder
+	for _, name := range names {
+		diff.WriteString(generated.diffs[name])
+	}
+	return diff.String()
+}
+
+// WriteFile writes data to file when it differs from existing content.
+func (out *outputFiles) WriteFile(name string, data []byte, perm os.FileMode) (err error) {
+
+	out.lock.Lock()
+	defer out.lock.Unlock()
+	out.written[filepath.Clean(name)] = true
+	existing, err := os.ReadFile(name)
+	if err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	if out.check {
+		out.diffs[name] = unifiedDiff(name, existing, data)
+		return nil
+	}
+	return os.WriteFile(name, data, perm)
+}
+
+// isWritten reports whether file was generated during this run.
+func (out *outputFiles) isWritten(name string) bool {
+
+	out.lock.Lock()
+	defer out.lock.Unlock()
+	return out.written[filepath.Clean(name)]
+}
+
+// remove deletes stale generated file.
+func (out *outputFiles) remove(name string) (err error) {
+
+	out.lock.Lock()
+	defer out.lock.Unlock()
+	if out.check {
+		existing, _ := os.ReadFile(name)
+		out.diffs[name] = unifiedDiff(name, existing, nil)
+		return nil
+	}
+	return os.Remove(name)
+}
+
+// unifiedDiff returns diff with single hunk, covering all lines between common prefix and suffix.
+func unifiedDiff(name string, before, after []byte) string {
+
+	oldLines, newLines := splitLines(before), splitLines(after)
+	var prefix, suffix int
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	start := max(prefix-diffContext, 0)
+	oldEnd := min(len(oldLines)-suffix+diffContext, len(oldLines))
+	newEnd := min(len(newLines)-suffix+diffContext, len(newLines))
+
+	var diff strings.Builder
+	fromName, toName := "a/"+filepath.ToSlash(name), "b/"+filepath.ToSlash(name)
+	if before == nil {
+		fromName = "/dev/null"
+	}
+	if after == nil {
+		toName = "/dev/null"
+	}
+	fmt.Fprintf(&diff, "--- %s\n+++ %s\n", fromName, toName)
+	fmt.Fprintf(&diff, "@@ -%s +%s @@\n", hunkRange(start, oldEnd), hunkRange(start, newEnd))
+	for _, line := range oldLines[start:prefix] {
+		fmt.Fprintf(&diff, " %s\n", line)
+	}
+	for _, line := range oldLines[prefix : len(oldLines)-suffix] {
+		fmt.Fprintf(&diff, "-%s\n", line)
+	}
+	for _, line := range newLines[prefix : len(newLines)-suffix] {
+		fmt.Fprintf(&diff, "+%s\n", line)
+	}
+	for _, line := range oldLines[len(oldLines)-suffix : oldEnd] {
+		fmt.Fprintf(&diff, " %s\n", line)
+	}
+	return diff.String()
+}
+
+func hunkRange(start, end int) string {
+
+	if end == start {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, end-start)
+}
+
+func splitLines(data []byte) []string {
+
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
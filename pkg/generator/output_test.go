@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		diff   string
+	}{
+		{
+			name:   "changed",
+			before: "a\nb\nc\nd\ne\nf\ng\nh\n",
+			after:  "a\nb\nc\nd\nE\nf\ng\nh\n",
+			diff:   "--- a/x.go\n+++ b/x.go\n@@ -2,7 +2,7 @@\n b\n c\n d\n-e\n+E\n f\n g\n h\n",
+		},
+		{
+			name:   "appended",
+			before: "a\nb\n",
+			after:  "a\nb\nc\n",
+			diff:   "--- a/x.go\n+++ b/x.go\n@@ -1,2 +1,3 @@\n a\n b\n+c\n",
+		},
+		{
+			name:  "created",
+			after: "a\nb\n",
+			diff:  "--- /dev/null\n+++ b/x.go\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:   "removed",
+			before: "a\nb\n",
+			diff:   "--- a/x.go\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var before, after []byte
+			if test.before != "" {
+				before = []byte(test.before)
+			}
+			if test.after != "" {
+				after = []byte(test.after)
+			}
+			if diff := unifiedDiff("x.go", before, after); diff != test.diff {
+				t.Errorf("diff:\n%s\nwant:\n%s", diff, test.diff)
+			}
+		})
+	}
+}
+
+func TestRenderCheck(t *testing.T) {
+
+	t.Cleanup(func() {
+		generated.check = false
+		generated.diffs = make(map[string]string)
+	})
+	outDir := filepath.Join(t.TempDir(), "client")
+	render := func(check bool) (diff string) {
+		tr, err := NewTransport(testLog(), "test", "testdata/files")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr.SetCheck(check)
+		if err = tr.RenderClient(outDir); err != nil {
+			t.Fatal(err)
+		}
+		return tr.Diff()
+	}
+	render(false)
+	clientFile := filepath.Join(outDir, "files-http-client.go")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(clientFile, past, past); err != nil {
+		t.Fatal(err)
+	}
+	// unchanged file is not written again
+	if diff := render(false); diff != "" {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+	if info, err := os.Stat(clientFile); err != nil || !info.ModTime().Equal(past) {
+		t.Errorf("unchanged file is rewritten: %v", err)
+	}
+
+	content, err := os.ReadFile(clientFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := strings.Replace(string(content), "func (cli *ClientFiles) Get(", "func (cli *ClientFiles) Find(", 1)
+	staleFile := filepath.Join(outDir, "stale.go")
+	for fileName, data := range map[string]string{clientFile: edited, staleFile: "// " + doNotEdit + "\npackage client\n"} {
+		if err = os.WriteFile(fileName, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	diff := render(true)
+	for _, snippet := range []string{
+		"-func (cli *ClientFiles) Find(",
+		"+func (cli *ClientFiles) Get(",
+		"--- a/" + filepath.ToSlash(staleFile) + "\n+++ /dev/null\n",
+	} {
+		if !strings.Contains(diff, snippet) {
+			t.Errorf("diff does not contain %q:\n%s", snippet, diff)
+		}
+	}
+	// check mode leaves files untouched
+	if content, _ = os.ReadFile(clientFile); string(content) != edited {
+		t.Error("file is written in check mode")
+	}
+	if _, err = os.Stat(staleFile); err != nil {
+		t.Errorf("stale file is removed in check mode: %v", err)
+	}
+}
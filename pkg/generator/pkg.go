@@ -18,6 +18,9 @@ var tsFiles embed.FS
 //go:embed pkg/*
 var pkgFiles embed.FS
 
+//go:embed py/* kotlin/* swift/*
+var clientFiles embed.FS
+
 func pkgCopyTo(pkg, dst string) (err error) {
 
 	pkgPath := path.Join("pkg", pkg)
@@ -26,6 +29,9 @@ func pkgCopyTo(pkg, dst string) (err error) {
 		return err
 	}
 	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
 		var fileContent []byte
 		if fileContent, err = pkgFiles.ReadFile(fmt.Sprintf("%s/%s", pkgPath, entry.Name())); err != nil {
 			return err
@@ -34,7 +40,7 @@ func pkgCopyTo(pkg, dst string) (err error) {
 			return err
 		}
 		filename := path.Join(dst, pkg, entry.Name())
-		if err = os.WriteFile(filename, fileContent, 0600); err != nil {
+		if err = generated.WriteFile(filename, fileContent, 0600); err != nil {
 			return err
 		}
 	}
@@ -57,7 +63,7 @@ func tsCopyTo(pkg, dst string) (err error) {
 			return err
 		}
 		filename := path.Join(dst, pkg, entry.Name())
-		if err = os.WriteFile(filename, fileContent, 0600); err != nil {
+		if err = generated.WriteFile(filename, fileContent, 0600); err != nil {
 			return err
 		}
 	}
@@ -73,3 +79,22 @@ func (tr *Transport) pkgPath(dir string) (pkgPath string) {
 	}
 	return tr.module.Module.Mod.String() + pkgDir
 }
+
+// clientCopyTo copies runtime of client for lang to dst, prepending header to files.
+func clientCopyTo(lang, dst, header string) (err error) {
+
+	var entries []fs.DirEntry
+	if entries, err = clientFiles.ReadDir(lang); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		var fileContent []byte
+		if fileContent, err = clientFiles.ReadFile(fmt.Sprintf("%s/%s", lang, entry.Name())); err != nil {
+			return err
+		}
+		if err = generated.WriteFile(path.Join(dst, entry.Name()), append([]byte(header), fileContent...), 0600); err != nil {
+			return err
+		}
+	}
+	return
+}
@@ -0,0 +1,18 @@
+package httpclient
+
+import "net/http"
+
+type HTTPError struct {
+	Code int
+	Body []byte
+	err  error
+}
+
+func (e *HTTPError) Error() string {
+	return e.err.Error()
+}
+
+// CookieSetter is implemented by result types mapped with http-cookies which need the whole cookie.
+type CookieSetter interface {
+	SetCookie(cookie *http.Cookie)
+}
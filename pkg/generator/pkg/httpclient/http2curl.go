@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Redactor masks sensitive data of requests, dumped by LogRequest and LogOnError.
+type Redactor interface {
+	RedactHeader(name, value string) string
+	RedactJSON(data []byte) []byte
+}
+
+type CurlCommand struct {
+	slice []string
+}
+
+type nopCloser struct {
+	io.Reader
+}
+
+func toCurl(req *http.Request, redactor Redactor) (command *CurlCommand, err error) {
+
+	command = &CurlCommand{}
+	command.append("curl")
+	command.append("-X", bashEscape(req.Method))
+	if req.Body != nil {
+		var body []byte
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return
+		}
+		req.Body = nopCloser{bytes.NewBuffer(body)}
+		if redactor != nil {
+			body = redactor.RedactJSON(body)
+		}
+		bodyEscaped := bashEscape(string(body))
+		command.append("-d", bodyEscaped)
+	}
+	var keys = make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := strings.Join(req.Header[k], " ")
+		if redactor != nil {
+			value = redactor.RedactHeader(k, value)
+		}
+		command.append("-H", bashEscape(fmt.Sprintf("%s: %s", k, value)))
+	}
+	command.append(bashEscape(req.URL.String()))
+	return
+}
+
+func (c *CurlCommand) append(newSlice ...string) {
+	c.slice = append(c.slice, newSlice...)
+}
+
+func (c *CurlCommand) String() string {
+	return strings.Join(c.slice, " ")
+}
+
+func bashEscape(str string) string {
+	return `'` + strings.ReplaceAll(str, `'`, `'\''`) + `'`
+}
+
+func (nopCloser) Close() error { return nil }
@@ -1,41 +1,39 @@
 package httpclient
 
 import (
-	"bytes"
-	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	"github.com/rs/zerolog/log"
-	"github.com/valyala/fasthttp"
 )
 
 type ClientHTTP struct {
-	client         *fasthttp.Client
-	BaseURL        string
-	logRequests    bool
-	logOnError     bool
-	headersFromCtx []interface{}
+	options    options
+	BaseURL    string
+	httpClient *http.Client
 }
 
 func NewClient(baseURL string, opts ...Option) *ClientHTTP {
 
 	c := &ClientHTTP{
-		client: &fasthttp.Client{
-			ReadTimeout:  10 * time.Second,
-			WriteTimeout: 10 * time.Second,
-		},
 		BaseURL: baseURL,
+		options: prepareOpts(opts),
 	}
-	for _, opt := range opts {
-		opt(c)
-	}
+	c.httpClient = c.options.buildClient()
 	return c
 }
 
-func (c *ClientHTTP) Do(ctx context.Context, req *fasthttp.Request, resp *fasthttp.Response) (err error) {
+// Do executes request, checks the response status against successCode and returns the read body.
+// Retries and circuit breaker are applied according to options, whole call is traced by one client span.
+func (c *ClientHTTP) Do(req *http.Request, successCode int) (resp *http.Response, body []byte, err error) {
 
-	for _, header := range c.headersFromCtx {
+	req, span := c.startSpan(req)
+	defer func() { endSpan(span, resp, err) }()
+	defer func(begin time.Time) { c.observe(req, begin, resp, err) }(time.Now())
+	ctx := req.Context()
+	for _, header := range c.options.headersFromCtx {
 		if value := ctx.Value(header); value != nil {
 			if k := toString(header); k != "" {
 				if v := toString(value); v != "" {
@@ -44,53 +42,126 @@ func (c *ClientHTTP) Do(ctx context.Context, req *fasthttp.Request, resp *fastht
 			}
 		}
 	}
-	if c.logRequests {
-		if cmd, cmdErr := toCurlCommand(req); cmdErr == nil {
-			log.Debug().Str("method", string(req.Header.Method())).
-				Str("curl", cmd.String()).
-				Msg("HTTP request")
+	if c.options.logRequests {
+		if cmd, cmdErr := toCurl(req, c.options.redactor); cmdErr == nil {
+			log.Ctx(ctx).Debug().Str("method", req.Method).Str("curl", cmd.String()).Msg("HTTP request")
 		}
 	}
 	defer func() {
-		if err != nil && c.logOnError {
-			if cmd, cmdErr := toCurlCommand(req); cmdErr == nil {
-				log.Error().Str("method", string(req.Header.Method())).
-					Str("curl", cmd.String()).
-					Err(err).
-					Msg("HTTP request failed")
+		if err != nil && c.options.logOnError {
+			if cmd, cmdErr := toCurl(req, c.options.redactor); cmdErr == nil {
+				log.Ctx(ctx).Error().Str("method", req.Method).Str("curl", cmd.String()).Err(err).Msg("HTTP request failed")
 			}
 		}
 	}()
-	err = c.client.Do(req, resp)
+	call := func() (err error) {
+		resp, body, err = c.roundTrip(req, successCode)
+		return
+	}
+	if c.options.breaker != nil {
+		err = c.options.breaker(call)
+		return
+	}
+	err = call()
+	return
+}
+
+func (c *ClientHTTP) roundTrip(req *http.Request, successCode int) (resp *http.Response, body []byte, err error) {
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if err = c.waitRetry(req, attempt); err != nil {
+				return
+			}
+			if c.options.observer != nil {
+				c.options.observer.Retry(req.Context(), operation(req), attempt)
+			}
+		}
+		resp, body, err = c.send(req)
+		if attempt >= c.options.retries || !c.options.retryIf(req, resp, err) {
+			break
+		}
+	}
+	if err != nil {
+		return
+	}
+	if resp.StatusCode != successCode {
+		if c.options.errorDecoder != nil {
+			if err = c.options.errorDecoder(resp.StatusCode, body); err != nil {
+				return
+			}
+		}
+		err = &HTTPError{
+			Code: resp.StatusCode,
+			Body: body,
+			err:  fmt.Errorf("HTTP error: %d. URL: %s, Method: %s, Body: %s", resp.StatusCode, req.URL.String(), req.Method, string(body)),
+		}
+	}
+	return
+}
+
+func (c *ClientHTTP) send(req *http.Request) (resp *http.Response, body []byte, err error) {
+
+	if resp, err = c.httpClient.Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
 	return
 }
 
-func (c *ClientHTTP) SetTimeout(timeout time.Duration) {
-	c.client.ReadTimeout = timeout
-	c.client.WriteTimeout = timeout
+func (c *ClientHTTP) waitRetry(req *http.Request, attempt int) (err error) {
+
+	if req.GetBody != nil {
+		if req.Body, err = req.GetBody(); err != nil {
+			return
+		}
+	}
+	timer := time.NewTimer(c.options.retryBackoff * time.Duration(attempt))
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return
+	}
+}
+
+func defaultRetryIf(req *http.Request, resp *http.Response, err error) bool {
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+	default:
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// ResponseCookie returns the cookie with given name set by the response or nil.
+func ResponseCookie(resp *http.Response, name string) *http.Cookie {
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
 }
 
-func toCurlCommand(req *fasthttp.Request) (*bytes.Buffer, error) {
-
-	var cmd bytes.Buffer
-	cmd.WriteString("curl -X ")
-	cmd.Write(req.Header.Method())
-	cmd.WriteString(" '")
-	cmd.WriteString(req.URI().String())
-	cmd.WriteString("'")
-	req.Header.VisitAll(func(key, value []byte) {
-		cmd.WriteString(" -H '")
-		cmd.Write(key)
-		cmd.WriteString(": ")
-		cmd.Write(value)
-		cmd.WriteString("'")
-	})
-	if req.Body() != nil && len(req.Body()) > 0 {
-		cmd.WriteString(" -d '")
-		cmd.Write(req.Body())
-		cmd.WriteString("'")
-	}
-	return &cmd, nil
+// ResponseCookieValue returns the value of cookie with given name set by the response or empty string.
+func ResponseCookieValue(resp *http.Response, name string) string {
+
+	if cookie := ResponseCookie(resp, name); cookie != nil {
+		return cookie.Value
+	}
+	return ""
 }
 
 func toString(v interface{}) string {
@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/seniorGolang/tg/v2/pkg/generator/pkg/cb"
+)
+
+// testServer responds by statuses in order, the last one is repeated. Bodies of requests are collected.
+func testServer(t *testing.T, statuses ...int) (server *httptest.Server, calls *atomic.Int32, bodies *[]string) {
+
+	calls, bodies = new(atomic.Int32), new([]string)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		call := int(calls.Add(1)) - 1
+		w.WriteHeader(statuses[min(call, len(statuses)-1)])
+		_, _ = w.Write([]byte("response"))
+	}))
+	t.Cleanup(server.Close)
+	return
+}
+
+func TestRetry(t *testing.T) {
+
+	retryPost := RetryIf(func(req *http.Request, resp *http.Response, err error) bool {
+		return err != nil || resp.StatusCode == http.StatusServiceUnavailable
+	})
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		options  []Option
+		calls    int32
+		code     int
+	}{
+		{name: "no retry", method: http.MethodGet, statuses: []int{503, 200}, calls: 1, code: 503},
+		{name: "recovered", method: http.MethodGet, statuses: []int{503, 502, 200}, options: []Option{WithRetry(2, time.Millisecond)}, calls: 3},
+		{name: "exhausted", method: http.MethodGet, statuses: []int{504}, options: []Option{WithRetry(1, time.Millisecond)}, calls: 2, code: 504},
+		{name: "not retryable status", method: http.MethodGet, statuses: []int{500, 200}, options: []Option{WithRetry(2, time.Millisecond)}, calls: 1, code: 500},
+		{name: "not idempotent", method: http.MethodPost, statuses: []int{503, 200}, options: []Option{WithRetry(2, time.Millisecond)}, calls: 1, code: 503},
+		{name: "retry if", method: http.MethodPost, statuses: []int{503, 200}, options: []Option{WithRetry(2, time.Millisecond), retryPost}, calls: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, calls, bodies := testServer(t, test.statuses...)
+			client := NewClient(server.URL, test.options...)
+			req, _ := http.NewRequest(test.method, server.URL, strings.NewReader("request"))
+			_, body, err := client.Do(req, http.StatusOK)
+			if calls.Load() != test.calls {
+				t.Errorf("calls = %d, want %d", calls.Load(), test.calls)
+			}
+			for _, reqBody := range *bodies {
+				if reqBody != "request" {
+					t.Errorf("request body = %q, want it to be sent on every attempt", reqBody)
+				}
+			}
+			var httpErr *HTTPError
+			switch {
+			case test.code == 0 && err != nil:
+				t.Errorf("error = %v, want success", err)
+			case test.code == 0 && string(body) != "response":
+				t.Errorf("body = %q, want response", body)
+			case test.code != 0 && (!errors.As(err, &httpErr) || httpErr.Code != test.code):
+				t.Errorf("error = %v, want HTTPError %d", err, test.code)
+			}
+		})
+	}
+}
+
+func TestErrorDecoder(t *testing.T) {
+
+	errDecoded := errors.New("decoded")
+	server, _, _ := testServer(t, http.StatusBadRequest)
+	client := NewClient(server.URL, WithErrorDecoder(func(statusCode int, body []byte) error {
+		if statusCode == http.StatusBadRequest && string(body) == "response" {
+			return errDecoded
+		}
+		return nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, _, err := client.Do(req, http.StatusOK); !errors.Is(err, errDecoded) {
+		t.Errorf("error = %v, want decoded error", err)
+	}
+}
+
+func TestBreaker(t *testing.T) {
+
+	server, calls, _ := testServer(t, http.StatusServiceUnavailable)
+	breaker := cb.NewCircuitBreaker("test", cb.Settings{
+		ReadyToTrip: func(counts cb.Counts) bool { return counts.ConsecutiveFailures >= 2 },
+		Timeout:     time.Minute,
+	})
+	client := NewClient(server.URL, WithRetry(1, time.Millisecond), WithBreaker(func(call func() error) error {
+		return breaker.Execute(call)
+	}))
+	for range 2 {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		if _, _, err := client.Do(req, http.StatusOK); err == nil {
+			t.Fatal("error is nil, want HTTPError")
+		}
+	}
+	// breaker counts calls with all their retries as one failure
+	if calls.Load() != 4 {
+		t.Errorf("calls = %d, want 4", calls.Load())
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, _, err := client.Do(req, http.StatusOK); !errors.Is(err, cb.ErrOpenState) {
+		t.Errorf("error = %v, want %v", err, cb.ErrOpenState)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("calls = %d, want no requests by open breaker", calls.Load())
+	}
+}
+
+func TestResponseCookie(t *testing.T) {
+
+	resp := &http.Response{Header: http.Header{"Set-Cookie": {"session=abc; Path=/"}}}
+	if value := ResponseCookieValue(resp, "session"); value != "abc" {
+		t.Errorf("ResponseCookieValue() = %q, want abc", value)
+	}
+	if cookie := ResponseCookie(resp, "token"); cookie != nil {
+		t.Errorf("ResponseCookie() = %v, want nil", cookie)
+	}
+}
@@ -0,0 +1,39 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Observer receives result of every call of Do and its retries, e.g. to record client metrics.
+// Operation is set by WithOperation or is method of request, code is status of response or zero.
+type Observer interface {
+	Observe(ctx context.Context, operation string, duration time.Duration, code int, err error)
+	Retry(ctx context.Context, operation string, attempt int)
+}
+
+func operation(req *http.Request) string {
+
+	if name, _ := req.Context().Value(operationKey{}).(string); name != "" {
+		return name
+	}
+	return req.Method
+}
+
+func (c *ClientHTTP) observe(req *http.Request, begin time.Time, resp *http.Response, err error) {
+
+	if c.options.observer == nil {
+		return
+	}
+	var code int
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		code = httpErr.Code
+	case resp != nil:
+		code = resp.StatusCode
+	}
+	c.options.observer.Observe(req.Context(), operation(req), time.Since(begin), code, err)
+}
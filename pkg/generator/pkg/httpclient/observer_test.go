@@ -0,0 +1,58 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testObserver struct {
+	operation string
+	code      int
+	err       error
+	retries   []int
+}
+
+func (observer *testObserver) Observe(_ context.Context, operation string, _ time.Duration, code int, err error) {
+	observer.operation, observer.code, observer.err = operation, code, err
+}
+
+func (observer *testObserver) Retry(_ context.Context, _ string, attempt int) {
+	observer.retries = append(observer.retries, attempt)
+}
+
+func TestObserver(t *testing.T) {
+
+	errRejected := errors.New("rejected")
+	rejectAll := WithBreaker(func(func() error) error { return errRejected })
+	tests := []struct {
+		name     string
+		statuses []int
+		options  []Option
+		code     int
+		retries  int
+		err      error
+	}{
+		{name: "success", statuses: []int{200}, code: 200},
+		{name: "retried", statuses: []int{503, 503, 200}, options: []Option{WithRetry(2, time.Millisecond)}, code: 200, retries: 2},
+		{name: "failed", statuses: []int{500}, code: 500},
+		{name: "rejected", statuses: []int{200}, options: []Option{rejectAll}, err: errRejected},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _, _ := testServer(t, test.statuses...)
+			observer := &testObserver{}
+			client := NewClient(server.URL, append(test.options, WithObserver(observer))...)
+			req, _ := http.NewRequestWithContext(WithOperation(context.Background(), "files.get"), http.MethodGet, server.URL, nil)
+			_, _, err := client.Do(req, http.StatusOK)
+			if observer.operation != "files.get" || observer.code != test.code || len(observer.retries) != test.retries {
+				t.Errorf("observed %s with code %d and retries %v", observer.operation, observer.code, observer.retries)
+			}
+			if observer.err != err || (test.err != nil && !errors.Is(err, test.err)) {
+				t.Errorf("observed error %v, returned %v", observer.err, err)
+			}
+		})
+	}
+}
@@ -2,51 +2,190 @@ package httpclient
 
 import (
 	"crypto/tls"
+	"net/http"
 	"time"
 
-	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
-type Option func(*ClientHTTP)
+const defaultTimeout = 10 * time.Second
 
-func WithClient(client *fasthttp.Client) Option {
-	return func(c *ClientHTTP) {
-		c.client = client
+type ErrorDecoder func(statusCode int, body []byte) error
+
+type options struct {
+	logOnError     bool
+	logRequests    bool
+	timeout        time.Duration
+	readTimeout    time.Duration
+	tlsConfig      *tls.Config
+	clientHTTP     *http.Client
+	transport      http.RoundTripper
+	headersFromCtx []interface{}
+	retries        int
+	retryBackoff   time.Duration
+	retryIf        func(req *http.Request, resp *http.Response, err error) bool
+	breaker        func(call func() error) error
+	errorDecoder   ErrorDecoder
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+	observer       Observer
+	redactor       Redactor
+}
+
+type Option func(ops *options)
+
+func prepareOpts(opts []Option) (options options) {
+
+	options.timeout = defaultTimeout
+	options.retryIf = defaultRetryIf
+	for _, op := range opts {
+		op(&options)
+	}
+	return
+}
+
+func (ops options) buildClient() (client *http.Client) {
+
+	client = &http.Client{Timeout: ops.timeout}
+	if ops.clientHTTP != nil {
+		clientCopy := *ops.clientHTTP
+		client = &clientCopy
+	}
+	if ops.transport != nil {
+		client.Transport = ops.transport
+	}
+	if ops.tlsConfig == nil && ops.readTimeout == 0 {
+		return
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if client.Transport == nil {
+		transport, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		return
+	}
+	transport = transport.Clone()
+	if ops.tlsConfig != nil {
+		transport.TLSClientConfig = ops.tlsConfig
+	}
+	if ops.readTimeout != 0 {
+		transport.ResponseHeaderTimeout = ops.readTimeout
+	}
+	client.Transport = transport
+	return
+}
+
+// WithClient sets the base http.Client. It is copied, so the passed value is not modified by other options.
+func WithClient(client *http.Client) Option {
+	return func(ops *options) {
+		ops.clientHTTP = client
+	}
+}
+
+func WithTransport(transport http.RoundTripper) Option {
+	return func(ops *options) {
+		ops.transport = transport
 	}
 }
 
 func WithTLS(config *tls.Config) Option {
-	return func(c *ClientHTTP) {
-		c.client.TLSConfig = config
+	return func(ops *options) {
+		ops.tlsConfig = config
+	}
+}
+
+// WithTimeout sets the whole request timeout. Zero means no timeout, default is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(ops *options) {
+		ops.timeout = timeout
 	}
 }
 
+// WithReadTimeout limits the time to wait for the response headers.
 func WithReadTimeout(timeout time.Duration) Option {
-	return func(c *ClientHTTP) {
-		c.client.ReadTimeout = timeout
+	return func(ops *options) {
+		ops.readTimeout = timeout
 	}
 }
 
+// Deprecated: net/http has no separate write timeout, use WithTimeout.
 func WithWriteTimeout(timeout time.Duration) Option {
-	return func(c *ClientHTTP) {
-		c.client.WriteTimeout = timeout
+	return WithTimeout(timeout)
+}
+
+// WithRetry repeats failed requests up to count times waiting backoff*attempt between them.
+// By default only idempotent methods are repeated on network errors and 502, 503, 504 statuses.
+func WithRetry(count int, backoff time.Duration) Option {
+	return func(ops *options) {
+		ops.retries = count
+		ops.retryBackoff = backoff
+	}
+}
+
+// RetryIf overrides the check whether request should be repeated. resp is nil when err is not nil.
+func RetryIf(retryIf func(req *http.Request, resp *http.Response, err error) bool) Option {
+	return func(ops *options) {
+		ops.retryIf = retryIf
+	}
+}
+
+// WithBreaker wraps every call (with all its retries) by breaker, e.g. circuit breaker Execute.
+func WithBreaker(breaker func(call func() error) error) Option {
+	return func(ops *options) {
+		ops.breaker = breaker
+	}
+}
+
+// WithErrorDecoder sets decoder for responses with unexpected status. When decoder returns nil, HTTPError is returned.
+func WithErrorDecoder(decoder ErrorDecoder) Option {
+	return func(ops *options) {
+		ops.errorDecoder = decoder
 	}
 }
 
 func LogRequest() Option {
-	return func(c *ClientHTTP) {
-		c.logRequests = true
+	return func(ops *options) {
+		ops.logRequests = true
+	}
+}
+
+// WithRedactor sets masking of sensitive data in dumps of requests.
+func WithRedactor(redactor Redactor) Option {
+	return func(ops *options) {
+		ops.redactor = redactor
 	}
 }
 
 func LogOnError() Option {
-	return func(c *ClientHTTP) {
-		c.logOnError = true
+	return func(ops *options) {
+		ops.logOnError = true
 	}
 }
 
 func WithHeader(headers ...interface{}) Option {
-	return func(c *ClientHTTP) {
-		c.headersFromCtx = headers
+	return func(ops *options) {
+		ops.headersFromCtx = append(ops.headersFromCtx, headers...)
+	}
+}
+
+// WithTracerProvider sets provider of client spans, default is global one.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(ops *options) {
+		ops.tracerProvider = provider
+	}
+}
+
+// WithPropagator sets propagator, which injects trace context into requests, default is W3C trace-context and baggage.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(ops *options) {
+		ops.propagator = propagator
+	}
+}
+
+// WithObserver sets observer of calls, call rejected by breaker is observed with its error.
+func WithObserver(observer Observer) Option {
+	return func(ops *options) {
+		ops.observer = observer
 	}
 }
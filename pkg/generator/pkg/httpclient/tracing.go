@@ -0,0 +1,62 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "httpclient"
+
+type operationKey struct{}
+
+// WithOperation sets name of client span for requests with returned context, e.g. 'service.method'.
+func WithOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+func (c *ClientHTTP) startSpan(req *http.Request) (*http.Request, trace.Span) {
+
+	ctx := req.Context()
+	name := operation(req)
+	tracer := otel.GetTracerProvider().Tracer(tracerName)
+	if c.options.tracerProvider != nil {
+		tracer = c.options.tracerProvider.Tracer(tracerName)
+	}
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+			attribute.String("server.address", req.URL.Hostname()),
+		),
+	)
+	req = req.WithContext(ctx)
+	propagator := c.options.propagator
+	if propagator == nil {
+		propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, span
+}
+
+// endSpan sets status of span by response status and error and ends it.
+func endSpan(span trace.Span, resp *http.Response, err error) {
+
+	defer span.End()
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
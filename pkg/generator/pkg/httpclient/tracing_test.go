@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		operation string
+		status    int
+		span      string
+		code      codes.Code
+	}{
+		{name: "operation", operation: "files.get", status: http.StatusOK, span: "files.get", code: codes.Unset},
+		{name: "method", status: http.StatusOK, span: http.MethodGet, code: codes.Unset},
+		{name: "failed", operation: "files.get", status: http.StatusInternalServerError, span: "files.get", code: codes.Error},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			var traceParent string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				traceParent = r.Header.Get("Traceparent")
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+			client := NewClient(server.URL, WithTracerProvider(provider))
+			ctx := context.Background()
+			if test.operation != "" {
+				ctx = WithOperation(ctx, test.operation)
+			}
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/files/1", nil)
+			_, _, _ = client.Do(req, http.StatusOK)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("%d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != test.span || span.SpanKind() != trace.SpanKindClient || span.Status().Code != test.code {
+				t.Errorf("span %s of kind %s with status %v", span.Name(), span.SpanKind(), span.Status())
+			}
+			for _, attr := range span.Attributes() {
+				if attr.Key == "http.response.status_code" && attr.Value.AsInt64() != int64(test.status) {
+					t.Errorf("status code attribute %d, want %d", attr.Value.AsInt64(), test.status)
+				}
+			}
+			if !strings.Contains(traceParent, span.SpanContext().SpanID().String()) {
+				t.Errorf("traceparent %q does not refer to span %s", traceParent, span.SpanContext().SpanID())
+			}
+		})
+	}
+}
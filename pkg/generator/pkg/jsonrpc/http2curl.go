@@ -9,6 +9,12 @@ import (
 	"strings"
 )
 
+// Redactor masks sensitive data of requests, dumped by LogRequest and LogOnError.
+type Redactor interface {
+	RedactHeader(name, value string) string
+	RedactJSON(data []byte) []byte
+}
+
 type CurlCommand struct {
 	slice []string
 }
@@ -17,7 +23,7 @@ type nopCloser struct {
 	io.Reader
 }
 
-func toCurl(req *http.Request) (command *CurlCommand, err error) {
+func toCurl(req *http.Request, redactor Redactor) (command *CurlCommand, err error) {
 
 	command = &CurlCommand{}
 	command.append("curl")
@@ -28,6 +34,9 @@ func toCurl(req *http.Request) (command *CurlCommand, err error) {
 			return
 		}
 		req.Body = nopCloser{bytes.NewBuffer(body)}
+		if redactor != nil {
+			body = redactor.RedactJSON(body)
+		}
 		bodyEscaped := bashEscape(string(body))
 		command.append("-d", bodyEscaped)
 	}
@@ -37,7 +46,11 @@ func toCurl(req *http.Request) (command *CurlCommand, err error) {
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		command.append("-H", bashEscape(fmt.Sprintf("%s: %s", k, strings.Join(req.Header[k], " "))))
+		value := strings.Join(req.Header[k], " ")
+		if redactor != nil {
+			value = redactor.RedactHeader(k, value)
+		}
+		command.append("-H", bashEscape(fmt.Sprintf("%s: %s", k, value)))
 	}
 	command.append(bashEscape(req.URL.String()))
 	return
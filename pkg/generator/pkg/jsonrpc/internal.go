@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
@@ -21,6 +22,7 @@ func (client *ClientRPC) newRequest(ctx context.Context, reqBody interface{}) (r
 	}
 	request.Header.Set("Accept", "application/json")
 	request.Header.Set("Content-Type", "application/json")
+	client.inject(ctx, request.Header)
 	for k, v := range client.options.customHeaders {
 		if k == "Host" {
 			request.Host = v
@@ -42,19 +44,22 @@ func (client *ClientRPC) newRequest(ctx context.Context, reqBody interface{}) (r
 
 func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcResponse *ResponseRPC, err error) {
 
+	ctx, span := client.startSpan(ctx, request)
+	defer func() { endSpan(span, rpcResponse, err) }()
+	defer func(begin time.Time) { client.observe(ctx, request, begin, rpcResponse, err) }(time.Now())
 	var httpRequest *http.Request
 	if httpRequest, err = client.newRequest(ctx, request); err != nil {
 		err = fmt.Errorf("rpc call %v() on %v: %v", request.Method, client.endpoint, err.Error())
 		return
 	}
 	if client.options.logRequests {
-		if cmd, cmdErr := toCurl(httpRequest); cmdErr == nil {
+		if cmd, cmdErr := toCurl(httpRequest, client.options.redactor); cmdErr == nil {
 			log.Ctx(ctx).Debug().Str("method", request.Method).Str("curl", cmd.String()).Msg("call")
 		}
 	}
 	defer func() {
 		if err != nil && client.options.logOnError {
-			if cmd, cmdErr := toCurl(httpRequest); cmdErr == nil {
+			if cmd, cmdErr := toCurl(httpRequest, client.options.redactor); cmdErr == nil {
 				log.Ctx(ctx).Error().Str("method", request.Method).Str("curl", cmd.String()).Msg("call")
 			}
 		}
@@ -107,6 +112,14 @@ func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcRe
 
 func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*RequestRPC) (rpcResponses ResponsesRPC, err error) {
 
+	ctx, span := client.startBatchSpan(ctx, rpcRequests)
+	defer func() { endSpan(span, nil, err) }()
+	defer func(begin time.Time) {
+		responses := rpcResponses.AsMap()
+		for _, request := range rpcRequests {
+			client.observe(ctx, request, begin, responses[request.ID], err)
+		}
+	}(time.Now())
 	defer func() {
 		if err != nil {
 			for _, request := range rpcRequests {
@@ -129,13 +142,13 @@ func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*Request
 		return
 	}
 	if client.options.logRequests {
-		if cmd, cmdErr := toCurl(httpRequest); cmdErr == nil {
+		if cmd, cmdErr := toCurl(httpRequest, client.options.redactor); cmdErr == nil {
 			log.Ctx(ctx).Debug().Str("method", "batch").Int("count", len(rpcRequests)).Str("curl", cmd.String()).Msg("call")
 		}
 	}
 	defer func() {
 		if err != nil && client.options.logOnError {
-			if cmd, cmdErr := toCurl(httpRequest); cmdErr == nil {
+			if cmd, cmdErr := toCurl(httpRequest, client.options.redactor); cmdErr == nil {
 				log.Ctx(ctx).Error().Str("method", "batch").Int("count", len(rpcRequests)).Str("curl", cmd.String()).Msg("call")
 			}
 		}
@@ -0,0 +1,33 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Observer receives result of every call, e.g. to record client metrics. Code is code of JSON-RPC error,
+// status of HTTP error or zero, err is JSON-RPC error of response, when call itself succeeded.
+type Observer interface {
+	Observe(ctx context.Context, method string, duration time.Duration, code int, err error)
+}
+
+func (client *ClientRPC) observe(ctx context.Context, request *RequestRPC, begin time.Time, response *ResponseRPC, err error) {
+
+	if client.options.observer == nil {
+		return
+	}
+	if err == nil && response != nil && response.Error != nil {
+		err = response.Error
+	}
+	var code int
+	var rpcErr *RPCError
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &rpcErr):
+		code = rpcErr.Code
+	case errors.As(err, &httpErr):
+		code = httpErr.Code
+	}
+	client.options.observer.Observe(ctx, request.Method, time.Since(begin), code, err)
+}
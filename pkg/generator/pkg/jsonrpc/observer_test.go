@@ -0,0 +1,37 @@
+package jsonrpc
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+type testObserver struct {
+	methods []string
+	codes   []int
+}
+
+func (observer *testObserver) Observe(_ context.Context, method string, _ time.Duration, code int, _ error) {
+
+	observer.methods = append(observer.methods, method)
+	observer.codes = append(observer.codes, code)
+}
+
+func TestObserver(t *testing.T) {
+
+	server, _ := testServer(t)
+	observer := &testObserver{}
+	client := NewClient(server.URL, WithObserver(observer))
+	_, _ = client.Call(context.Background(), "users.get", 1)
+	_, _ = client.Call(context.Background(), "users.fail")
+	_, _ = client.CallBatch(context.Background(), RequestsRPC{NewRequest("users.get", 1), NewRequest("users.fail")})
+	_, _ = NewClient("http://127.0.0.1:0", WithObserver(observer)).Call(context.Background(), "users.get")
+
+	// every request of batch is observed, JSON-RPC error is observed by its code, failed call without code
+	methods := []string{"users.get", "users.fail", "users.get", "users.fail", "users.get"}
+	codes := []int{0, -32000, 0, -32000, 0}
+	if !slices.Equal(observer.methods, methods) || !slices.Equal(observer.codes, codes) {
+		t.Errorf("observed %v with codes %v, want %v with %v", observer.methods, observer.codes, methods, codes)
+	}
+}
@@ -3,6 +3,9 @@ package jsonrpc
 import (
 	"crypto/tls"
 	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type options struct {
@@ -13,6 +16,10 @@ type options struct {
 	clientHTTP         *http.Client
 	headersFromCtx     []interface{}
 	customHeaders      map[string]string
+	tracerProvider     trace.TracerProvider
+	propagator         propagation.TextMapPropagator
+	observer           Observer
+	redactor           Redactor
 }
 
 type Option func(ops *options)
@@ -56,8 +63,36 @@ func LogRequest() Option {
 	}
 }
 
+// WithRedactor sets masking of sensitive data in dumps of requests.
+func WithRedactor(redactor Redactor) Option {
+	return func(ops *options) {
+		ops.redactor = redactor
+	}
+}
+
 func LogOnError() Option {
 	return func(ops *options) {
 		ops.logOnError = true
 	}
 }
+
+// TracerProvider sets provider of client spans, default is global one.
+func TracerProvider(provider trace.TracerProvider) Option {
+	return func(ops *options) {
+		ops.tracerProvider = provider
+	}
+}
+
+// Propagator sets propagator, which injects trace context into requests, default is W3C trace-context and baggage.
+func Propagator(propagator propagation.TextMapPropagator) Option {
+	return func(ops *options) {
+		ops.propagator = propagator
+	}
+}
+
+// WithObserver sets observer of calls, requests of batch are observed separately with duration of whole batch.
+func WithObserver(observer Observer) Option {
+	return func(ops *options) {
+		ops.observer = observer
+	}
+}
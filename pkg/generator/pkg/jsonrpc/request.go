@@ -1,7 +1,10 @@
 package jsonrpc
 
 import (
+	"context"
+
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type RequestRPC struct {
@@ -9,10 +12,19 @@ type RequestRPC struct {
 	Method  string      `json:"method"`
 	Params  interface{} `json:"params,omitempty"`
 	JSONRPC string      `json:"jsonrpc"`
+
+	spanContext trace.SpanContext
 }
 
 type RequestsRPC []*RequestRPC
 
+// WithContext links request to span of context, span of batch call refers to it.
+func (request *RequestRPC) WithContext(ctx context.Context) *RequestRPC {
+
+	request.spanContext = trace.SpanContextFromContext(ctx)
+	return request
+}
+
 func NewRequest(method string, params ...interface{}) *RequestRPC {
 
 	request := &RequestRPC{
@@ -0,0 +1,104 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "jsonrpc"
+
+func (client *ClientRPC) tracer() trace.Tracer {
+
+	if client.options.tracerProvider != nil {
+		return client.options.tracerProvider.Tracer(tracerName)
+	}
+	return otel.GetTracerProvider().Tracer(tracerName)
+}
+
+func (client *ClientRPC) propagator() propagation.TextMapPropagator {
+
+	if client.options.propagator != nil {
+		return client.options.propagator
+	}
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// startSpan starts client span of call, it is named by JSON-RPC method as 'service.method'.
+func (client *ClientRPC) startSpan(ctx context.Context, request *RequestRPC) (context.Context, trace.Span) {
+
+	attrs := append(client.serverAttributes(), requestAttributes(request)...)
+	return client.tracer().Start(ctx, request.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// startBatchSpan starts one span of batch, which is linked to contexts of requests, set by WithContext.
+func (client *ClientRPC) startBatchSpan(ctx context.Context, requests []*RequestRPC) (context.Context, trace.Span) {
+
+	links := make([]trace.Link, 0, len(requests))
+	for _, request := range requests {
+		if request.spanContext.IsValid() {
+			links = append(links, trace.Link{SpanContext: request.spanContext, Attributes: requestAttributes(request)})
+		}
+	}
+	attrs := append(client.serverAttributes(), attribute.String("rpc.system", "jsonrpc"), attribute.Int("rpc.jsonrpc.batch_size", len(requests)))
+	return client.tracer().Start(ctx, "batch", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...), trace.WithLinks(links...))
+}
+
+func (client *ClientRPC) serverAttributes() (attrs []attribute.KeyValue) {
+
+	if endpoint, err := url.Parse(client.endpoint); err == nil && endpoint.Hostname() != "" {
+		attrs = append(attrs, attribute.String("server.address", endpoint.Hostname()))
+	}
+	return
+}
+
+func requestAttributes(request *RequestRPC) []attribute.KeyValue {
+
+	service, method := "", request.Method
+	if idx := strings.LastIndex(request.Method, "."); idx != -1 {
+		service, method = request.Method[:idx], request.Method[idx+1:]
+	}
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.jsonrpc.version", request.JSONRPC),
+		attribute.String("rpc.jsonrpc.request_id", request.ID.String()),
+	}
+}
+
+func (client *ClientRPC) inject(ctx context.Context, header http.Header) {
+	client.propagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// endSpan sets status of span by call error or error of JSON-RPC response and ends it.
+func endSpan(span trace.Span, response *ResponseRPC, err error) {
+
+	defer span.End()
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		span.SetAttributes(attribute.Int("http.response.status_code", httpErr.Code))
+	}
+	if response != nil && response.Error != nil {
+		span.SetAttributes(
+			attribute.Int("rpc.jsonrpc.error_code", response.Error.Code),
+			attribute.String("rpc.jsonrpc.error_message", response.Error.Message),
+		)
+		if err == nil {
+			span.SetStatus(codes.Error, response.Error.Message)
+			return
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// testServer answers every request by its id, method 'users.fail' returns error. Headers of requests are collected.
+func testServer(t *testing.T) (server *httptest.Server, headers *[]http.Header) {
+
+	headers = new([]http.Header)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*headers = append(*headers, r.Header.Clone())
+		body, _ := io.ReadAll(r.Body)
+		var requests []map[string]any
+		batch := strings.HasPrefix(string(body), "[")
+		if !batch {
+			body = []byte("[" + string(body) + "]")
+		}
+		_ = json.Unmarshal(body, &requests)
+		responses := make([]map[string]any, 0, len(requests))
+		for _, request := range requests {
+			response := map[string]any{"jsonrpc": Version, "id": request["id"], "result": "ok"}
+			if request["method"] == "users.fail" {
+				response = map[string]any{"jsonrpc": Version, "id": request["id"], "error": map[string]any{"code": -32000, "message": "failed"}}
+			}
+			responses = append(responses, response)
+		}
+		if batch {
+			_ = json.NewEncoder(w).Encode(responses)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(responses[0])
+	}))
+	t.Cleanup(server.Close)
+	return
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestCallTracing(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	server, headers := testServer(t)
+	client := NewClient(server.URL, TracerProvider(provider))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	if _, err := client.Call(ctx, "users.get", 1); err != nil {
+		t.Fatal(err)
+	}
+	if response, err := client.Call(ctx, "users.fail"); err != nil || response.Error == nil {
+		t.Fatalf("response %+v, error %v, want JSON-RPC error", response, err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("%d spans, want 3", len(spans))
+	}
+	get, fail := spans[0], spans[1]
+	if get.Name() != "users.get" || get.SpanKind() != trace.SpanKindClient || get.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span %s of kind %s with parent %s", get.Name(), get.SpanKind(), get.Parent().SpanID())
+	}
+	attrs := attributes(get)
+	if attrs["rpc.service"].AsString() != "users" || attrs["rpc.method"].AsString() != "get" || attrs["server.address"].AsString() != "127.0.0.1" {
+		t.Errorf("attributes %v", attrs)
+	}
+	if get.Status().Code != codes.Unset {
+		t.Errorf("status of successful call is %v", get.Status())
+	}
+	if attrs = attributes(fail); fail.Status().Code != codes.Error || attrs["rpc.jsonrpc.error_code"].AsInt64() != -32000 {
+		t.Errorf("status %v, attributes %v of failed call", fail.Status(), attrs)
+	}
+	// trace context of span is propagated to server
+	if traceParent := (*headers)[0].Get("Traceparent"); !strings.Contains(traceParent, get.SpanContext().SpanID().String()) {
+		t.Errorf("traceparent %q does not refer to span %s", traceParent, get.SpanContext().SpanID())
+	}
+}
+
+func TestBatchTracing(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	server, _ := testServer(t)
+	client := NewClient(server.URL, TracerProvider(provider))
+
+	ctx, caller := provider.Tracer("test").Start(context.Background(), "caller")
+	responses, err := client.CallBatch(context.Background(), RequestsRPC{
+		NewRequest("users.get", 1).WithContext(ctx),
+		NewRequest("users.fail"),
+	})
+	caller.End()
+	if err != nil || len(responses) != 2 {
+		t.Fatalf("responses %v, error %v", responses, err)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans, want 2", len(spans))
+	}
+	batch := spans[0]
+	if batch.Name() != "batch" || attributes(batch)["rpc.jsonrpc.batch_size"].AsInt64() != 2 {
+		t.Errorf("span %s with attributes %v", batch.Name(), attributes(batch))
+	}
+	// only request with context is linked to batch
+	if links := batch.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != caller.SpanContext().SpanID() {
+		t.Errorf("links %v, want link to caller", links)
+	}
+}
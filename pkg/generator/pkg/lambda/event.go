@@ -0,0 +1,114 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// httpEvent is API Gateway HTTP API (payload format 2.0) request.
+type httpEvent struct {
+	Version         string            `json:"version"`
+	RawPath         string            `json:"rawPath"`
+	RawQueryString  string            `json:"rawQueryString"`
+	Cookies         []string          `json:"cookies,omitempty"`
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body,omitempty"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+	RequestContext  struct {
+		DomainName string `json:"domainName"`
+		RequestID  string `json:"requestId"`
+		HTTP       struct {
+			Method   string `json:"method"`
+			Path     string `json:"path"`
+			SourceIP string `json:"sourceIp"`
+		} `json:"http"`
+	} `json:"requestContext"`
+}
+
+// httpResponse is API Gateway HTTP API (payload format 2.0) response.
+type httpResponse struct {
+	StatusCode      int               `json:"statusCode"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	Cookies         []string          `json:"cookies,omitempty"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+}
+
+func (event httpEvent) request(ctx context.Context) (req *http.Request, err error) {
+
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		if body, err = base64.StdEncoding.DecodeString(event.Body); err != nil {
+			return
+		}
+	}
+	reqURL := url.URL{Path: event.RawPath, RawQuery: event.RawQueryString}
+	if req, err = http.NewRequestWithContext(ctx, event.RequestContext.HTTP.Method, reqURL.String(), bytes.NewReader(body)); err != nil {
+		return
+	}
+	for name, value := range event.Headers {
+		req.Header.Set(name, value)
+	}
+	if len(event.Cookies) != 0 {
+		req.Header.Set("Cookie", strings.Join(event.Cookies, "; "))
+	}
+	req.Host = event.RequestContext.DomainName
+	req.RemoteAddr = event.RequestContext.HTTP.SourceIP
+	req.RequestURI = reqURL.RequestURI()
+	return
+}
+
+type responseWriter struct {
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{header: make(http.Header)}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(data)
+}
+
+func (w *responseWriter) response() (resp httpResponse) {
+
+	resp.StatusCode = w.status
+	if resp.StatusCode == 0 {
+		resp.StatusCode = http.StatusOK
+	}
+	resp.Headers = make(map[string]string, len(w.header))
+	for name, values := range w.header {
+		if name == "Set-Cookie" {
+			resp.Cookies = values
+			continue
+		}
+		resp.Headers[name] = strings.Join(values, ",")
+	}
+	if utf8.Valid(w.body.Bytes()) {
+		resp.Body = w.body.String()
+		return
+	}
+	resp.Body = base64.StdEncoding.EncodeToString(w.body.Bytes())
+	resp.IsBase64Encoded = true
+	return
+}
@@ -0,0 +1,107 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	envRuntimeAPI = "AWS_LAMBDA_RUNTIME_API"
+	envTraceID    = "_X_AMZN_TRACE_ID"
+
+	headerRequestID = "Lambda-Runtime-Aws-Request-Id"
+	headerDeadline  = "Lambda-Runtime-Deadline-Ms"
+	headerTraceID   = "Lambda-Runtime-Trace-Id"
+)
+
+var ErrNoRuntime = errors.New(envRuntimeAPI + " is not set, process is not running in AWS Lambda")
+
+type runtime struct {
+	baseURL string
+	client  *http.Client
+	handler http.Handler
+}
+
+// Start serves API Gateway HTTP API events by handler using AWS Lambda Runtime API.
+// It is the entrypoint of 'provided' runtimes and returns only on runtime failure.
+func Start(handler http.Handler) (err error) {
+
+	api := os.Getenv(envRuntimeAPI)
+	if api == "" {
+		return ErrNoRuntime
+	}
+	rt := &runtime{
+		baseURL: "http://" + api + "/2018-06-01/runtime/invocation/",
+		client:  &http.Client{},
+		handler: handler,
+	}
+	for {
+		if err = rt.next(); err != nil {
+			return
+		}
+	}
+}
+
+func (rt *runtime) next() (err error) {
+
+	var resp *http.Response
+	if resp, err = rt.client.Get(rt.baseURL + "next"); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("runtime API next invocation: %s", resp.Status)
+	}
+	requestID := resp.Header.Get(headerRequestID)
+	if traceID := resp.Header.Get(headerTraceID); traceID != "" {
+		_ = os.Setenv(envTraceID, traceID)
+	}
+	ctx := context.Background()
+	if deadline, parseErr := strconv.ParseInt(resp.Header.Get(headerDeadline), 10, 64); parseErr == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, time.UnixMilli(deadline))
+		defer cancel()
+	}
+	var event httpEvent
+	if err = json.NewDecoder(resp.Body).Decode(&event); err != nil {
+		return rt.post(requestID+"/error", invocationError{Message: err.Error(), Type: "InvalidEvent"})
+	}
+	var req *http.Request
+	if req, err = event.request(ctx); err != nil {
+		return rt.post(requestID+"/error", invocationError{Message: err.Error(), Type: "InvalidEvent"})
+	}
+	w := newResponseWriter()
+	rt.handler.ServeHTTP(w, req)
+	return rt.post(requestID+"/response", w.response())
+}
+
+type invocationError struct {
+	Message string `json:"errorMessage"`
+	Type    string `json:"errorType"`
+}
+
+func (rt *runtime) post(path string, payload interface{}) (err error) {
+
+	var body []byte
+	if body, err = json.Marshal(payload); err != nil {
+		return
+	}
+	var resp *http.Response
+	if resp, err = rt.client.Post(rt.baseURL+path, "application/json", bytes.NewReader(body)); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("runtime API %s: %s", path, resp.Status)
+	}
+	return
+}
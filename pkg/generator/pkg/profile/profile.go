@@ -0,0 +1,124 @@
+package profile
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Prefix is path, which profiles are served on, compatible with 'go tool pprof'.
+const Prefix = "/debug/pprof/"
+
+const defaultSeconds = 30
+
+// Handler serves profiles of runtime/pprof like net/http/pprof does, but without registration in http.DefaultServeMux.
+func Handler() http.Handler {
+	return http.HandlerFunc(serve)
+}
+
+func serve(w http.ResponseWriter, r *http.Request) {
+
+	switch name := strings.TrimPrefix(r.URL.Path, Prefix); name {
+	case "":
+		index(w)
+	case "cmdline":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = fmt.Fprint(w, strings.Join(os.Args, "\x00"))
+	case "profile":
+		cpu(w, r)
+	case "trace":
+		execution(w, r)
+	default:
+		lookup(w, r, name)
+	}
+}
+
+func index(w http.ResponseWriter) {
+
+	profiles := pprof.Profiles()
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name() < profiles[j].Name() })
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	var page strings.Builder
+	page.WriteString("<html><head><title>/debug/pprof/</title></head><body><table>\n")
+	for _, profile := range profiles {
+		name := html.EscapeString(profile.Name())
+		_, _ = fmt.Fprintf(&page, "<tr><td>%d</td><td><a href=\"%s?debug=1\">%s</a></td></tr>\n", profile.Count(), name, name)
+	}
+	page.WriteString("<tr><td></td><td><a href=\"profile\">profile</a></td></tr>\n")
+	page.WriteString("<tr><td></td><td><a href=\"trace\">trace</a></td></tr>\n")
+	page.WriteString("</table></body></html>\n")
+	_, _ = w.Write([]byte(page.String()))
+}
+
+func lookup(w http.ResponseWriter, r *http.Request, name string) {
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, "unknown profile", http.StatusNotFound)
+		return
+	}
+	debug, _ := strconv.Atoi(r.FormValue("debug"))
+	if name == "heap" && r.FormValue("gc") != "" {
+		runtime.GC()
+	}
+	setContentType(w, name, debug)
+	_ = profile.WriteTo(w, debug)
+}
+
+func cpu(w http.ResponseWriter, r *http.Request) {
+
+	setContentType(w, "profile", 0)
+	if err := pprof.StartCPUProfile(w); err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, "could not enable CPU profiling: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleep(r)
+	pprof.StopCPUProfile()
+}
+
+func execution(w http.ResponseWriter, r *http.Request) {
+
+	setContentType(w, "trace", 0)
+	if err := trace.Start(w); err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, "could not enable tracing: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleep(r)
+	trace.Stop()
+}
+
+// sleep waits for 'seconds' query parameter or until request is canceled.
+func sleep(r *http.Request) {
+
+	seconds, err := strconv.ParseFloat(r.FormValue("seconds"), 64)
+	if err != nil || seconds <= 0 {
+		seconds = defaultSeconds
+	}
+	timer := time.NewTimer(time.Duration(seconds * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+}
+
+func setContentType(w http.ResponseWriter, name string, debug int) {
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if debug != 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+}
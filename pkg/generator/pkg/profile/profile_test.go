@@ -0,0 +1,41 @@
+package profile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		path        string
+		code        int
+		contentType string
+		body        string
+	}{
+		{name: "index", path: "", code: http.StatusOK, contentType: "text/html; charset=utf-8", body: `<a href="goroutine?debug=1">goroutine</a>`},
+		{name: "debug", path: "goroutine?debug=1", code: http.StatusOK, contentType: "text/plain; charset=utf-8", body: "goroutine profile:"},
+		{name: "binary", path: "heap?gc=1", code: http.StatusOK, contentType: "application/octet-stream"},
+		{name: "cpu", path: "profile?seconds=0.05", code: http.StatusOK, contentType: "application/octet-stream"},
+		{name: "cmdline", path: "cmdline", code: http.StatusOK, contentType: "text/plain; charset=utf-8"},
+		{name: "unknown", path: "unknown", code: http.StatusNotFound, body: "unknown profile"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, Prefix+test.path, nil))
+			if w.Code != test.code {
+				t.Errorf("code %d, want %d", w.Code, test.code)
+			}
+			if contentType := w.Header().Get("Content-Type"); test.contentType != "" && contentType != test.contentType {
+				t.Errorf("content type %q, want %q", contentType, test.contentType)
+			}
+			if !strings.Contains(w.Body.String(), test.body) || (test.code == http.StatusOK && w.Body.Len() == 0) {
+				t.Errorf("body %q, want %q", w.Body.String(), test.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package tracer
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Attribute converts argument or result of method to span attribute. Scalars keep their types, other values are encoded to JSON.
+func Attribute(key string, value any) attribute.KeyValue {
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return attribute.String(key, "null")
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return attribute.String(key, "null")
+	}
+	if stringer, ok := rv.Interface().(fmt.Stringer); ok {
+		return attribute.String(key, stringer.String())
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return attribute.String(key, rv.String())
+	case reflect.Bool:
+		return attribute.Bool(key, rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return attribute.Int64(key, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return attribute.Int64(key, int64(rv.Uint())) // nolint:gosec
+	case reflect.Float32, reflect.Float64:
+		return attribute.Float64(key, rv.Float())
+	default:
+		data, err := json.Marshal(rv.Interface())
+		if err != nil {
+			return attribute.String(key, fmt.Sprintf("%+v", rv.Interface()))
+		}
+		return attribute.String(key, string(data))
+	}
+}
@@ -0,0 +1,127 @@
+package tracer
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(data []byte) (n int, err error) {
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err = w.ResponseWriter.Write(data)
+	w.size += int64(n)
+	return
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func HTTPMiddleware(opts ...Option) func(next http.Handler) http.Handler {
+
+	cfg := config{
+		collectClientIP: true,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	tracer := cfg.TracerProvider.Tracer(
+		instrumentationName,
+		trace.WithInstrumentationVersion(contrib.Version()),
+	)
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	meter := cfg.MeterProvider.Meter(
+		instrumentationName,
+		metric.WithInstrumentationVersion(contrib.Version()),
+	)
+	httpServerDuration, err := meter.Float64Histogram(MetricNameHttpServerDuration, metric.WithUnit(UnitMilliseconds), metric.WithDescription("measures the duration inbound HTTP requests"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	httpServerActiveRequests, err := meter.Int64UpDownCounter(MetricNameHttpServerActiveRequests, metric.WithUnit(UnitDimensionless), metric.WithDescription("measures the number of concurrent HTTP requests that are currently in-flight"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	if cfg.Propagators == nil {
+		cfg.Propagators = otel.GetTextMapPropagator()
+	}
+	var serverName string
+	if cfg.ServerName != nil {
+		serverName = *cfg.ServerName
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			savedCtx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			start := time.Now()
+			metricAttrs := semconv.HTTPServerMetricAttributesFromHTTPRequest(serverName, r)
+			httpServerActiveRequests.Add(savedCtx, 1, metric.WithAttributes(metricAttrs...))
+			defer httpServerActiveRequests.Add(savedCtx, -1, metric.WithAttributes(metricAttrs...))
+			ctx := cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(r.Header))
+			attrs := semconv.HTTPServerAttributesFromHTTPRequest(serverName, "", r)
+			if !cfg.collectClientIP {
+				attrs = withoutAttribute(attrs, semconv.HTTPClientIPKey)
+			}
+			ctx, span := tracer.Start(ctx, r.Method+" "+r.URL.Path, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+			tracingHeaders := make(propagation.HeaderCarrier)
+			cfg.Propagators.Inject(ctx, tracingHeaders)
+			for _, headerKey := range tracingHeaders.Keys() {
+				w.Header().Set(headerKey, tracingHeaders.Get(headerKey))
+			}
+			sw := &statusWriter{ResponseWriter: w}
+			req := r.WithContext(ctx)
+			next.ServeHTTP(sw, req)
+			if sw.status == 0 {
+				sw.status = http.StatusOK
+			}
+			if req.Pattern != "" {
+				span.SetName(req.Pattern)
+				span.SetAttributes(semconv.HTTPRouteKey.String(req.Pattern))
+			}
+			span.SetAttributes(append(semconv.HTTPAttributesFromHTTPStatusCode(sw.status), semconv.HTTPResponseContentLengthKey.Int64(sw.size))...)
+			spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(sw.status, trace.SpanKindServer)
+			span.SetStatus(spanStatus, spanMessage)
+			httpServerDuration.Record(savedCtx, float64(time.Since(start).Microseconds())/1000, metric.WithAttributes(append(metricAttrs, semconv.HTTPAttributesFromHTTPStatusCode(sw.status)...)...))
+		})
+	}
+}
+
+func withoutAttribute(attrs []attribute.KeyValue, key attribute.Key) []attribute.KeyValue {
+
+	filtered := attrs[:0]
+	for _, attr := range attrs {
+		if attr.Key != key {
+			filtered = append(filtered, attr)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,72 @@
+package tracer
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+type methodSampling struct {
+	method string
+	ratio  float64
+	routes []string
+}
+
+// methodSampler samples root spans of methods by their own ratio. Spans of server are named by request path
+// (optionally prefixed by HTTP method), spans of methods are named by method name.
+type methodSampler struct {
+	fallback trace.Sampler
+	methods  []methodSampling
+	samplers []trace.Sampler
+}
+
+func newSampler(ratio float64, methods []methodSampling) trace.Sampler {
+
+	root := &methodSampler{fallback: trace.TraceIDRatioBased(ratio), methods: methods}
+	for _, method := range methods {
+		root.samplers = append(root.samplers, trace.TraceIDRatioBased(method.ratio))
+	}
+	return trace.ParentBased(root)
+}
+
+func (s *methodSampler) ShouldSample(params trace.SamplingParameters) trace.SamplingResult {
+
+	urlPath := params.Name
+	if idx := strings.IndexByte(urlPath, ' '); idx != -1 {
+		urlPath = urlPath[idx+1:]
+	}
+	for i, method := range s.methods {
+		if params.Name == method.method {
+			return s.samplers[i].ShouldSample(params)
+		}
+		for _, route := range method.routes {
+			if matchRoute(route, urlPath) {
+				return s.samplers[i].ShouldSample(params)
+			}
+		}
+	}
+	return s.fallback.ShouldSample(params)
+}
+
+func (s *methodSampler) Description() string {
+	return "MethodSampler{" + s.fallback.Description() + "}"
+}
+
+// matchRoute matches path against route, where {param} and :param match any segment.
+func matchRoute(route, urlPath string) bool {
+
+	routeItems := strings.Split(strings.Trim(route, "/"), "/")
+	pathItems := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(routeItems) != len(pathItems) {
+		return false
+	}
+	for i, item := range routeItems {
+		if strings.HasPrefix(item, ":") || (strings.HasPrefix(item, "{") && strings.HasSuffix(item, "}")) {
+			continue
+		}
+		if item != pathItems[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package tracer
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestMatchRoute(t *testing.T) {
+
+	tests := []struct {
+		route string
+		path  string
+		match bool
+	}{
+		{route: "/orders/list", path: "/orders/list", match: true},
+		{route: "/orders/list", path: "orders/list/", match: true},
+		{route: "/orders/{id}", path: "/orders/42", match: true},
+		{route: "/orders/:id", path: "/orders/42", match: true},
+		{route: "/orders/{id}", path: "/orders/42/items", match: false},
+		{route: "/orders/list", path: "/orders/find", match: false},
+	}
+	for _, test := range tests {
+		if match := matchRoute(test.route, test.path); match != test.match {
+			t.Errorf("matchRoute(%q, %q) = %t, want %t", test.route, test.path, match, test.match)
+		}
+	}
+}
+
+func TestSampler(t *testing.T) {
+
+	sampler := newSampler(1, []methodSampling{
+		{method: "orders.get", ratio: 0, routes: []string{"/api/orders/{id}"}},
+		{method: "orders.list", ratio: 1, routes: []string{"/orders/list"}},
+	})
+	tests := []struct {
+		name     string
+		span     string
+		decision trace.SamplingDecision
+	}{
+		{name: "method", span: "orders.get", decision: trace.Drop},
+		{name: "route", span: "/api/orders/42", decision: trace.Drop},
+		{name: "route with verb", span: "GET /api/orders/42", decision: trace.Drop},
+		{name: "sampled method", span: "orders.list", decision: trace.RecordAndSample},
+		{name: "fallback", span: "/api/orders", decision: trace.RecordAndSample},
+	}
+	traceID := oteltrace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := sampler.ShouldSample(trace.SamplingParameters{TraceID: traceID, Name: test.span})
+			if result.Decision != test.decision {
+				t.Errorf("decision = %v, want %v", result.Decision, test.decision)
+			}
+		})
+	}
+	t.Run("parent", func(t *testing.T) {
+		parent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceID: traceID, SpanID: oteltrace.SpanID{1}, TraceFlags: oteltrace.FlagsSampled})
+		result := sampler.ShouldSample(trace.SamplingParameters{
+			ParentContext: oteltrace.ContextWithSpanContext(t.Context(), parent),
+			TraceID:       traceID,
+			Name:          "orders.get",
+		})
+		if result.Decision != trace.RecordAndSample {
+			t.Errorf("decision = %v, want decision of parent", result.Decision)
+		}
+	})
+}
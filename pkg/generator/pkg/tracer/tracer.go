@@ -2,39 +2,242 @@ package tracer
 
 import (
 	"context"
-
-	"github.com/rs/zerolog/log"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/propagation"
-	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"crypto/tls"
+	"os"
 
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"google.golang.org/grpc/credentials"
 )
 
+type Protocol string
+
+const (
+	ProtocolGRPC Protocol = "grpc"
+	ProtocolHTTP Protocol = "http"
+)
+
+type initConfig struct {
+	endpoint   string
+	protocol   Protocol
+	tlsConfig  *tls.Config
+	headers    map[string]string
+	ratio      float64
+	methods    []methodSampling
+	exporter   trace.SpanExporter
+	syncExport bool
+	attributes []attribute.KeyValue
+	detectors  []resource.Detector
+	propagator propagation.TextMapPropagator
+	stdout     bool
+}
+
+type InitOption func(cfg *initConfig)
+
+// WithEndpoint sets OTLP collector address, e.g. 'localhost:4317' for gRPC or 'localhost:4318' for HTTP.
+func WithEndpoint(endpoint string) InitOption {
+	return func(cfg *initConfig) {
+		cfg.endpoint = endpoint
+	}
+}
+
+// WithProtocol sets OTLP protocol, default is gRPC.
+func WithProtocol(protocol Protocol) InitOption {
+	return func(cfg *initConfig) {
+		cfg.protocol = protocol
+	}
+}
+
+// WithTLS enables TLS connection to collector, by default connection is insecure.
+func WithTLS(config *tls.Config) InitOption {
+	return func(cfg *initConfig) {
+		cfg.tlsConfig = config
+	}
+}
+
+// WithHeaders sets headers of requests to collector, e.g. authorization.
+func WithHeaders(headers map[string]string) InitOption {
+	return func(cfg *initConfig) {
+		cfg.headers = headers
+	}
+}
+
+// WithSampleRatio samples given part of root spans, child spans follow decision of parent. Default is 1.
+func WithSampleRatio(ratio float64) InitOption {
+	return func(cfg *initConfig) {
+		cfg.ratio = ratio
+	}
+}
+
+// WithMethodSampling overrides sampling ratio of root spans of method, which is served by routes.
+func WithMethodSampling(method string, ratio float64, routes ...string) InitOption {
+	return func(cfg *initConfig) {
+		cfg.methods = append(cfg.methods, methodSampling{method: method, ratio: ratio, routes: routes})
+	}
+}
+
+// WithExporter replaces OTLP exporter, spans are exported in batches.
+func WithExporter(exporter trace.SpanExporter) InitOption {
+	return func(cfg *initConfig) {
+		cfg.exporter = exporter
+		cfg.syncExport = false
+	}
+}
+
+// WithSyncExporter replaces OTLP exporter, every span is exported when it ends. Useful in tests with in-memory exporter.
+func WithSyncExporter(exporter trace.SpanExporter) InitOption {
+	return func(cfg *initConfig) {
+		cfg.exporter = exporter
+		cfg.syncExport = true
+	}
+}
+
+// WithStdout exports spans to stdout in human-readable form.
+func WithStdout() InitOption {
+	return func(cfg *initConfig) {
+		cfg.stdout = true
+	}
+}
+
+// WithAttributes adds attributes to resource of spans.
+func WithAttributes(attributes ...attribute.KeyValue) InitOption {
+	return func(cfg *initConfig) {
+		cfg.attributes = append(cfg.attributes, attributes...)
+	}
+}
+
+// WithDetectors adds resource detectors to default ones: environment (OTEL_RESOURCE_ATTRIBUTES), host, process and SDK.
+func WithDetectors(detectors ...resource.Detector) InitOption {
+	return func(cfg *initConfig) {
+		cfg.detectors = append(cfg.detectors, detectors...)
+	}
+}
+
+// WithPropagator sets propagator of trace context, default is W3C trace-context and baggage.
+func WithPropagator(propagator propagation.TextMapPropagator) InitOption {
+	return func(cfg *initConfig) {
+		cfg.propagator = propagator
+	}
+}
+
+// Init creates tracer provider of service, which exports spans to OTLP gRPC endpoint, and sets it as global one.
 func Init(ctx context.Context, serviceName, endpoint string, attributes ...attribute.KeyValue) (tracer *trace.TracerProvider) {
 
-	exporter, err := otlptrace.New(
-		ctx,
-		otlptracegrpc.NewClient(
-			otlptracegrpc.WithInsecure(),
-			otlptracegrpc.WithEndpoint(endpoint),
-		),
+	var err error
+	if tracer, err = newProvider(ctx, serviceName, WithEndpoint(endpoint), WithAttributes(attributes...)); err != nil {
+		log.Ctx(ctx).Panic().Err(err).Send()
+	}
+	return
+}
+
+// InitWithOptions creates tracer provider of service and sets it and propagator as global ones.
+// Returned shutdown flushes buffered spans and stops exporter.
+func InitWithOptions(ctx context.Context, serviceName string, options ...InitOption) (shutdown func(ctx context.Context) error, err error) {
+
+	var provider *trace.TracerProvider
+	if provider, err = newProvider(ctx, serviceName, options...); err != nil {
+		return nil, err
+	}
+	return provider.Shutdown, nil
+}
+
+func newProvider(ctx context.Context, serviceName string, options ...InitOption) (provider *trace.TracerProvider, err error) {
+
+	cfg := initConfig{
+		protocol: ProtocolGRPC,
+		ratio:    1,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+	exporter := cfg.exporter
+	if exporter == nil {
+		if exporter, err = cfg.newExporter(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not set exporter")
+		}
+	}
+	var res *resource.Resource
+	if res, err = cfg.newResource(ctx, serviceName); err != nil {
+		return nil, errors.Wrap(err, "could not detect resource")
+	}
+	export := trace.WithBatcher(exporter)
+	if cfg.syncExport {
+		export = trace.WithSyncer(exporter)
+	}
+	provider = trace.NewTracerProvider(
+		trace.WithSampler(newSampler(cfg.ratio, cfg.methods)),
+		trace.WithResource(res),
+		export,
 	)
-	if err != nil {
-		log.Ctx(ctx).Panic().Err(errors.Wrap(err, "could not set exporter")).Send()
-		return
-	}
-	tracer = trace.NewTracerProvider(
-		trace.WithSampler(trace.AlwaysSample()),
-		trace.WithBatcher(exporter),
-		trace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, append(attributes, semconv.ServiceNameKey.String(serviceName))...)),
+	if cfg.propagator == nil {
+		cfg.propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(cfg.propagator)
+	return provider, nil
+}
+
+func (cfg initConfig) newExporter(ctx context.Context) (exporter trace.SpanExporter, err error) {
+
+	if cfg.stdout {
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	}
+	var client otlptrace.Client
+	switch cfg.protocol {
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithHeaders(cfg.headers)}
+		if cfg.endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.endpoint))
+		}
+		if cfg.tlsConfig != nil {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(cfg.tlsConfig))
+		} else {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		client = otlptracehttp.NewClient(opts...)
+	case ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithHeaders(cfg.headers)}
+		if cfg.endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.endpoint))
+		}
+		if cfg.tlsConfig != nil {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(cfg.tlsConfig)))
+		} else {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		client = otlptracegrpc.NewClient(opts...)
+	default:
+		return nil, errors.Errorf("unknown protocol '%s'", cfg.protocol)
+	}
+	return otlptrace.New(ctx, client)
+}
+
+func (cfg initConfig) newResource(ctx context.Context, serviceName string) (res *resource.Resource, err error) {
+
+	res, err = resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithHost(),
+		resource.WithProcessPID(),
+		resource.WithProcessExecutableName(),
+		resource.WithProcessRuntimeName(),
+		resource.WithProcessRuntimeVersion(),
+		resource.WithTelemetrySDK(),
+		resource.WithDetectors(cfg.detectors...),
+		resource.WithAttributes(append(cfg.attributes, semconv.ServiceNameKey.String(serviceName))...),
 	)
-	otel.SetTracerProvider(tracer)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	// partial resource is usable, e.g. when some of process attributes are not available
+	if errors.Is(err, resource.ErrPartialResource) {
+		err = nil
+	}
 	return
 }
@@ -126,6 +126,18 @@ func (f *formatState) formatPtr(v reflect.Value) {
 
 func (f *formatState) format(v reflect.Value, opts ...option) {
 
+	if v.IsValid() {
+		if policy, found := redaction.typePolicy(v.Type()); found {
+			if !isScalar(v.Kind()) {
+				view := fmt.Sprintf("%+v", v)
+				_, _ = f.fs.Write([]byte(Redact(policy, view)))
+				return
+			}
+			if opt, found := redaction.policy(policy); found {
+				opts = append(opts, opt)
+			}
+		}
+	}
 	if toString := v.MethodByName("String"); toString.IsValid() {
 		if v.Kind() == reflect.Ptr && v.IsNil() {
 			_, _ = f.fs.Write(applyOptions([]byte("nil")))
@@ -206,7 +218,7 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 						_, _ = f.fs.Write(spaceBytes)
 					}
 					f.ignoreNextType = true
-					f.format(f.unpackValue(v.Index(i)))
+					f.format(f.unpackValue(v.Index(i)), opts...)
 				}
 				f.format(reflect.ValueOf(fmt.Sprintf(" <-[%d]->", numEntries)))
 				for i := numEntries - 4; i < numEntries; i++ {
@@ -214,7 +226,7 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 						_, _ = f.fs.Write(spaceBytes)
 					}
 					f.ignoreNextType = true
-					f.format(f.unpackValue(v.Index(i)))
+					f.format(f.unpackValue(v.Index(i)), opts...)
 				}
 				break
 			}
@@ -223,7 +235,7 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 					_, _ = f.fs.Write(spaceBytes)
 				}
 				f.ignoreNextType = true
-				f.format(f.unpackValue(v.Index(i)))
+				f.format(f.unpackValue(v.Index(i)), opts...)
 			}
 		}
 		f.depth--
@@ -260,7 +272,7 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 				f.format(f.unpackValue(key))
 				_, _ = f.fs.Write(colonBytes)
 				f.ignoreNextType = true
-				f.format(f.unpackValue(v.MapIndex(key)))
+				f.format(f.unpackValue(v.MapIndex(key)), append(opts, keyOption(key))...)
 			}
 		}
 		f.depth--
@@ -288,7 +300,7 @@ func (f *formatState) format(v reflect.Value, opts ...option) {
 					_, _ = f.fs.Write([]byte(vtf.Name))
 					_, _ = f.fs.Write(colonBytes)
 				}
-				f.format(f.unpackValue(v.Field(i)), tagToOption(vtf.Tag.Get(tagName)))
+				f.format(f.unpackValue(v.Field(i)), redaction.structFieldOption(vt, vtf))
 			}
 		}
 		f.depth--
@@ -327,3 +339,24 @@ func (f *formatState) Format(fs fmt.State, verb rune) {
 	}
 	f.format(reflect.ValueOf(f.value))
 }
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Struct, reflect.Ptr, reflect.Interface:
+		return false
+	}
+	return true
+}
+
+// keyOption returns option of map value by policy of field with the same name as key.
+func keyOption(key reflect.Value) option {
+
+	if key.Kind() != reflect.String {
+		return nil
+	}
+	if policy, found := redaction.fieldPolicy(key.String()); found {
+		opt, _ := redaction.policy(policy)
+		return opt
+	}
+	return nil
+}
@@ -49,3 +49,8 @@ func hide(formula string) option {
 		return
 	}
 }
+
+// Hide masks value as dumper tag 'hide' does: formula is fh, lh, md, <from>:<to> or '-' to drop value.
+func Hide(value, formula string) string {
+	return string(applyOptions([]byte(value), hide(formula)))
+}
@@ -0,0 +1,339 @@
+package viewer
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Policy masks sensitive value.
+type Policy func(value string) string
+
+const (
+	PolicyEmail = "email"
+	PolicyPhone = "phone"
+	PolicyCard  = "card"
+	PolicyJWT   = "jwt"
+	PolicyDrop  = "drop"
+)
+
+type registry struct {
+	lock       sync.RWMutex
+	policies   map[string]Policy
+	fields     map[string]string
+	keys       map[string]string
+	types      map[reflect.Type]string
+	typeFields map[reflect.Type]map[string]string
+	sensitive  map[reflect.Type]bool
+}
+
+var redaction = registry{
+	policies: map[string]Policy{
+		PolicyEmail: redactEmail,
+		PolicyPhone: redactPhone,
+		PolicyCard:  redactCard,
+		PolicyJWT:   redactJWT,
+		PolicyDrop:  func(string) string { return "" },
+		"fh":        hidePolicy("fh"),
+		"lh":        hidePolicy("lh"),
+		"md":        hidePolicy("md"),
+	},
+	fields:     make(map[string]string),
+	keys:       make(map[string]string),
+	types:      make(map[reflect.Type]string),
+	typeFields: make(map[reflect.Type]map[string]string),
+	sensitive:  make(map[reflect.Type]bool),
+}
+
+// RegisterPolicy adds named policy or replaces existing one.
+func RegisterPolicy(name string, policy Policy) {
+
+	redaction.lock.Lock()
+	defer redaction.lock.Unlock()
+	redaction.policies[name] = policy
+}
+
+// RedactField masks values of struct fields, JSON and map keys and headers with name (case-insensitive) in any type.
+func RedactField(name, policy string) {
+
+	redaction.lock.Lock()
+	defer redaction.lock.Unlock()
+	redaction.fields[strings.ToLower(name)] = policy
+	redaction.keys[strings.ToLower(name)] = policy
+	clear(redaction.sensitive)
+}
+
+// RedactType masks whole values of type.
+func RedactType(typ reflect.Type, policy string) {
+
+	redaction.lock.Lock()
+	defer redaction.lock.Unlock()
+	redaction.types[typ] = policy
+	clear(redaction.sensitive)
+}
+
+// RedactTypeField masks values of field of struct type. JSON name of field is masked in JSON dumps too.
+func RedactTypeField(typ reflect.Type, field, policy string) {
+
+	redaction.lock.Lock()
+	defer redaction.lock.Unlock()
+	if redaction.typeFields[typ] == nil {
+		redaction.typeFields[typ] = make(map[string]string)
+	}
+	redaction.typeFields[typ][field] = policy
+	if structField, found := typ.FieldByName(field); found {
+		name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if name == "" {
+			name = field
+		}
+		if name != "-" {
+			redaction.keys[strings.ToLower(name)] = policy
+		}
+	}
+	clear(redaction.sensitive)
+}
+
+// Redact masks value with named policy. Unknown policy drops value.
+func Redact(policy, value string) string {
+
+	redaction.lock.RLock()
+	apply, found := redaction.policies[policy]
+	redaction.lock.RUnlock()
+	if !found {
+		return ""
+	}
+	return apply(value)
+}
+
+// Field returns view of value, masked by policy of field name, when it is registered.
+func Field(name string, value any) string {
+
+	view := Sprintf("%+v", value)
+	if policy, found := redaction.fieldPolicy(name); found {
+		return Redact(policy, view)
+	}
+	return view
+}
+
+// RedactValue returns value as is or its masked view, when field name or type of value has redaction policies.
+func RedactValue(name string, value any) any {
+
+	if policy, found := redaction.fieldPolicy(name); found {
+		return Redact(policy, Sprintf("%+v", value))
+	}
+	if value != nil && redaction.isSensitive(reflect.TypeOf(value)) {
+		return Sprintf("%+v", value)
+	}
+	return value
+}
+
+// RedactHeader masks value of header by policy of field with the same name.
+func RedactHeader(name, value string) string {
+
+	if policy, found := redaction.fieldPolicy(name); found {
+		return Redact(policy, value)
+	}
+	return value
+}
+
+// RedactJSON masks values of registered keys in JSON document, invalid JSON is returned as is.
+func RedactJSON(data []byte) []byte {
+
+	redaction.lock.RLock()
+	empty := len(redaction.keys) == 0
+	redaction.lock.RUnlock()
+	if empty {
+		return data
+	}
+	var document any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return data
+	}
+	if !redactDocument(document) {
+		return data
+	}
+	if view, err := json.Marshal(document); err == nil {
+		return view
+	}
+	return data
+}
+
+func redactDocument(document any) (changed bool) {
+
+	switch node := document.(type) {
+	case map[string]any:
+		for key, value := range node {
+			redaction.lock.RLock()
+			policy, found := redaction.keys[strings.ToLower(key)]
+			redaction.lock.RUnlock()
+			if !found {
+				changed = redactDocument(value) || changed
+				continue
+			}
+			view, isString := value.(string)
+			if !isString {
+				raw, _ := json.Marshal(value)
+				view = string(raw)
+			}
+			node[key] = Redact(policy, view)
+			changed = true
+		}
+	case []any:
+		for _, item := range node {
+			changed = redactDocument(item) || changed
+		}
+	}
+	return
+}
+
+func (r *registry) policy(name string) (option, bool) {
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	apply, found := r.policies[name]
+	if !found {
+		return nil, false
+	}
+	return policyOption(apply), true
+}
+
+func (r *registry) fieldPolicy(name string) (policy string, found bool) {
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	policy, found = r.fields[strings.ToLower(name)]
+	return
+}
+
+func (r *registry) typePolicy(typ reflect.Type) (policy string, found bool) {
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	policy, found = r.types[typ]
+	return
+}
+
+// structFieldOption returns option of struct field by dumper tag, policy of type field or global field policy.
+func (r *registry) structFieldOption(typ reflect.Type, field reflect.StructField) option {
+
+	if opt := tagToOption(field.Tag.Get(tagName)); opt != nil {
+		return opt
+	}
+	r.lock.RLock()
+	policy, found := r.typeFields[typ][field.Name]
+	if !found {
+		policy, found = r.fields[strings.ToLower(field.Name)]
+	}
+	r.lock.RUnlock()
+	if !found {
+		return nil
+	}
+	opt, _ := r.policy(policy)
+	return opt
+}
+
+// isSensitive reports whether values of type may contain masked data.
+func (r *registry) isSensitive(typ reflect.Type) bool {
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.walkSensitive(typ)
+}
+
+func (r *registry) walkSensitive(typ reflect.Type) (sensitive bool) {
+
+	if cached, found := r.sensitive[typ]; found {
+		return cached
+	}
+	r.sensitive[typ] = false
+	defer func() { r.sensitive[typ] = sensitive }()
+	if _, found := r.types[typ]; found {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return r.walkSensitive(typ.Elem())
+	case reflect.Map:
+		return (typ.Key().Kind() == reflect.String && len(r.fields) != 0) || r.walkSensitive(typ.Elem())
+	case reflect.Struct:
+		if len(r.typeFields[typ]) != 0 {
+			return true
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if _, found := r.fields[strings.ToLower(field.Name)]; found || field.Tag.Get(tagName) != "" || r.walkSensitive(field.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func policyOption(policy Policy) option {
+	return func(bytes []byte) []byte {
+		return []byte(policy(string(bytes)))
+	}
+}
+
+func hidePolicy(formula string) Policy {
+	return func(value string) string {
+		return string(hide(formula)([]byte(value)))
+	}
+}
+
+func redactEmail(value string) string {
+
+	local, domain, found := strings.Cut(value, "@")
+	if !found || local == "" {
+		return maskRunes(value, 1, 0)
+	}
+	return maskRunes(local, 1, 0) + "@" + domain
+}
+
+func redactPhone(value string) string {
+	return maskDigits(value, 4)
+}
+
+func redactCard(value string) string {
+	return maskDigits(value, 4)
+}
+
+func redactJWT(value string) string {
+
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return maskRunes(value, 0, 0)
+	}
+	return parts[0] + ".***.***"
+}
+
+// maskRunes replaces runes of value except first and last ones.
+func maskRunes(value string, first, last int) string {
+
+	runes := []rune(value)
+	for i := first; i < len(runes)-last; i++ {
+		runes[i] = '*'
+	}
+	return string(runes)
+}
+
+// maskDigits replaces digits of value except last ones, separators are kept.
+func maskDigits(value string, last int) string {
+
+	runes := []rune(value)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] < '0' || runes[i] > '9' {
+			continue
+		}
+		if last > 0 {
+			last--
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
@@ -0,0 +1,123 @@
+package viewer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+
+	RegisterPolicy("upper", strings.ToUpper)
+	tests := []struct {
+		policy string
+		value  string
+		view   string
+	}{
+		{policy: PolicyEmail, value: "john@example.com", view: "j***@example.com"},
+		{policy: PolicyEmail, value: "john", view: "j***"},
+		{policy: PolicyPhone, value: "+7 (912) 345-67-89", view: "+* (***) ***-67-89"},
+		{policy: PolicyCard, value: "4111 1111 1111 1234", view: "**** **** **** 1234"},
+		{policy: PolicyJWT, value: "header.payload.signature", view: "header.***.***"},
+		{policy: PolicyJWT, value: "token", view: "*****"},
+		{policy: PolicyDrop, value: "secret", view: ""},
+		{policy: "fh", value: "12345678", view: "****5678"},
+		{policy: "lh", value: "12345678", view: "1234****"},
+		{policy: "md", value: "123456789", view: "123***789"},
+		{policy: "upper", value: "secret", view: "SECRET"},
+		{policy: "unknown", value: "secret", view: ""},
+	}
+	for _, test := range tests {
+		if view := Redact(test.policy, test.value); view != test.view {
+			t.Errorf("Redact(%q, %q) = %q, want %q", test.policy, test.value, view, test.view)
+		}
+	}
+}
+
+type redactCredentials struct {
+	Login    string
+	Password string `json:"pass"`
+	Token    string `dumper:"hide,fh"`
+}
+
+type redactSecret string
+
+type redactUser struct {
+	Email       string
+	Credentials redactCredentials
+	Secret      redactSecret
+	Tags        map[string]string
+}
+
+func TestRedactValues(t *testing.T) {
+
+	RedactField("email", PolicyEmail)
+	RedactField("X-Api-Key", PolicyDrop)
+	RedactType(reflect.TypeOf(redactSecret("")), PolicyDrop)
+	RedactTypeField(reflect.TypeOf(redactCredentials{}), "Password", PolicyDrop)
+
+	user := redactUser{
+		Email:       "john@example.com",
+		Credentials: redactCredentials{Login: "john", Password: "qwerty", Token: "12345678"},
+		Secret:      "secret",
+		Tags:        map[string]string{"email": "john@example.com"},
+	}
+	view := Sprintf("%+v", user)
+	for _, snippet := range []string{"Email:j***@example.com", "Login:john", "Token:****5678", "email:j***@example.com"} {
+		if !strings.Contains(view, snippet) {
+			t.Errorf("view %s does not contain %q", view, snippet)
+		}
+	}
+	for _, secret := range []string{"qwerty", "secret", "john@"} {
+		if strings.Contains(view, secret) {
+			t.Errorf("view %s contains %q", view, secret)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		field string
+		value any
+		want  any
+	}{
+		{name: "field", field: "Email", value: "john@example.com", want: "j***@example.com"},
+		{name: "plain", field: "login", value: "john", want: "john"},
+		{name: "scalar", field: "limit", value: 10, want: 10},
+		{name: "sensitive type", field: "user", value: redactCredentials{Login: "john", Password: "qwerty"}, want: "{Login:john Password: Token:}"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if value := RedactValue(test.field, test.value); value != test.want {
+				t.Errorf("RedactValue(%q) = %#v, want %#v", test.field, value, test.want)
+			}
+		})
+	}
+	if value := RedactHeader("x-api-key", "key"); value != "" {
+		t.Errorf("RedactHeader() = %q, want dropped value", value)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+
+	RedactField("email", PolicyEmail)
+	RedactTypeField(reflect.TypeOf(redactCredentials{}), "Password", PolicyDrop)
+	tests := []struct {
+		name string
+		data string
+		view string
+	}{
+		{name: "plain", data: `{"login":"john","limit":10}`, view: `{"login":"john","limit":10}`},
+		{name: "field", data: `{"Email":"john@example.com"}`, view: `{"Email":"j***@example.com"}`},
+		{name: "json name of type field", data: `{"pass":"qwerty"}`, view: `{"pass":""}`},
+		{name: "nested", data: `{"users":[{"email":"john@example.com","id":1}]}`, view: `{"users":[{"email":"j***@example.com","id":1}]}`},
+		{name: "not string", data: `{"email":12345}`, view: `{"email":"1****"}`},
+		{name: "invalid", data: `{"email":`, view: `{"email":`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if view := string(RedactJSON([]byte(test.data))); view != test.view {
+				t.Errorf("RedactJSON() = %s, want %s", view, test.view)
+			}
+		})
+	}
+}
@@ -14,5 +14,8 @@ func tagToOption(tag string) (opt option) {
 			return hide(parsed[1])
 		}
 	}
+	if len(parsed) == 1 && parsed[0] != "" {
+		opt, _ = redaction.policy(parsed[0])
+	}
 	return
 }
@@ -0,0 +1,264 @@
+"""JSON-RPC 2.0 runtime of generated client."""
+
+from __future__ import annotations
+
+import dataclasses
+import datetime
+import itertools
+import json
+import re
+import typing
+import urllib.error
+import urllib.request
+from typing import Any, Callable, Dict, Generic, List, Optional, TypeVar
+
+T = TypeVar("T")
+
+
+class RpcError(Exception):
+    """Error of JSON-RPC call."""
+
+    code: int = 0
+
+    def __init__(self, message: str, code: Optional[int] = None, data: Any = None):
+        super().__init__(message)
+        self.message = message
+        if code is not None:
+            self.code = code
+        self.data = data
+
+    def __str__(self) -> str:
+        return f"{self.message} (code {self.code})"
+
+
+class ParseError(RpcError):
+    """Invalid JSON was received by the server."""
+
+    code = -32700
+
+
+class InvalidRequestError(RpcError):
+    """The JSON sent is not a valid Request object."""
+
+    code = -32600
+
+
+class MethodNotFoundError(RpcError):
+    """The method does not exist / is not available."""
+
+    code = -32601
+
+
+class InvalidParamsError(RpcError):
+    """Invalid method parameter(s)."""
+
+    code = -32602
+
+
+class InternalError(RpcError):
+    """Internal JSON-RPC error."""
+
+    code = -32603
+
+
+class ServerError(RpcError):
+    """Implementation-defined server error (codes from -32099 to -32000)."""
+
+
+class TransportError(RpcError):
+    """HTTP request failed, code is HTTP status."""
+
+
+ERRORS: Dict[int, type] = {
+    error.code: error
+    for error in (ParseError, InvalidRequestError, MethodNotFoundError, InvalidParamsError, InternalError)
+}
+
+
+def error_from(error: Dict[str, Any]) -> RpcError:
+    """Returns error of class, registered in ERRORS for code of JSON-RPC error."""
+
+    code = error.get("code", 0)
+    cls = ERRORS.get(code) or (ServerError if -32099 <= code <= -32000 else RpcError)
+    return cls(error.get("message", ""), code, error.get("data"))
+
+
+Transport = Callable[[Any], Any]
+
+_ids = itertools.count(1)
+
+
+def request(method: str, params: Dict[str, Any]) -> Dict[str, Any]:
+    return {"jsonrpc": "2.0", "id": next(_ids), "method": method, "params": params}
+
+
+class HttpTransport:
+    """Sends requests by HTTP POST to url."""
+
+    def __init__(self, url: str, headers: Optional[Dict[str, str]] = None, timeout: float = 30.0):
+        self.url = url
+        self.headers = headers or {}
+        self.timeout = timeout
+
+    def __call__(self, payload: Any) -> Any:
+        headers = {"Accept": "application/json", "Content-Type": "application/json", **self.headers}
+        req = urllib.request.Request(self.url, data=json.dumps(payload).encode(), headers=headers, method="POST")
+        try:
+            with urllib.request.urlopen(req, timeout=self.timeout) as response:
+                body = response.read()
+        except urllib.error.HTTPError as error:
+            raise TransportError(str(error.reason), error.code, error.read().decode(errors="replace")) from error
+        return json.loads(body) if body else None
+
+
+def unwrap(response: Any) -> Any:
+    if not isinstance(response, dict):
+        raise InternalError("invalid response")
+    if response.get("error") is not None:
+        raise error_from(response["error"])
+    return response.get("result")
+
+
+class RpcClient:
+    """Calls methods one by one."""
+
+    def __init__(self, url: str = "", headers: Optional[Dict[str, str]] = None, timeout: float = 30.0,
+                 transport: Optional[Transport] = None):
+        self.transport = transport or HttpTransport(url, headers, timeout)
+
+    def call(self, method: str, params: Dict[str, Any], decoder: Callable[[Any], T]) -> T:
+        response = self.transport(request(method, params))
+        if isinstance(response, list):
+            response = response[0] if response else None
+        return decoder(unwrap(response))
+
+
+class BatchCall(Generic[T]):
+    """Call of batch, its result is available after execution of batch."""
+
+    def __init__(self, method: str, params: Dict[str, Any], decoder: Callable[[Any], T]):
+        self.request = request(method, params)
+        self.decoder = decoder
+        self.error: Optional[RpcError] = None
+        self._result: Any = None
+        self._done = False
+
+    @property
+    def result(self) -> T:
+        """Returns result of call or raises its error."""
+
+        if not self._done:
+            raise RuntimeError(f"batch with {self.request['method']} is not executed")
+        if self.error is not None:
+            raise self.error
+        return self._result
+
+    def resolve(self, response: Any) -> None:
+        self._done = True
+        try:
+            self._result = self.decoder(unwrap(response))
+        except RpcError as error:
+            self.error = error
+
+
+class RpcBatch:
+    """Collects calls and sends them in one request. Methods of batch return BatchCall instead of result."""
+
+    def __init__(self, transport: Transport):
+        self.transport = transport
+        self.calls: List[BatchCall[Any]] = []
+
+    def call(self, method: str, params: Dict[str, Any], decoder: Callable[[Any], T]) -> BatchCall[T]:
+        call = BatchCall(method, params, decoder)
+        self.calls.append(call)
+        return call
+
+    def execute(self) -> List[BatchCall[Any]]:
+        calls, self.calls = self.calls, []
+        if not calls:
+            return calls
+        response = self.transport([call.request for call in calls])
+        if not isinstance(response, list):
+            # whole batch is rejected by server
+            unwrap(response)
+            raise InternalError("invalid response")
+        responses = {item.get("id"): item for item in response if isinstance(item, dict)}
+        for call in calls:
+            call.resolve(responses.get(call.request["id"]))
+        return calls
+
+    def __enter__(self):
+        return self
+
+    def __exit__(self, exc_type, exc, tb):
+        if exc_type is None:
+            self.execute()
+
+
+def encode(value: Any) -> Any:
+    """Converts value to JSON compatible one, fields of dataclasses are named by their JSON names."""
+
+    if dataclasses.is_dataclass(value) and not isinstance(value, type):
+        return {
+            field.metadata.get("json", field.name): encode(getattr(value, field.name))
+            for field in dataclasses.fields(value)
+        }
+    if isinstance(value, datetime.datetime):
+        if value.tzinfo is None:
+            value = value.replace(tzinfo=datetime.timezone.utc)
+        return value.isoformat()
+    if isinstance(value, (list, tuple)):
+        return [encode(item) for item in value]
+    if isinstance(value, dict):
+        return {key: encode(item) for key, item in value.items()}
+    return value
+
+
+_fraction = re.compile(r"(\.\d{6})\d+")
+
+
+def _parse_time(value: str) -> datetime.datetime:
+    value = _fraction.sub(r"\1", value)
+    if value.endswith("Z"):
+        value = value[:-1] + "+00:00"
+    return datetime.datetime.fromisoformat(value)
+
+
+def decode(tp: Any, value: Any) -> Any:
+    """Converts JSON value to type tp."""
+
+    if value is None or tp is Any:
+        return value
+    origin = typing.get_origin(tp)
+    args = typing.get_args(tp)
+    if origin is typing.Union:
+        return decode(next((arg for arg in args if arg is not type(None)), Any), value)
+    if origin in (list, List):
+        return [decode(args[0] if args else Any, item) for item in value]
+    if origin in (dict, Dict):
+        return {key: decode(args[1] if len(args) > 1 else Any, item) for key, item in value.items()}
+    if dataclasses.is_dataclass(tp):
+        hints = typing.get_type_hints(tp)
+        values = {}
+        for field in dataclasses.fields(tp):
+            key = field.metadata.get("json", field.name)
+            if key in value:
+                values[field.name] = decode(hints[field.name], value[key])
+        return tp(**values)
+    if tp is datetime.datetime:
+        return _parse_time(value)
+    if tp is float:
+        return float(value)
+    return value
+
+
+def result_of(tp: Any, name: str) -> Callable[[Any], Any]:
+    """Returns decoder of named result."""
+
+    return lambda result: decode(tp, (result or {}).get(name))
+
+
+def result_as(tp: Any) -> Callable[[Any], Any]:
+    """Returns decoder of all results."""
+
+    return lambda result: decode(tp, result or {})
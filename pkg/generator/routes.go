@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"path"
+
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+// route is an endpoint served by transport. Path uses {param} placeholders.
+type route struct {
+	name   string
+	method string
+	path   string
+}
+
+// routes returns all endpoints of services including JSON-RPC batch paths and every API version.
+func (tr *Transport) routes() (routes []route) {
+
+	if tr.hasJsonRPC {
+		routes = append(routes, route{name: "Batch", method: "POST", path: path.Join("/", tr.tags.Value(tagHttpPrefix, ""))})
+	}
+	for _, svcName := range tr.serviceKeys() {
+		svc := tr.services[svcName]
+		if svc.tags.Contains(tagServerJsonRPC) {
+			routes = append(routes, route{name: svc.Name + "Batch", method: "POST", path: svc.batchPath()})
+		}
+		for _, method := range svc.methods {
+			routes = append(routes, method.routes()...)
+		}
+	}
+	return
+}
+
+// routes returns endpoints of method for every API version.
+func (m *method) routes() (routes []route) {
+
+	for _, version := range m.routeVersions() {
+		if m.isJsonRPC() {
+			routes = append(routes, route{name: m.svc.Name + m.Name + utils.ToCamel(version), method: "POST", path: m.jsonrpcPathVersion(version)})
+			continue
+		}
+		if m.isHTTP() {
+			for _, verb := range m.httpMethods() {
+				routes = append(routes, route{name: m.svc.Name + m.httpMethodName(verb) + utils.ToCamel(version), method: verb, path: m.httpPathSwaggerVersion(version)})
+			}
+		}
+	}
+	return
+}
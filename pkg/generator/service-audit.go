@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+func (svc *service) renderAudit(outDir string) (err error) {
+
+	if err = pkgCopyTo("viewer", outDir); err != nil {
+		return err
+	}
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
+
+	pkgViewer := fmt.Sprintf("%s/viewer", svc.tr.pkgPath(outDir))
+	srcFile.ImportName(pkgViewer, "viewer")
+	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+
+	srcFile.Type().Id("audit"+svc.Name).Struct(
+		Id(_next_).Qual(svc.pkgPath, svc.Name),
+		Id("audit").Op("*").Id("auditConfig"),
+	)
+
+	// config is not named 'audit', as it would shadow package of service with such name
+	srcFile.Line().Func().Id("auditMiddleware" + svc.Name).Params(Id("cfg").Op("*").Id("auditConfig")).Params(Id("Middleware" + svc.Name)).Block(
+		Return(Func().Params(Id(_next_).Qual(svc.pkgPath, svc.Name)).Params(Qual(svc.pkgPath, svc.Name)).Block(
+			Return(Op("&").Id("audit" + svc.Name).Values(Dict{
+				Id(_next_):  Id(_next_),
+				Id("audit"): Id("cfg"),
+			})),
+		)),
+	)
+
+	for _, method := range svc.methods {
+		srcFile.Line().Func().Params(Id("m").Id("audit" + svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).BlockFunc(func(g *Group) {
+			if method.tags.IsSet(tagAudit) {
+				g.Line().Defer().Func().Params(Id("_begin").Qual(packageTime, "Time")).Block(
+					If(Id("m").Dot("audit").Dot("sink").Op("==").Nil()).Block(
+						Return(),
+					),
+					Id("m").Dot("audit").Dot("write").Call(Id(_ctx_), Id("_begin"), Lit(svc.lccName()), Lit(method.fullName()), Map(String()).String().Values(DictFunc(func(d Dict) {
+						for _, arg := range method.auditArgs() {
+							value := Qual(pkgViewer, "Field").Call(Lit(arg.Name), Id(arg.Name))
+							if formula, hidden := method.hideFormula(arg); hidden {
+								value = Qual(pkgViewer, "Hide").Call(Qual(pkgViewer, "Sprintf").Call(Lit("%+v"), Id(arg.Name)), Lit(formula))
+							}
+							if policy := method.tags.Sub(arg.Name).Value(tagLogRedact); policy != "" {
+								value = Qual(pkgViewer, "Redact").Call(Lit(policy), Qual(pkgViewer, "Sprintf").Call(Lit("%+v"), Id(arg.Name)))
+							}
+							d[Lit(arg.Name)] = value
+						}
+					})), Err()),
+				).Call(Qual(packageTime, "Now").Call())
+				g.Line()
+			}
+			g.Return().Id("m").Dot(_next_).Dot(method.Name).CallFunc(func(cg *Group) {
+				for _, arg := range method.Args {
+					argCode := Id(arg.Name)
+					if types.IsEllipsis(arg.Type) {
+						argCode.Op("...")
+					}
+					cg.Add(argCode)
+				}
+			})
+		})
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-audit.go"))
+}
+
+func (svc *service) hasAudit() bool {
+
+	for _, method := range svc.methods {
+		if method.tags.IsSet(tagAudit) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditArgs returns arguments of method listed by audit-args annotation, all arguments without it.
+// Variables skipped by log-skip are not recorded.
+func (m *method) auditArgs() (args []types.Variable) {
+
+	names := m.varNames(tagAuditArgs)
+	skipped := m.varNames(tagLogSkip)
+	for _, arg := range m.argsWithoutContext() {
+		if slices.Contains(skipped, arg.Name) {
+			continue
+		}
+		if m.tags.IsSet(tagAuditArgs) && !slices.Contains(names, arg.Name) {
+			continue
+		}
+		args = append(args, arg)
+	}
+	return
+}
+
+// hideFormula returns formula of dumper:hide tag of variable.
+func (m *method) hideFormula(variable types.Variable) (formula string, hidden bool) {
+
+	for _, item := range strings.Split(m.tags.Sub(variable.Name).Value(tagTag), "|") {
+		if value, found := strings.CutPrefix(item, "dumper:hide,"); found {
+			return value, true
+		}
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package generator
+
+import "testing"
+
+func TestRenderAudit(t *testing.T) {
+
+	tests := []struct {
+		backend string
+		actor   string
+	}{
+		{backend: backendNetHTTP, actor: `ctx = srv.audit.withActor(ctx, r.Header.Get)`},
+		{backend: backendFiber, actor: `ctx.SetUserContext(srv.audit.withActor(ctx.UserContext(), func(name string) string {`},
+	}
+	for _, test := range tests {
+		t.Run(test.backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/audit", test.backend)
+			assertContains(t, files, "orders-audit.go",
+				`func auditMiddlewareOrders(cfg *auditConfig) MiddlewareOrders {`,
+				`return func(next audit.Orders) audit.Orders {`,
+				// audit-args limits recorded arguments, log-redact policy is applied to them
+				`m.audit.write(ctx, _begin, "orders", "orders.cancel", map[string]string{`,
+				`"id":    viewer.Field("id", id),`,
+				`"token": viewer.Redact("jwt", viewer.Sprintf("%+v", token)),`,
+				// log-skip excludes argument, dumper:hide masks it
+				`map[string]string{"login": viewer.Hide(viewer.Sprintf("%+v", login), "md")}`,
+				`func (m auditOrders) Get(ctx context.Context, id int) (status string, err error) {
+	return m.next.Get(ctx, id)
+}`,
+			)
+			assertNotContains(t, files, "orders-audit.go", `"reason"`, `"password"`)
+			assertContains(t, files, "audit.go",
+				`func NewAuditLogger(log zerolog.Logger) *AuditLogger {`,
+				`func NewAuditFile(filename string) (sink *AuditFile, err error) {`,
+				`_, err = sink.file.Write(append(line, '\n'))`,
+				`func AuditActorHeader(header string) AuditOption {`,
+				`func AuditActorKey(key interface{}) AuditOption {`,
+			)
+			assertContains(t, files, "server.go",
+				`func (srv *Server) WithAudit(sink AuditSink, options ...AuditOption) *Server {`,
+				`srv.httpOrders = srv.Orders().WithAudit(srv.audit)`,
+			)
+			assertContains(t, files, "orders-server.go", `srv.Wrap(auditMiddlewareOrders(audit))`)
+			assertContains(t, files, "header.go", test.actor)
+		})
+	}
+	// service without audited methods has no audit middleware
+	files := renderServer(t, "testdata/trace", backendNetHTTP)
+	if _, found := files["orders-audit.go"]; found {
+		t.Error("orders-audit.go is generated without audit annotation")
+	}
+	assertNotContains(t, files, "server.go", `WithAudit(`)
+}
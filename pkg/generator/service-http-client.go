@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,12 +33,9 @@ func (svc *service) renderClientHTTP(outDir string) (err error) {
 	}
 	srcFile.ImportName(packageContext, "context")
 	srcFile.ImportName(packageFmt, "fmt")
-	srcFile.ImportName(packageTime, "time")
+	srcFile.ImportName(packageHttp, "http")
 	srcFile.ImportName(packageUUID, "goUUID")
-	srcFile.ImportName(packageFiber, "fiber")
 
-	srcFile.ImportName(fmt.Sprintf("%s/cache", svc.tr.pkgPath(outDir)), "cache")
-	srcFile.ImportName(fmt.Sprintf("%s/hasher", svc.tr.pkgPath(outDir)), "hasher")
 	srcFile.ImportName(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "httpclient")
 
 	srcFile.Type().Id("Client" + svc.Name).StructFunc(func(g *Group) {
@@ -48,6 +46,7 @@ func (svc *service) renderClientHTTP(outDir string) (err error) {
 		Id("endpoint").String(),
 		Id("opts").Op("...").Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "Option"),
 	).Params(Id("client").Op("*").Id("Client"+svc.Name)).Block(
+		Id("opts").Op("=").Append(Index().Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "Option").Values(Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "WithRedactor").Call(Id("redactor").Values())), Id("opts").Op("...")),
 		List(Id("httpClient")).Op(":=").Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "NewClient").Call(Id("endpoint"), Id("opts").Op("...")),
 		Return(Op("&").Id("Client"+svc.Name).Values(Dict{
 			Id("httpClient"): Id("httpClient"),
@@ -55,57 +54,43 @@ func (svc *service) renderClientHTTP(outDir string) (err error) {
 	).Line()
 
 	for _, method := range svc.methods {
-		srcFile.Line().Add(svc.httpClientMethodFunc(ctx, method, outDir))
+		if !method.isActual() {
+			continue
+		}
+		if err = svc.checkClientCookies(method); err != nil {
+			return err
+		}
+		for _, verb := range method.httpMethods() {
+			srcFile.Line().Add(svc.httpClientMethodFunc(ctx, method, verb, outDir))
+		}
 	}
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-http-client.go"))
 }
 
-func (svc *service) httpClientMethodFunc(ctx context.Context, method *method, _ string) Code {
+func (svc *service) httpClientMethodFunc(ctx context.Context, method *method, httpMethod, outDir string) Code {
 
-	c := Comment(fmt.Sprintf("%s performs the %s operation.", method.Name, method.Name))
+	funcName := method.httpMethodName(httpMethod)
+	c := Comment(fmt.Sprintf("%s performs the %s operation.", funcName, method.Name))
+	if funcName != method.Name {
+		c = Comment(fmt.Sprintf("%s performs the %s operation by %s request.", funcName, method.Name, httpMethod))
+	}
 	c.Line()
 	c.Func().Params(Id("cli").Op("*").Id("Client" + svc.Name)).
-		Id(method.Name).
+		Id(funcName).
 		Params(funcDefinitionParams(ctx, method.Args)).
 		Params(funcDefinitionParams(ctx, method.Results)).
 		BlockFunc(func(g *Group) {
 			g.Line()
 			g.Var().Id("reqBody").Index().Byte()
-			var httpMethod string
-			if method.tags.Contains(tagMethodHTTP) {
-				httpMethod = method.tags.Value(tagMethodHTTP)
-			} else {
-				httpMethod = "POST"
-			}
-			var successStatusCode int
-			if method.tags.Contains(tagHttpSuccess) {
-				successCodeStr := method.tags.Value(tagHttpSuccess)
-				code, err := strconv.Atoi(successCodeStr)
-				if err != nil {
-					successStatusCode = http.StatusOK
-				} else {
-					successStatusCode = code
-				}
-			} else {
-				successStatusCode = http.StatusOK
-			}
-			methodPath := strings.ToLower(method.Name)
-			pathParams := make(map[string]string)
-			if method.tags.Contains(tagHttpPath) {
-				methodPathAnnotation := method.tags.Value(tagHttpPath)
-				matches := argPathMap(method.tags)
-				for key, value := range matches {
-					placeholder := value
-					paramName := key
-					pathParams[placeholder] = paramName
-				}
-				methodPath = methodPathAnnotation
+			successStatusCode := http.StatusOK
+			if code, err := strconv.Atoi(method.tags.Value(tagHttpSuccess)); err == nil {
+				successStatusCode = code
 			}
-			svcPrefix := svc.tags.Value(tagHttpPrefix, svc.Name)
+			pathParams := method.argPathMap()
 			argsMappings := varArgsMap(method.tags)
 			cookieMappings := varCookieMap(method.tags)
 			headerMappings := varHeaderMap(method.tags)
-			if len(method.arguments()) > 1 {
+			if len(method.arguments()) != 0 {
 				g.Id("request").Op(":=").Id(method.requestStructName()).Values(DictFunc(func(dict Dict) {
 					for idx, arg := range method.argsWithoutContext() {
 						if _, exists := argsMappings[arg.Name]; exists {
@@ -123,101 +108,190 @@ func (svc *service) httpClientMethodFunc(ctx context.Context, method *method, _
 						dict[Id(utils.ToCamel(arg.Name))] = Id(method.argsWithoutContext()[idx].Name)
 					}
 				}))
-				g.Id("reqBody").Op(",").Err().Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "Marshal").Call(Id("request"))
-				g.If(Err().Op("!=").Nil()).Block(
+				g.If(List(Id("reqBody"), Err()).Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "Marshal").Call(Id("request")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
 			}
-			g.Id("req").Op(":=").Qual(packageFasthttp, "AcquireRequest").Call()
-			g.Defer().Qual(packageFasthttp, "ReleaseRequest").Call(Id("req"))
-			urlPathFmt := methodPath
-			for placeholder := range pathParams {
-				urlPathFmt = strings.ReplaceAll(urlPathFmt, ":"+placeholder, "%v")
-			}
-			fullURLPath := path.Join("%s", svcPrefix, urlPathFmt)
 			var urlPathArgs []Code
-			urlPathArgs = append(urlPathArgs, Lit(fullURLPath))
-			urlPathArgs = append(urlPathArgs, Id("cli").Dot("httpClient").Dot("BaseURL"))
-			for _, paramName := range pathParams {
-				urlPathArgs = append(urlPathArgs, Id(paramName))
+			urlTokens := strings.Split(method.httpPath(), "/")
+			for i, token := range urlTokens {
+				if strings.HasPrefix(token, ":") {
+					paramName := strings.TrimPrefix(token, ":")
+					urlTokens[i] = "%s"
+					paramCode := Code(Id(paramName))
+					if arg := method.argByName(paramName); arg != nil {
+						paramCode = varToString(arg)
+					}
+					urlPathArgs = append(urlPathArgs, Qual(packageURL, "PathEscape").Call(paramCode))
+				}
 			}
-			g.Id("req").Dot("SetRequestURI").Call(
+			urlPathArgs = append([]Code{Lit("%s" + strings.Join(urlTokens, "/")), Id("cli").Dot("httpClient").Dot("BaseURL")}, urlPathArgs...)
+			g.Var().Id("req").Op("*").Qual(packageHttp, "Request")
+			g.If(List(Id("req"), Err()).Op("=").Qual(packageHttp, "NewRequestWithContext").Call(
+				Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "WithOperation").Call(Id(_ctx_), Lit(svc.lcName()+"."+method.lcName())),
+				Lit(httpMethod),
 				Qual(packageFmt, "Sprintf").Call(urlPathArgs...),
+				Qual(packageBytes, "NewReader").Call(Id("reqBody")),
+			).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
 			)
-			g.Id("req").Dot("Header").Dot("SetMethod").Call(Lit(httpMethod))
 			g.Id("req").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Lit("application/json"))
-			g.Id("req").Dot("SetBody").Call(Id("reqBody"))
-			for paramName, cookieName := range cookieMappings {
-				g.Id("req").Dot("Header").Dot("SetCookie").Call(Lit(cookieName), varToString(method.argByName(paramName)))
+			for _, paramName := range sortedKeys(cookieMappings) {
+				if arg := method.argByName(paramName); arg != nil {
+					g.Id("req").Dot("AddCookie").Call(Op("&").Qual(packageHttp, "Cookie").Values(Dict{
+						Id("Name"):  Lit(cookieMappings[paramName]),
+						Id("Value"): varToString(arg),
+					}))
+				}
 			}
-			for paramName, headerName := range headerMappings {
-				g.Id("req").Dot("Header").Dot("Set").Call(Lit(headerName), varToString(method.argByName(paramName)))
+			for _, paramName := range sortedKeys(headerMappings) {
+				if arg := method.argByName(paramName); arg != nil {
+					g.Id("req").Dot("Header").Dot("Set").Call(Lit(headerMappings[paramName]), varToString(arg))
+				}
 			}
-			for paramName, argName := range argsMappings {
-				paramVar := method.argByName(paramName)
-				if isPointerType(paramVar.Type) {
-					g.If(Id(paramName).Op("!=").Nil()).Block(
-						Id("req").Dot("URI").Call().Dot("QueryArgs").Call().Dot("Set").Call(Lit(argName), varToString(paramVar)),
-					)
-				} else {
-					g.Id("req").Dot("URI").Call().Dot("QueryArgs").Call().Dot("Set").Call(Lit(argName), varToString(paramVar))
+			if len(argsMappings) != 0 {
+				g.Id("query").Op(":=").Id("req").Dot("URL").Dot("Query").Call()
+				for _, paramName := range sortedKeys(argsMappings) {
+					paramVar := method.argByName(paramName)
+					if paramVar == nil {
+						continue
+					}
+					if isPointerType(paramVar.Type) {
+						g.If(Id(paramName).Op("!=").Nil()).Block(
+							Id("query").Dot("Set").Call(Lit(argsMappings[paramName]), Qual(packageFmt, "Sprint").Call(Op("*").Id(paramName))),
+						)
+					} else {
+						g.Id("query").Dot("Set").Call(Lit(argsMappings[paramName]), varToString(paramVar))
+					}
 				}
+				g.Id("req").Dot("URL").Dot("RawQuery").Op("=").Id("query").Dot("Encode").Call()
 			}
-			g.Id("resp").Op(":=").Qual(packageFasthttp, "AcquireResponse").Call()
-			g.Defer().Qual(packageFasthttp, "ReleaseResponse").Call(Id("resp"))
-			g.If(List(Id("deadline"), Id("ok")).Op(":=").Id(_ctx_).Dot("Deadline").Call(), Id("ok")).Block(
-				Id("timeout").Op(":=").Qual(packageTime, "Until").Call(Id("deadline")),
-				Id("cli").Dot("httpClient").Dot("SetTimeout").Call(Id("timeout")),
-			)
-			g.If(Err().Op("=").Id("cli").Dot("httpClient").Dot("Do").Call(Id("ctx"), Id("req"), Id("resp")).Op(";").Err().Op("!=").Nil()).Block(
-				Return(),
-			)
-			g.Id("respBody").Op(":=").Id("resp").Dot("Body").Call()
-			g.If(Id("resp").Dot("StatusCode").Call().Op("!=").Lit(successStatusCode)).Block(
-				Err().Op("=").Qual(packageFmt, "Errorf").Call(
-					Lit("HTTP error: %d. URL: %s, Method: %s, Body: %s"),
-					Id("resp").Dot("StatusCode").Call(),
-					Id("req").Dot("URI").Call().Dot("String").Call(),
-					Id("req").Dot("Header").Dot("Method").Call(),
-					String().Call(Id("respBody")),
-				),
+			retHeaders, retCookies := svc.httpClientResultMaps(method)
+			respID, respBodyID := Id("_"), Id("_")
+			if len(retHeaders)+len(retCookies) != 0 {
+				respID = Id("resp")
+				g.Var().Id("resp").Op("*").Qual(packageHttp, "Response")
+			}
+			withBody := httpMethod != http.MethodHead && len(method.resultsWithoutError()) != 0
+			if withBody {
+				respBodyID = Id("respBody")
+				g.Var().Id("respBody").Index().Byte()
+			}
+			g.If(List(respID, respBodyID, Err()).Op("=").Id("cli").Dot("httpClient").Dot("Do").Call(Id("req"), Lit(successStatusCode)).Op(";").Err().Op("!=").Nil()).Block(
 				Return(),
 			)
-			if len(method.resultsWithoutError()) == 1 {
+			if withBody && len(method.resultsWithoutError()) == 1 {
 				g.Var().Id("response").Id(method.responseStructName())
 				g.If(Err().Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "Unmarshal").Call(Id("respBody"), Op("&").Id("response").Dot(utils.ToCamel(method.resultsWithoutError()[0].Name))).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
-				for _, ret := range method.resultsWithoutError() {
-					g.Id(ret.Name).Op("=").Id("response").Dot(utils.ToCamel(ret.Name))
-				}
-			} else {
+			} else if withBody {
 				g.Var().Id("response").Id(method.responseStructName())
 				g.If(Err().Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "Unmarshal").Call(Id("respBody"), Op("&").Id("response")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
-				for _, ret := range method.resultsWithoutError() {
+			}
+			for _, ret := range method.resultsWithoutError() {
+				if withBody {
 					g.Id(ret.Name).Op("=").Id("response").Dot(utils.ToCamel(ret.Name))
 				}
 			}
+			for _, retName := range sortedKeys(retHeaders) {
+				g.Add(svc.httpClientResultFromString(method, retName, Id("resp").Dot("Header").Dot("Get").Call(Lit(retHeaders[retName]))))
+			}
+			for _, retName := range sortedKeys(retCookies) {
+				g.Add(svc.httpClientResultFromCookie(method, retName, retCookies[retName], outDir))
+			}
 			g.Return()
 		})
 	return c
 }
 
-func argPathMap(tags tags.DocTags) (paths map[string]string) {
+// httpClientResultMaps returns results mapped by http-headers and http-cookies to header and cookie names.
+func (svc *service) httpClientResultMaps(method *method) (headers, cookies map[string]string) {
 
-	pathToArg := make(map[string]string)
-	if urlPath := tags.Value(tagHttpPath); urlPath != "" {
-		urlTokens := strings.Split(urlPath, "/")
-		for _, token := range urlTokens {
-			if strings.HasPrefix(token, ":") {
-				arg := strings.TrimSpace(strings.TrimPrefix(token, ":"))
-				pathToArg[arg] = arg
-			}
+	headers = make(map[string]string)
+	for retName, header := range method.varHeaderMap() {
+		if method.resultByName(retName) != nil {
+			headers[retName] = header
 		}
 	}
-	return pathToArg
+	return headers, method.retCookieMap()
+}
+
+func (svc *service) httpClientResultFromString(method *method, retName string, from *Statement) Code {
+
+	ret := method.resultByName(retName)
+	tmpName := "_" + retName
+	return If(Id(tmpName).Op(":=").Add(from).Op(";").Id(tmpName).Op("!=").Lit("")).BlockFunc(func(bg *Group) {
+		retID := Id("ret" + utils.ToCamel(retName))
+		bg.Var().Add(retID).Add(httpClientBaseType(ret.Type))
+		bg.Add(method.argToTypeConverter(Id(tmpName), ret.Type, retID.Clone(), Line().If(Err().Op("!=").Nil()).Block(Return())))
+		if isPointerType(ret.Type) {
+			bg.Id(retName).Op("=").Op("&").Add(retID.Clone())
+			return
+		}
+		bg.Id(retName).Op("=").Add(retID.Clone())
+	})
+}
+
+// checkClientCookies reports results of method, which can not be read from cookies, e.g. maps and interfaces.
+func (svc *service) checkClientCookies(method *method) (err error) {
+
+	for _, retName := range sortedKeys(method.retCookieMap()) {
+		if ret := method.resultByName(retName); ret != nil && types.TypeName(ret.Type) == nil {
+			return fmt.Errorf("%s.%s: result '%s' of type %s can not be read from cookie", svc.Name, method.Name, retName, ret.Type)
+		}
+	}
+	return
+}
+
+func (svc *service) httpClientResultFromCookie(method *method, retName, cookieName, outDir string) Code {
+
+	ret := method.resultByName(retName)
+	if typeName := types.TypeName(ret.Type); typeName != nil {
+		switch *typeName {
+		case "string", "bool", "int", "int64", "int32", "uint", "uint64", "uint32", "float64", "float32", "UUID", "Time":
+			return svc.httpClientResultFromString(method, retName, Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "ResponseCookieValue").Call(Id("resp"), Lit(cookieName)))
+		}
+	}
+	return If(Id("rCookie").Op(":=").Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "ResponseCookie").Call(Id("resp"), Lit(cookieName)).Op(";").Id("rCookie").Op("!=").Nil()).BlockFunc(func(bg *Group) {
+		target := Op("&").Id(retName)
+		if isPointerType(ret.Type) {
+			target = Id("target")
+			bg.Id("target").Op(":=").Id(retName)
+			bg.If(Id("target").Op("==").Nil()).Block(
+				Id("target").Op("=").New(httpClientBaseType(ret.Type)),
+			)
+		}
+		bg.If(List(Id("setter"), Id("ok")).Op(":=").Any().Call(target).Op(".").Call(Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "CookieSetter")).Op(";").Id("ok")).BlockFunc(func(ig *Group) {
+			ig.Id("setter").Dot("SetCookie").Call(Id("rCookie"))
+			if isPointerType(ret.Type) {
+				ig.Id(retName).Op("=").Id("target")
+			}
+		})
+	})
+}
+
+func httpClientBaseType(varType types.Type) Code {
+
+	typeName := varType.String()
+	if t, ok := varType.(types.TPointer); ok {
+		typeName = t.NextType().String()
+	}
+	if pkg := importPackage(varType); pkg != "" {
+		return Qual(pkg, strings.Split(typeName, ".")[1])
+	}
+	return Id(typeName)
+}
+
+func sortedKeys(values map[string]string) (keys []string) {
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
 }
 
 func varArgsMap(tags tags.DocTags) map[string]string {
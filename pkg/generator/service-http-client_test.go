@@ -0,0 +1,42 @@
+package generator
+
+import "testing"
+
+func TestRenderHTTPClient(t *testing.T) {
+
+	files := renderClient(t, "testdata/files")
+	assertContains(t, files, "files-http-client.go",
+		`"GET", fmt.Sprintf("%s/api/files/%s", cli.httpClient.BaseURL, url.PathEscape(fmt.Sprint(id)))`,
+		`req.Header.Set("X-Token", token)`,
+		`query.Set("limit", fmt.Sprint(limit))`,
+		`cli.httpClient.Do(req, 200)`,
+		`"POST", fmt.Sprintf("%s/api/files/upload", cli.httpClient.BaseURL)`,
+		`cli.httpClient.Do(req, 201)`,
+		`func (cli *ClientFiles) UploadPut(ctx context.Context, name string, data []byte) (id int, err error) {`,
+		`"PUT", fmt.Sprintf("%s/api/files/upload", cli.httpClient.BaseURL)`,
+	)
+	assertContains(t, files, "http-options.go",
+		`func DecodeErrorHTTP(decoder ErrorDecoder) httpclient.Option {`,
+		`func CircuitBreakerHTTP(cfg cb.Settings) httpclient.Option {`,
+	)
+	// client calls routes of server, which are built by the same paths
+	server := renderServer(t, "testdata/files", backendNetHTTP)
+	assertContains(t, server, "files-http.go",
+		`mux.HandleFunc("GET /api/files/{id}", http.serveGet)`,
+		`mux.HandleFunc("POST /api/files/upload", http.serveUpload)`,
+		`mux.HandleFunc("PUT /api/files/upload", http.serveUpload)`,
+	)
+	if _, found := files["httpclient/httpclient_test.go"]; found {
+		t.Error("tests of httpclient are copied to client")
+	}
+}
+
+func TestRenderHTTPClientWithoutPrefix(t *testing.T) {
+
+	files := renderClient(t, "testdata/verbs")
+	assertContains(t, files, "cache-http-client.go",
+		`fmt.Sprintf("%s/cache/%s", cli.httpClient.BaseURL, url.PathEscape(key))`,
+		`fmt.Sprintf("%s/cache/trace", cli.httpClient.BaseURL)`,
+	)
+	assertNotContains(t, files, "cache-http-client.go", `"%s//`)
+}
@@ -6,6 +6,7 @@ package generator
 import (
 	"path"
 	"path/filepath"
+	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 
@@ -21,6 +22,9 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 	srcFile.ImportName(packageFiber, "fiber")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+	if svc.tr.isNetHTTP() {
+		srcFile.ImportAlias(packageHttp, "nethttp")
+	}
 
 	srcFile.Type().Id("http"+svc.Name).Struct(
 		Id("errorHandler").Id("ErrorHandler"),
@@ -47,8 +51,15 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 	if svc.tags.IsSet(tagMetrics) {
 		srcFile.Line().Add(svc.withMetricsFunc())
 	}
+	if svc.hasAudit() {
+		srcFile.Line().Add(svc.withAuditFunc())
+	}
 	srcFile.Line().Add(svc.withErrorHandler())
 
+	if svc.tr.isNetHTTP() {
+		srcFile.Line().Add(svc.setRoutesNetHTTP())
+		return srcFile.Save(path.Join(outDir, svc.lcName()+"-http.go"))
+	}
 	srcFile.Line().Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("SetRoutes").Params(Id("route").Op("*").Qual(packageFiber, "App")).BlockFunc(func(bg *Group) {
 		if svc.tags.Contains(tagServerJsonRPC) {
 			bg.Id("route").Dot("Post").Call(Lit(svc.batchPath()), Id("http").Dot("serveBatch"))
@@ -56,7 +67,9 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 				if !method.isJsonRPC() {
 					continue
 				}
-				bg.Id("route").Dot("Post").Call(Lit(method.jsonrpcPath()), Id("http").Dot("serve"+method.Name))
+				for _, version := range method.routeVersions() {
+					bg.Id("route").Dot("Post").Call(Lit(method.jsonrpcPathVersion(version)), method.routeHandler(version, Id("http").Dot("serve"+method.Name)))
+				}
 			}
 		}
 		if svc.tags.Contains(tagServerHTTP) {
@@ -64,13 +77,21 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 				if !method.isHTTP() {
 					continue
 				}
+				handler := Id("http").Dot("serve" + method.Name)
 				if method.tags.Contains(tagHandler) {
-					bg.Id("route").Dot(utils.ToCamel(method.httpMethod())).Call(Lit(method.httpPath()), Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).Block(
+					handler = Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).Block(
 						Return().Qual(method.handlerQual()).Call(Id(_ctx_), Id("http").Dot("base")),
-					))
-					continue
+					)
+				}
+				for _, version := range method.routeVersions() {
+					for _, verb := range method.routeMethods() {
+						if isStandardHTTPMethod(verb) {
+							bg.Id("route").Dot(utils.ToCamel(strings.ToLower(verb))).Call(Lit(method.httpPathVersion(version)), method.routeHandler(version, handler))
+							continue
+						}
+						bg.Id("route").Dot("Add").Call(Lit(verb), Lit(method.httpPathVersion(version)), method.routeHandler(version, handler))
+					}
 				}
-				bg.Id("route").Dot(utils.ToCamel(method.httpMethod())).Call(Lit(method.httpPath()), Id("http").Dot("serve"+method.Name))
 			}
 		}
 	})
@@ -106,9 +127,18 @@ func (svc *service) withTraceFunc() Code {
 
 func (svc *service) withMetricsFunc() Code {
 
-	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("WithMetrics").Params().Params(Op("*").Id("http" + svc.Name)).BlockFunc(func(bg *Group) {
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("WithMetrics").Params(Id("metrics").Op("*").Id("metricsConfig")).Params(Op("*").Id("http" + svc.Name)).BlockFunc(func(bg *Group) {
+
+		bg.Id("http").Dot("svc").Dot("WithMetrics").Call(Id("metrics"))
+		bg.Return(Id("http"))
+	})
+}
+
+func (svc *service) withAuditFunc() Code {
+
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("WithAudit").Params(Id("audit").Op("*").Id("auditConfig")).Params(Op("*").Id("http" + svc.Name)).BlockFunc(func(bg *Group) {
 
-		bg.Id("http").Dot("svc").Dot("WithMetrics").Call()
+		bg.Id("http").Dot("svc").Dot("WithAudit").Call(Id("audit"))
 		bg.Return(Id("http"))
 	})
 }
@@ -0,0 +1,48 @@
+package generator
+
+import "testing"
+
+func TestRenderVerbs(t *testing.T) {
+
+	tests := []struct {
+		backend string
+		routes  []string
+	}{
+		{
+			backend: backendNetHTTP,
+			routes: []string{
+				// pattern of GET matches HEAD requests too
+				`mux.HandleFunc("GET /cache/{key}", http.serveGet)`,
+				`mux.HandleFunc("TRACE /cache/trace", http.serveTrace)`,
+				`mux.HandleFunc("PURGE /cache/{key}", http.servePurge)`,
+			},
+		},
+		{
+			backend: backendFiber,
+			routes: []string{
+				`route.Get("/cache/:key", http.serveGet)`,
+				`route.Trace("/cache/trace", http.serveTrace)`,
+				`route.Add("PURGE", "/cache/:key", http.servePurge)`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/verbs", test.backend)
+			assertContains(t, files, "cache-http.go", test.routes...)
+			if test.backend == backendFiber {
+				assertContains(t, files, "server.go", `srv.config.RequestMethods = withMethods(srv.config.RequestMethods, "PURGE")`)
+			}
+		})
+	}
+	t.Run("client", func(t *testing.T) {
+		files := renderClient(t, "testdata/verbs")
+		assertContains(t, files, "cache-http-client.go",
+			`func (cli *ClientCache) Get(ctx context.Context, key string) (value string, err error) {`,
+			`func (cli *ClientCache) GetHead(ctx context.Context, key string) (value string, err error) {`,
+			`"HEAD", fmt.Sprintf("%s/cache/%s"`,
+			`"TRACE", fmt.Sprintf("%s/cache/trace"`,
+			`"PURGE", fmt.Sprintf("%s/cache/%s"`,
+		)
+	})
+}
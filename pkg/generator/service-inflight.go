@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+func (svc *service) renderInflight(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
+
+	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+
+	srcFile.Type().Id("inflight"+svc.Name).Struct(
+		Id(_next_).Qual(svc.pkgPath, svc.Name),
+		Id("calls").Op("*").Id("inflightCalls"),
+	)
+
+	srcFile.Line().Func().Id("inflightMiddleware" + svc.Name).Params(Id("calls").Op("*").Id("inflightCalls")).Params(Id("Middleware" + svc.Name)).Block(
+		Return(Func().Params(Id(_next_).Qual(svc.pkgPath, svc.Name)).Params(Qual(svc.pkgPath, svc.Name)).Block(
+			Return(Op("&").Id("inflight" + svc.Name).Values(Dict{
+				Id(_next_):  Id(_next_),
+				Id("calls"): Id("calls"),
+			})),
+		)),
+	)
+
+	for _, method := range svc.methods {
+		srcFile.Line().Func().Params(Id("m").Id("inflight"+svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
+			Line().Defer().Id("m").Dot("calls").Dot("begin").Call(Lit(method.fullName())).Call(),
+			Return().Id("m").Dot(_next_).Dot(method.Name).CallFunc(func(cg *Group) {
+				for _, arg := range method.Args {
+					argCode := Id(arg.Name)
+					if types.IsEllipsis(arg.Type) {
+						argCode.Op("...")
+					}
+					cg.Add(argCode)
+				}
+			}),
+		)
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-inflight.go"))
+}
@@ -33,13 +33,13 @@ func (svc *service) renderClientJsonRPC(outDir string) (err error) {
 		sg.Op("*").Id("ClientJsonRPC")
 	}).Line()
 	for _, method := range svc.methods {
-		if method.tags.Contains(tagMethodHTTP) {
+		if method.tags.Contains(tagMethodHTTP) || !method.isActual() {
 			continue
 		}
 		srcFile.Type().Id("ret" + svc.Name + method.Name).Op("=").Func().Params(funcDefinitionParams(ctx, method.Results))
 	}
 	for _, method := range svc.methods {
-		if method.tags.Contains(tagMethodHTTP) {
+		if method.tags.Contains(tagMethodHTTP) || !method.isActual() {
 			continue
 		}
 		srcFile.Line().Add(svc.jsonrpcClientMethodFunc(ctx, method, outDir))
@@ -68,16 +68,16 @@ func (svc *service) jsonrpcClientMethodFunc(ctx context.Context, method *method,
 				Id("fallbackCheck").Op("=").Id("cli").Dot("fallback" + svc.Name).Dot(method.Name),
 			)
 			bg.Id("callMethod").Op(":=").Func().Params(Id("request").Any()).Params(Id("response").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "ResponseRPC"), Err().Error()).Block(
-				Return(Id("cli").Dot("rpc").Dot("Call").Call(Id(_ctx_), Lit(svc.lcName()+"."+method.lcName()), Id("request"))),
+				Return(Id("cli").Dot("rpc").Dot("Call").Call(Id(_ctx_), Lit(method.jsonrpcName()), Id("request"))),
 			)
 			bg.If(Err().Op("=").
-				Id("cli").Dot("proceedResponse").Call(Id(_ctx_), Id("callMethod"), Id("request"), Id("fallbackCheck"), Op("&").Id("response")).
+				Id("cli").Dot("proceedResponse").Call(Id(_ctx_), Lit(method.jsonrpcName()), Id("callMethod"), Id("request"), Id("fallbackCheck"), Op("&").Id("response")).
 				Op(";").Err().Op("!=").Nil()).Block(
 				Return(),
 			)
 		} else {
 			bg.Var().Id("rpcResponse").Op("*").Qual(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "ResponseRPC")
-			bg.If(List(Id("rpcResponse"), Err()).Op("=").Id("cli").Dot("rpc").Dot("Call").Call(Id(_ctx_), Lit(svc.lcName()+"."+method.lcName()), Id("request")).Op(";").Err().Op("!=").Nil().Op("||").Id("rpcResponse").Op("==").Nil()).Block(
+			bg.If(List(Id("rpcResponse"), Err()).Op("=").Id("cli").Dot("rpc").Dot("Call").Call(Id(_ctx_), Lit(method.jsonrpcName()), Id("request")).Op(";").Err().Op("!=").Nil().Op("||").Id("rpcResponse").Op("==").Nil()).Block(
 				Return(),
 			)
 			bg.If(Id("rpcResponse").Dot("Error").Op("!=").Nil()).Block(
@@ -111,16 +111,16 @@ func (svc *service) jsonrpcClientRequestFunc(ctx context.Context, method *method
 
 		bg.Line()
 		bg.Id("request").Op("=").Id("RequestRPC").Values(Dict{
-			Id("rpcRequest"): Op("&").Qual(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "RequestRPC").Values(Dict{
+			Id("rpcRequest"): Parens(Op("&").Qual(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "RequestRPC").Values(Dict{
 				Id("ID"):      Qual(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "NewID").Call(),
 				Id("JSONRPC"): Qual(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "Version"),
-				Id("Method"):  Lit(svc.lcName() + "." + method.lcName()),
+				Id("Method"):  Lit(method.jsonrpcName()),
 				Id("Params"): Id(method.requestStructName()).Values(DictFunc(func(dg Dict) {
 					for idx, arg := range method.fieldsArgument() {
 						dg[Id(utils.ToCamel(arg.Name))] = Id(method.argsWithoutContext()[idx].Name)
 					}
 				})),
-			}),
+			})).Dot("WithContext").Call(Id(_ctx_)),
 		})
 		bg.If(Id("callback").Op("!=").Nil()).Block(
 			Var().Id("response").Id(method.responseStructName()),
@@ -145,7 +145,7 @@ func (svc *service) jsonrpcClientRequestFunc(ctx context.Context, method *method
 						),
 						Return(Id("rpcResponse"), Err()),
 					)
-					bg.Err().Op("=").Id("cli").Dot("proceedResponse").Call(Id(_ctx_), Id("callMethod"), Id("request"), Id("fallbackCheck"), Op("&").Id("response"))
+					bg.Err().Op("=").Id("cli").Dot("proceedResponse").Call(Id(_ctx_), Lit(method.jsonrpcName()), Id("callMethod"), Id("request"), Id("fallbackCheck"), Op("&").Id("response"))
 					bg.Id("callback").CallFunc(func(cg *Group) {
 						for _, ret := range method.fieldsResult() {
 							cg.Id("response").Dot(utils.ToCamel(ret.Name))
@@ -184,6 +184,9 @@ func (svc *service) renderClientFallbackError(outDir string) (err error) {
 
 	srcFile.Type().Id("fallback" + svc.Name).InterfaceFunc(func(ig *Group) {
 		for _, method := range svc.methods {
+			if !method.isActual() {
+				continue
+			}
 			ig.Id(method.Name).Params(Err().Error()).Bool()
 		}
 	})
@@ -30,19 +30,36 @@ func (svc *service) renderJsonRPC(outDir string) (err error) {
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
 	srcFile.ImportName(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
 	srcFile.ImportName(fmt.Sprintf("%s/context", svc.tr.pkgPath(outDir)), "context")
+	if svc.tr.isNetHTTP() {
+		srcFile.ImportAlias(packageHttp, "nethttp")
+	}
 
 	for _, method := range svc.methods {
 		if !method.isJsonRPC() {
 			continue
 		}
-		srcFile.Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).Block(
-			Return().Id("http").Dot("_serveMethod").Call(Id(_ctx_), Lit(method.lcName()), Id("http").Dot(method.lccName())),
-		)
+		if svc.tr.isNetHTTP() {
+			srcFile.Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(svc.tr.handlerParams()).Block(
+				Id("http").Dot("_serveMethod").Call(Id("w"), Id("r"), Lit(method.lcName()), Id("http").Dot(method.lccName())),
+			)
+		} else {
+			srcFile.Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).Block(
+				Return().Id("http").Dot("_serveMethod").Call(Id(_ctx_), Lit(method.lcName()), Id("http").Dot(method.lccName())),
+			)
+		}
 		srcFile.Add(svc.rpcMethodFunc(method, outDir))
 	}
-	srcFile.Add(svc.serveMethodFunc())
+	if svc.tr.isNetHTTP() {
+		srcFile.Add(svc.serveMethodFuncNetHTTP())
+	} else {
+		srcFile.Add(svc.serveMethodFunc())
+	}
 	srcFile.Add(svc.batchFunc())
-	srcFile.Add(svc.serveBatchFunc())
+	if svc.tr.isNetHTTP() {
+		srcFile.Add(serveBatchFuncNetHTTP(Id("http").Op("*").Id("http"+svc.Name), "http", svc.deprecatedJsonRPC(""), "deprecated"+svc.Name+"JsonRPC", svc.tr.tags.Value(tagPackageJSON, packageStdJSON)))
+	} else {
+		srcFile.Add(svc.serveBatchFunc())
+	}
 	srcFile.Add(svc.singleBatchFunc())
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-jsonrpc.go"))
 }
@@ -50,14 +67,14 @@ func (svc *service) renderJsonRPC(outDir string) (err error) {
 func (svc *service) rpcMethodFunc(method *method, outDir string) Code {
 
 	return Func().Params(Id("http").Op("*").Id("http"+svc.Name)).Id(method.lccName()).
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("requestBase").Id("baseJsonRPC")).
+		Params(svc.tr.handlerParams(), Id("requestBase").Id("baseJsonRPC")).
 		Params(Id("responseBase").Op("*").Id("baseJsonRPC")).BlockFunc(func(bg *Group) {
 		bg.Line()
 		bg.Var().Err().Error()
 		bg.Var().Id("request").Id(method.requestStructName())
 		bg.Var().Id("response").Id(method.responseStructName())
 		bg.Line()
-		bg.Id("methodCtx").Op(":=").Id(_ctx_).Dot("UserContext").Call()
+		bg.Id("methodCtx").Op(":=").Add(svc.tr.userContext())
 		bg.Id("methodCtx").Op("=").
 			Qual(packageZeroLogLog, "Ctx").Call(Id("methodCtx")).
 			Dot("With").Call().
@@ -65,18 +82,25 @@ func (svc *service) rpcMethodFunc(method *method, outDir string) Code {
 			Dot("Logger").Call().
 			Dot("WithContext").Call(Id("methodCtx"))
 		bg.Line()
-		bg.Defer().Func().Params().Block(
-			Id(_ctx_).Dot("SetUserContext").Call(Qual(fmt.Sprintf("%s/context", svc.tr.pkgPath(outDir)), "WithCtx").Call(
-				Id("methodCtx"),
-				Id("MethodCallMeta").Block(Dict{
-					Id("Err"):      Err(),
-					Id("Request"):  Op("&").Id("request"),
-					Id("Response"): Op("&").Id("response"),
-					Id("Service"):  Lit(svc.lcName()),
-					Id("Method"):   Lit(method.lcName()),
-				}),
-			)),
-		).Call()
+		callMeta := Id("MethodCallMeta").Block(Dict{
+			Id("Err"):      Err(),
+			Id("Request"):  Op("&").Id("request"),
+			Id("Response"): Op("&").Id("response"),
+			Id("Service"):  Lit(svc.lcName()),
+			Id("Method"):   Lit(method.lcName()),
+		})
+		if svc.tr.isNetHTTP() {
+			bg.Defer().Func().Params().Block(
+				Id("setMethodCall").Call(Id("methodCtx"), callMeta),
+			).Call()
+		} else {
+			bg.Defer().Func().Params().Block(
+				Id(_ctx_).Dot("SetUserContext").Call(Qual(fmt.Sprintf("%s/context", svc.tr.pkgPath(outDir)), "WithCtx").Call(
+					Id("methodCtx"),
+					callMeta,
+				)),
+			).Call()
+		}
 		bg.If(Id("requestBase").Dot("Params").Op("!=").Nil()).Block(
 			If(Err().Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "Unmarshal").Call(Id("requestBase").Dot("Params"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
 				ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil()))
@@ -134,7 +158,7 @@ func (svc *service) rpcMethodFunc(method *method, outDir string) Code {
 					bg.If(List(Id("rCookie"), Id("ok")).Op(":=").
 						Qual(packageReflect, "ValueOf").Call(Id("response").Dot(utils.ToCamel(retName))).Dot("Interface").Call().
 						Op(".").Call(Id("cookieType"))).Op(";").Id("ok").Op("&&").Id("response").Dot(utils.ToCamel(retName)).Op("!=").Nil().Block(
-						Id(_ctx_).Dot("Cookie").Call(Id("rCookie").Dot("Cookie").Call()),
+						svc.tr.setCookie(Id("rCookie").Dot("Cookie").Call()),
 					)
 				}
 			}
@@ -184,16 +208,19 @@ func (svc *service) serveMethodFunc() Code {
 func (svc *service) batchFunc() Code {
 
 	return Func().Params(Id("http").Op("*").Id("http"+svc.Name)).Id("doBatch").
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("requests").Op("[]").Id("baseJsonRPC")).Params(Id("responses").Id("jsonrpcResponses")).BlockFunc(
+		Params(svc.tr.handlerParams(), Id("requests").Op("[]").Id("baseJsonRPC")).Params(Id("responses").Id("jsonrpcResponses")).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.If(Len(Id("requests")).Op(">").Id("http").Dot("maxBatchSize")).Block(
 				Id("responses").Dot("append").Call(Id("makeErrorResponseJsonRPC").Call(Nil(), Id("invalidRequestError"), Lit("batch size exceeded"), Nil())),
 				Return(),
 			)
-			bg.If(Qual(packageStrings, "EqualFold").Call(Id(_ctx_).Dot("Get").Call(Lit(syncHeader)), Lit("true"))).Block(
+			if svc.tags.IsSet(tagTrace) {
+				bg.Id("traceBatch").Call(svc.tr.userContext(), Id("requests"))
+			}
+			bg.If(Qual(packageStrings, "EqualFold").Call(svc.tr.requestHeader(Lit(syncHeader)), Lit("true"))).Block(
 				For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
-					Id("response").Op(":=").Id("http").Dot("doSingleBatch").Call(Id(_ctx_), Id("request")),
+					Id("response").Op(":=").Id("http").Dot("doSingleBatch").Call(svc.tr.handlerArgs(), Id("request")),
 					If(Id("request").Dot("ID").Op("!=").Nil()).Block(
 						Id("responses").Dot("append").Call(Id("response")),
 					),
@@ -212,7 +239,7 @@ func (svc *service) batchFunc() Code {
 				Go().Func().Params().Block(
 					Defer().Id("wg").Dot("Done").Call(),
 					For(Id("request").Op(":=").Range().Id("callCh").Block(
-						Id("response").Op(":=").Id("http").Dot("doSingleBatch").Call(Id(_ctx_), Id("request")),
+						Id("response").Op(":=").Id("http").Dot("doSingleBatch").Call(svc.tr.handlerArgs(), Id("request")),
 						If(Id("request").Dot("ID").Op("!=").Nil()).Block(
 							Id("responses").Dot("append").Call(Id("response")),
 						),
@@ -231,7 +258,7 @@ func (svc *service) batchFunc() Code {
 func (svc *service) singleBatchFunc() Code {
 
 	return Func().Params(Id("http").Op("*").Id("http"+svc.Name)).Id("doSingleBatch").
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("request").Id("baseJsonRPC")).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
+		Params(svc.tr.handlerParams(), Id("request").Id("baseJsonRPC")).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
@@ -242,8 +269,12 @@ func (svc *service) singleBatchFunc() Code {
 						if !method.isJsonRPC() {
 							continue
 						}
-						sg.Case(Lit(method.lcName())).Block(
-							Return(Id("http").Dot(utils.ToLowerCamel(method.Name)).Call(Id(_ctx_), Id("request"))),
+						names := method.jsonrpcCase(method.lcName())
+						if len(names) == 0 {
+							continue
+						}
+						sg.Case(names...).Block(
+							Return(Id("http").Dot(utils.ToLowerCamel(method.Name)).Call(svc.tr.handlerArgs(), Id("request"))),
 						)
 					}
 					sg.Default().BlockFunc(func(dg *Group) {
@@ -277,6 +308,7 @@ func (svc *service) serveBatchFunc() Code {
 				ig.Id("single").Op("=").True()
 				ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
 			})
+			bg.Add(deprecationJsonRPC(Id(_ctx_), "deprecated"+svc.Name+"JsonRPC", svc.deprecatedJsonRPC("")))
 			bg.If(Id("single")).Block(
 				Return(Id("sendResponse").Call(Id(_ctx_), Id("http").Dot("doSingleBatch").
 					Call(Id(_ctx_), Id("requests").Op("[").Lit(0).Op("]")),
@@ -287,3 +319,27 @@ func (svc *service) serveBatchFunc() Code {
 			))
 		})
 }
+
+func (svc *service) serveMethodFuncNetHTTP() Code {
+
+	return Func().Params(Id("http").Op("*").Id("http"+svc.Name)).Id("_serveMethod").
+		Params(svc.tr.handlerParams(), Id("methodName").String(), Id("methodHandler").Id("methodJsonRPC")).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Var().Id("request").Id("baseJsonRPC")
+			bg.Var().Id("response").Op("*").Id("baseJsonRPC")
+			bg.If(Err().Op(":=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "NewDecoder").Call(Id("r").Dot("Body")).Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("makeErrorResponseJsonRPC").Call(Op("[]").Byte().Call(Lit(`"0"`)), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil())),
+				Return(),
+			)
+			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
+			bg.Id("method").Op(":=").Qual(packageStrings, "ToLower").Call(Id("request").Dot("Method"))
+			bg.If(Id("method").Op("!=").Lit("").Op("&&").Id("method").Op("!=").Id("methodName")).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("methodNotFoundError"), Lit("invalid method ").Op("+").Id("methodNameOrigin"), Nil())),
+				Return(),
+			)
+			bg.If(Id("response").Op("=").Id("methodHandler").Call(Id("w"), Id("r"), Id("request")).Op(";").Id("response").Op("!=").Nil()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("response")),
+			)
+		})
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"path"
 	"path/filepath"
+	"slices"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 )
@@ -21,8 +22,9 @@ func (svc *service) renderMetrics(outDir string) (err error) {
 	srcFile.ImportName(packagePrometheus, "metrics")
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
 
-	srcFile.Type().Id("metrics" + svc.Name).Struct(
+	srcFile.Type().Id("metrics"+svc.Name).Struct(
 		Id(_next_).Qual(svc.pkgPath, svc.Name),
+		Id("metrics").Op("*").Id("metricsConfig"),
 	)
 
 	srcFile.Line().Add(svc.metricsMiddleware())
@@ -35,14 +37,14 @@ func (svc *service) renderMetrics(outDir string) (err error) {
 
 func (svc *service) metricsMiddleware() Code {
 
-	return Func().Id("metricsMiddleware" + svc.Name).Params(Id(_next_).Qual(svc.pkgPath, svc.Name)).Params(Qual(svc.pkgPath, svc.Name)).
-		BlockFunc(func(g *Group) {
-			g.Return(Op("&").Id("metrics" + svc.Name).Values(
-				Dict{
-					Id(_next_): Id(_next_),
-				},
-			))
-		})
+	return Func().Id("metricsMiddleware" + svc.Name).Params(Id("metrics").Op("*").Id("metricsConfig")).Params(Id("Middleware" + svc.Name)).Block(
+		Return(Func().Params(Id(_next_).Qual(svc.pkgPath, svc.Name)).Params(Qual(svc.pkgPath, svc.Name)).Block(
+			Return(Op("&").Id("metrics" + svc.Name).Values(Dict{
+				Id(_next_):    Id(_next_),
+				Id("metrics"): Id("metrics"),
+			})),
+		)),
+	)
 }
 
 func (svc *service) metricFuncBody(method *method) func(g *Group) {
@@ -51,7 +53,9 @@ func (svc *service) metricFuncBody(method *method) func(g *Group) {
 
 		errCodeAssignment := Id("errCode").Op("=")
 
-		if method.isHTTP() {
+		if method.isHTTP() && svc.tr.isNetHTTP() {
+			errCodeAssignment.Qual(packageHttp, "StatusInternalServerError")
+		} else if method.isHTTP() {
 			errCodeAssignment.Qual(packageFiber, "StatusInternalServerError")
 		} else {
 			errCodeAssignment.Id("internalError")
@@ -70,24 +74,22 @@ func (svc *service) metricFuncBody(method *method) func(g *Group) {
 					Id("errCode").Op("=").Id("ec").Dot("Code").Call(),
 				),
 			),
-			Id("RequestCount").Dot("WithLabelValues").Call(
-				Lit(method.svc.lccName()),
-				Lit(method.lccName()),
-				Qual("strconv", "FormatBool").Call(Id("success")),
-				Qual("strconv", "Itoa").Call(Id("errCode"))).
-				Dot("Add").Call(Lit(1)),
-			Id("RequestCountAll").Dot("WithLabelValues").Call(
-				Lit(method.svc.lccName()),
-				Lit(method.lccName()),
-				Qual("strconv", "FormatBool").Call(Id("success")),
-				Qual("strconv", "Itoa").Call(Id("errCode"))).
-				Dot("Add").Call(Lit(1)),
-			Id("RequestLatency").Dot("WithLabelValues").Call(
-				Lit(method.svc.lccName()),
-				Lit(method.lccName()),
-				Qual("strconv", "FormatBool").Call(Id("success")),
-				Qual("strconv", "Itoa").Call(Id("errCode"))).
-				Dot("Observe").Call(Qual(packageTime, "Since").Call(Id("_begin")).Dot("Seconds").Call()),
+			Id("m").Dot("metrics").Dot("observeRequest").CallFunc(func(cg *Group) {
+				cg.Id(_ctx_)
+				cg.Id("_begin")
+				cg.Lit(method.svc.lccName())
+				cg.Lit(method.lccName())
+				cg.Qual("strconv", "FormatBool").Call(Id("success"))
+				cg.Qual("strconv", "Itoa").Call(Id("errCode"))
+				labels := method.metricsLabels()
+				for _, label := range svc.tr.metricsArgLabels() {
+					if slices.Contains(labels, label) {
+						cg.Add(varToString(method.argByName(label)))
+						continue
+					}
+					cg.Lit("")
+				}
+			}),
 		).Call(Qual(packageTime, "Now").Call())
 
 		g.Line().Return().Id("m").Dot(_next_).Dot(method.Name).Call(paramNames(method.Args))
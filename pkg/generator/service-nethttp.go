@@ -0,0 +1,110 @@
+package generator
+
+import (
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+func (svc *service) setRoutesNetHTTP() Code {
+
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("SetRoutes").Params(Id("mux").Op("*").Qual(packageHttp, "ServeMux")).BlockFunc(func(bg *Group) {
+		if svc.tags.Contains(tagServerJsonRPC) {
+			bg.Id("mux").Dot("HandleFunc").Call(Lit("POST "+svc.batchPath()), Id("http").Dot("serveBatch"))
+			for _, method := range svc.methods {
+				if !method.isJsonRPC() {
+					continue
+				}
+				for _, version := range method.routeVersions() {
+					bg.Id("mux").Dot("HandleFunc").Call(Lit("POST "+method.jsonrpcPathVersion(version)), method.routeHandler(version, Id("http").Dot("serve"+method.Name)))
+				}
+			}
+		}
+		if svc.tags.Contains(tagServerHTTP) {
+			for _, method := range svc.methods {
+				if !method.isHTTP() {
+					continue
+				}
+				handler := Id("http").Dot("serve" + method.Name)
+				if method.tags.Contains(tagHandler) {
+					handler = Func().Params(svc.tr.handlerParams()).Block(
+						Qual(method.handlerQual()).Call(Id("w"), Id("r"), Id("http").Dot("base")),
+					)
+				}
+				for _, version := range method.routeVersions() {
+					for _, verb := range method.routeMethods() {
+						pattern := verb + " " + method.httpPathSwaggerVersion(version)
+						bg.Id("mux").Dot("HandleFunc").Call(Lit(pattern), method.routeHandler(version, handler))
+					}
+				}
+			}
+		}
+	})
+}
+
+func (svc *service) httpServeMethodFuncNetHTTP(method *method) Code {
+
+	badRequest := func(msg string) func(arg, header string) *Statement {
+		return func(arg, header string) *Statement {
+			return Line().If(Err().Op("!=").Nil()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusBadRequest"), Lit(msg).Op("+").Err().Dot("Error").Call()),
+				Return(),
+			)
+		}
+	}
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(svc.tr.handlerParams()).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		bg.Var().Err().Error()
+		bg.Var().Id("request").Id(method.requestStructName())
+		if len(method.arguments()) != 0 {
+			bg.If(Err().Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "NewDecoder").Call(Id("r").Dot("Body")).Dot("Decode").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil().Op("&&").Err().Op("!=").Qual(packageIO, "EOF")).Block(
+				Qual(packageHttp, "Error").Call(Id("w"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Qual(packageHttp, "StatusBadRequest")),
+				Return(),
+			)
+		}
+		bg.Add(method.urlArgs(badRequest("path arguments could not be decoded: ")))
+		bg.Add(method.urlParams(badRequest("url arguments could not be decoded: ")))
+		bg.Add(method.httpArgHeaders(badRequest("http header could not be decoded: ")))
+		bg.Add(method.httpCookies(badRequest("http header could not be decoded: ")))
+		if responseMethod := method.tags.Value(tagHttpResponse, ""); responseMethod != "" {
+			bg.If(Err().Op("=").Add(toID(responseMethod).Call(Id("w"), Id("r"), Id("http").Dot("svc"), callParamNames("request", method.argsWithoutContext()))).Op(";").Err().Op("==").Nil()).Block(
+				Return(),
+			)
+		} else {
+			bg.Var().Id("response").Id(method.responseStructName())
+			bg.If().List(Id("response"), Err()).Op("=").Id("http").Dot(method.lccName()).Call(Id("r").Dot("Context").Call(), Id("request")).Op(";").Err().Op("==").Nil().BlockFunc(func(bf *Group) {
+				for retName := range method.retCookieMap() {
+					if ret := method.resultByName(retName); ret != nil {
+						bf.If(List(Id("rCookie"), Id("ok")).Op(":=").
+							Qual(packageReflect, "ValueOf").Call(Id("response").Dot(utils.ToCamel(retName))).Dot("Interface").Call().
+							Op(".").Call(Id("cookieType"))).Op(";").Id("ok").Op("&&").Id("response").Dot(utils.ToCamel(retName)).Op("!=").Nil().Block(
+							svc.tr.setCookie(Id("rCookie").Dot("Cookie").Call()),
+						)
+					}
+				}
+				bf.Add(method.httpRetHeaders())
+				bf.Var().Id("iResponse").Interface().Op("=").Id("response")
+				bf.If(List(Id("redirect"), Id("ok")).Op(":=").Id("iResponse").Op(".").Call(Id("withRedirect")).Op(";").Id("ok")).Block(
+					Qual(packageHttp, "Redirect").Call(Id("w"), Id("r"), Id("redirect").Dot("RedirectTo").Call(), Qual(packageHttp, "StatusFound")),
+					Return(),
+				)
+				statusCode := Qual(packageHttp, "StatusOK")
+				if successCode := method.tags.ValueInt(tagHttpSuccess, 0); successCode != 0 {
+					statusCode = Lit(successCode)
+				}
+				if len(method.resultsWithoutError()) == 1 {
+					bf.Id("sendResponse").Call(Id("w"), Id("r"), statusCode, Id("response").Dot(utils.ToCamel(method.resultsWithoutError()[0].Name)))
+				} else {
+					bf.Id("sendResponse").Call(Id("w"), Id("r"), statusCode, Id("response"))
+				}
+				bf.Return()
+			})
+		}
+		bg.Id("statusCode").Op(":=").Qual(packageHttp, "StatusInternalServerError")
+		bg.If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
+			Id("statusCode").Op("=").Id("errCoder").Dot("Code").Call(),
+		)
+		bg.Id("sendResponse").Call(Id("w"), Id("r"), Id("statusCode"), Err())
+	})
+}
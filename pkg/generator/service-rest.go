@@ -24,12 +24,19 @@ func (svc *service) renderREST(outDir string) (err error) {
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
 	srcFile.ImportName(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
+	if svc.tr.isNetHTTP() {
+		srcFile.ImportAlias(packageHttp, "nethttp")
+	}
 
 	for _, method := range svc.methods {
 		if !method.isHTTP() {
 			continue
 		}
 		srcFile.Add(svc.httpMethodFunc(method))
+		if svc.tr.isNetHTTP() {
+			srcFile.Add(svc.httpServeMethodFuncNetHTTP(method))
+			continue
+		}
 		srcFile.Add(svc.httpServeMethodFunc(method))
 	}
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-rest.go"))
@@ -119,7 +126,7 @@ func (svc *service) httpServeMethodFunc(method *method) Code {
 							ex.If(List(Id("rCookie"), Id("ok")).Op(":=").
 								Qual(packageReflect, "ValueOf").Call(Id("response").Dot(utils.ToCamel(retName))).Dot("Interface").Call().
 								Op(".").Call(Id("cookieType"))).Op(";").Id("ok").Op("&&").Id("response").Dot(utils.ToCamel(retName)).Op("!=").Nil().Block(
-								Id(_ctx_).Dot("Cookie").Call(Id("rCookie").Dot("Cookie").Call()),
+								svc.tr.setCookie(Id("rCookie").Dot("Cookie").Call()),
 							)
 						}
 					}
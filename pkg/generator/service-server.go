@@ -54,8 +54,13 @@ func (svc *service) renderServer(outDir string) (err error) {
 		)
 	}
 	if svc.tags.Contains(tagMetrics) {
-		srcFile.Line().Func().Params(Id("srv").Op("*").Id("server" + svc.Name)).Id("WithMetrics").Params().Block(
-			Id("srv").Dot("Wrap").Call(Id("metricsMiddleware" + svc.Name)),
+		srcFile.Line().Func().Params(Id("srv").Op("*").Id("server" + svc.Name)).Id("WithMetrics").Params(Id("metrics").Op("*").Id("metricsConfig")).Block(
+			Id("srv").Dot("Wrap").Call(Id("metricsMiddleware" + svc.Name).Call(Id("metrics"))),
+		)
+	}
+	if svc.hasAudit() {
+		srcFile.Line().Func().Params(Id("srv").Op("*").Id("server" + svc.Name)).Id("WithAudit").Params(Id("audit").Op("*").Id("auditConfig")).Block(
+			Id("srv").Dot("Wrap").Call(Id("auditMiddleware" + svc.Name).Call(Id("audit"))),
 		)
 	}
 	if svc.tags.Contains(tagLogger) {
@@ -78,13 +83,19 @@ func (svc *service) wrapFunc() Code {
 
 func (svc *service) newServerFunc() Code {
 
-	return Func().Id("newServer" + svc.Name).Params(Id("svc").Qual(svc.pkgPath, svc.Name)).Params(Op("*").Id("server" + svc.Name)).Block(
-		Return(Op("&").Id("server" + svc.Name).Values(DictFunc(func(dict Dict) {
+	return Func().Id("newServer"+svc.Name).Params(Id("svc").Qual(svc.pkgPath, svc.Name)).Params(Id("srv").Op("*").Id("server"+svc.Name)).Block(
+		Line(),
+		Id("srv").Op("=").Op("&").Id("server"+svc.Name).Values(DictFunc(func(dict Dict) {
 			dict[Id("svc")] = Id("svc")
+			dict[Id("inflight")] = Op("&").Id("inflightCalls").Values(Dict{
+				Id("calls"): Make(Map(Uint64()).Id("InflightCall")),
+			})
 			for _, method := range svc.methods {
 				dict[Id(method.lccName())] = Id("svc").Dot(method.Name)
 			}
-		}))),
+		})),
+		Id("srv").Dot("Wrap").Call(Id("inflightMiddleware"+svc.Name).Call(Id("srv").Dot("inflight"))),
+		Return(),
 	)
 }
 
@@ -92,6 +103,7 @@ func (svc *service) serverType() Code {
 
 	return Type().Id("server" + svc.Name).StructFunc(func(sg *Group) {
 		sg.Id("svc").Qual(svc.pkgPath, svc.Name)
+		sg.Id("inflight").Op("*").Id("inflightCalls")
 		for _, method := range svc.methods {
 			sg.Id(method.lccName()).Id(svc.Name + method.Name)
 		}
@@ -111,7 +123,10 @@ func (svc *service) middlewareSetType() Code {
 			ig.Id("WithTrace").Params()
 		}
 		if svc.tags.IsSet(tagMetrics) {
-			ig.Id("WithMetrics").Params()
+			ig.Id("WithMetrics").Params(Id("metrics").Op("*").Id("metricsConfig"))
+		}
+		if svc.hasAudit() {
+			ig.Id("WithAudit").Params(Id("audit").Op("*").Id("auditConfig"))
 		}
 		ig.Id("WithLog").Params()
 	})
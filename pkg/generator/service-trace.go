@@ -5,8 +5,11 @@ package generator
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 
@@ -15,12 +18,16 @@ import (
 
 func (svc *service) renderTrace(outDir string) (err error) {
 
+	if err = pkgCopyTo("viewer", outDir); err != nil {
+		return err
+	}
 	srcFile := newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
 
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+	srcFile.ImportName(fmt.Sprintf("%s/viewer", svc.tr.pkgPath(outDir)), "viewer")
 
 	srcFile.Type().Id("trace" + svc.Name).Struct(
 		Id("next").Qual(svc.pkgPath, svc.Name),
@@ -33,17 +40,29 @@ func (svc *service) renderTrace(outDir string) (err error) {
 	)
 
 	for _, method := range svc.methods {
+		args, results := method.traceAttrs()
 		srcFile.Line().Func().Params(Id("svc").Id("trace"+svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
 
 			Line(),
 			Var().Id("span").Qual(packageTrace, "Span"),
 			List(Id(_ctx_), Id("span")).Op("=").
 				Qual(packageOTEL, "Tracer").
-				Call(Qual(packageFmt, "Sprintf").Call(Lit("tg:%s"), Id("VersionTg"))).Dot("Start").Call(Id(_ctx_), Lit(method.fullName())),
-			Defer().Func().Params().Block(
-				Id("span").Dot("RecordError").Call(Err()),
-				Id("span").Dot("End").Call(),
-			).Call(),
+				Call(Qual(packageFmt, "Sprintf").Call(Lit("tg:%s"), Id("VersionTg"))).Dot("Start").CallFunc(func(cg *Group) {
+				cg.Id(_ctx_)
+				cg.Lit(method.fullName())
+				if len(args) != 0 {
+					cg.Qual(packageTrace, "WithAttributes").CallFunc(svc.traceAttributes(outDir, method, "request", args))
+				}
+			}),
+			Defer().Func().Params().BlockFunc(func(bg *Group) {
+				if len(results) != 0 {
+					bg.If(Err().Op("==").Nil()).Block(
+						Id("span").Dot("SetAttributes").CallFunc(svc.traceAttributes(outDir, method, "response", results)),
+					)
+				}
+				bg.Id("span").Dot("RecordError").Call(Err())
+				bg.Id("span").Dot("End").Call()
+			}).Call(),
 			Return(Id("svc").Dot("next").Dot(method.Name).CallFunc(func(cg *Group) {
 				for _, arg := range method.Args {
 					argCode := Id(arg.Name)
@@ -57,3 +76,44 @@ func (svc *service) renderTrace(outDir string) (err error) {
 	}
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-trace.go"))
 }
+
+// traceAttributes returns attributes of variables, masked by their log-redact policy or policies of viewer.
+func (svc *service) traceAttributes(outDir string, method *method, prefix string, vars []types.Variable) func(g *Group) {
+
+	pkgViewer := fmt.Sprintf("%s/viewer", svc.tr.pkgPath(outDir))
+	return func(g *Group) {
+		for _, variable := range vars {
+			value := Qual(pkgViewer, "RedactValue").Call(Lit(variable.Name), Id(variable.Name))
+			if policy := method.tags.Sub(variable.Name).Value(tagLogRedact); policy != "" {
+				value = Qual(pkgViewer, "Redact").Call(Lit(policy), Qual(pkgViewer, "Sprintf").Call(Lit("%+v"), Id(variable.Name)))
+			}
+			g.Line().Qual(fmt.Sprintf("%s/tracer", svc.tr.pkgPath(outDir)), "Attribute").Call(Lit(prefix+"."+variable.Name), value)
+		}
+		g.Line()
+	}
+}
+
+// traceAttrs returns arguments and results of method listed by trace-attrs annotation.
+// Variables skipped by log-skip or hidden by dumper are not recorded.
+func (m *method) traceAttrs() (args, results []types.Variable) {
+
+	names := m.varNames(tagTraceAttrs)
+	skipped := m.varNames(tagLogSkip)
+	recorded := func(variable types.Variable) bool {
+		if !slices.Contains(names, variable.Name) || slices.Contains(skipped, variable.Name) {
+			return false
+		}
+		return !strings.Contains(m.tags.Sub(variable.Name).Value(tagTag), "dumper:hide")
+	}
+	for _, arg := range m.argsWithoutContext() {
+		if recorded(arg) {
+			args = append(args, arg)
+		}
+	}
+	for _, ret := range m.resultsWithoutError() {
+		if recorded(ret) {
+			results = append(results, ret)
+		}
+	}
+	return
+}
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTrace(t *testing.T) {
+
+	for _, backend := range []string{backendNetHTTP, backendFiber} {
+		t.Run(backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/trace", backend)
+			assertContains(t, files, "orders-trace.go",
+				`Start(ctx, "orders.archive", trace1.WithAttributes(`,
+				`tracer.Attribute("request.id", viewer.RedactValue("id", id)),`,
+			)
+			assertContains(t, files, "jsonrpc.go",
+				`func traceBatch(ctx context.Context, requests []baseJsonRPC) {`,
+				`span.AddEvent("jsonrpc.request", trace.WithAttributes(attribute.Int("rpc.jsonrpc.batch_position", idx), attribute.String("rpc.jsonrpc.request_id", string(request.ID)), attribute.String("rpc.method", request.Method)))`,
+			)
+			// root and service batches both emit events of their requests
+			for _, fileName := range []string{"jsonrpc.go", "orders-jsonrpc.go"} {
+				if calls := strings.Count(files[fileName], "\ttraceBatch("); calls != 1 {
+					t.Errorf("%s calls traceBatch %d times, want 1", fileName, calls)
+				}
+			}
+		})
+	}
+	t.Run("disabled", func(t *testing.T) {
+		files := renderServer(t, "testdata/versions", backendNetHTTP)
+		assertNotContains(t, files, "jsonrpc.go", "traceBatch")
+	})
+}
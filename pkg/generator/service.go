@@ -26,17 +26,21 @@ type service struct {
 	tr      *Transport
 	tags    tags.DocTags
 
+	versions           []string
+	deprecatedVersions map[string]string
+
 	testsPath string
 }
 
-func newService(log logrus.FieldLogger, tr *Transport, filePath string, iface types.Interface) (svc *service) {
+func newService(log logrus.FieldLogger, tr *Transport, filePath string, iface types.Interface, pkgTags tags.DocTags) (svc *service) {
 
 	svc = &service{
 		tr:        tr,
 		log:       log,
 		Interface: iface,
-		tags:      tags.ParseTags(iface.Docs).Merge(tr.tags),
+		tags:      tags.ParseTags(iface.Docs).Merge(pkgTags),
 	}
+	svc.parseVersions(tags.ParseTags(iface.Docs))
 	for _, method := range iface.Methods {
 		svc.methods = append(svc.methods, newMethod(log, svc, method))
 	}
@@ -51,6 +55,10 @@ func (svc *service) isJsonRPC() bool {
 	return svc.tags.IsSet(tagServerJsonRPC)
 }
 
+func (svc *service) isHTTP() bool {
+	return svc.tags.IsSet(tagServerHTTP)
+}
+
 func (svc *service) lcName() string {
 	return strings.ToLower(svc.Name)
 }
@@ -80,6 +88,7 @@ func (svc *service) render(outDir string) (err error) {
 	showError(svc.log, svc.renderServer(outDir), "renderServer")
 	showError(svc.log, svc.renderExchange(outDir), "renderExchange")
 	showError(svc.log, svc.renderMiddleware(outDir), "renderMiddleware")
+	showError(svc.log, svc.renderInflight(outDir), "renderInflight")
 	if svc.tags.Contains(tagTests) {
 		showError(svc.log, svc.renderTest(svc.testsPath), "renderTest")
 	}
@@ -92,6 +101,9 @@ func (svc *service) render(outDir string) (err error) {
 	if svc.tags.Contains(tagLogger) {
 		showError(svc.log, svc.renderLogger(outDir), "renderLogger")
 	}
+	if svc.hasAudit() {
+		showError(svc.log, svc.renderAudit(outDir), "renderAudit")
+	}
 	if svc.tags.Contains(tagServerJsonRPC) {
 		showError(svc.log, svc.renderJsonRPC(outDir), "renderJsonRPC")
 	}
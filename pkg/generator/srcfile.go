@@ -4,7 +4,9 @@
 package generator
 
 import (
+	"bytes"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/dave/jennifer/jen"
 )
@@ -23,21 +25,25 @@ func newSrc(pkgName string) goFile {
 func (src *goFile) Save(filepath string) (err error) {
 
 	src.filepath = filepath
-	if err = src.File.Save(src.filepath); err != nil {
+	var buf bytes.Buffer
+	if err = src.File.Render(&buf); err != nil {
 		return
 	}
-
-	if err = src.goImports(); err != nil {
-		return
-	}
-	return
+	return generated.WriteFile(src.filepath, src.goImports(buf.Bytes()), 0644)
 }
 
-func (src *goFile) goImports() (err error) {
+// goImports returns source formatted by goimports, or source as is when goimports is not installed.
+func (src *goFile) goImports(source []byte) []byte {
 
-	var execPath string
-	if execPath, err = exec.LookPath("goimports"); err != nil {
-		return nil
+	execPath, err := exec.LookPath("goimports")
+	if err != nil {
+		return source
+	}
+	cmd := exec.Command(execPath, "-srcdir", filepath.Dir(src.filepath))
+	cmd.Stdin = bytes.NewReader(source)
+	formatted, err := cmd.Output()
+	if err != nil {
+		return source
 	}
-	return exec.Command(execPath, "-local", "-w", src.filepath).Run()
+	return formatted
 }
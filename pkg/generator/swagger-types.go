@@ -23,6 +23,11 @@ type swPath struct {
 	Patch       *swOperation `json:"patch,omitempty" yaml:"patch,omitempty"`
 	Put         *swOperation `json:"put,omitempty" yaml:"put,omitempty"`
 	Delete      *swOperation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Head        *swOperation `json:"head,omitempty" yaml:"head,omitempty"`
+	Options     *swOperation `json:"options,omitempty" yaml:"options,omitempty"`
+	Trace       *swOperation `json:"trace,omitempty" yaml:"trace,omitempty"`
+	// Custom verbs are not supported by OpenAPI 3.0, so they are documented by extension.
+	AdditionalOperations map[string]*swOperation `json:"x-additionalOperations,omitempty" yaml:"x-additionalOperations,omitempty"`
 }
 
 type swOperation struct {
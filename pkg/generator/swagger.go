@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"reflect"
 	"slices"
 	"strconv"
 	"strings"
@@ -17,7 +16,6 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/seniorGolang/tg/v2/pkg/tags"
-	"github.com/seniorGolang/tg/v2/pkg/utils"
 )
 
 const (
@@ -109,6 +107,9 @@ services:
 		serviceTags := strings.Split(service.tags.Value(tagSwaggerTags, service.Name), ",")
 		doc.log.WithField("module", "swagger").Infof("service %s append jsonRPC methods", serviceTags)
 		for _, method := range service.methods {
+			if !method.isActual() {
+				continue
+			}
 			if method.tags.Contains(tagSwaggerTags) {
 				serviceTags = strings.Split(method.tags.Value(tagSwaggerTags), ",")
 			}
@@ -190,7 +191,7 @@ services:
 					Description: method.tags.Value(tagDesc),
 					Parameters:  parameters,
 					Tags:        serviceTags,
-					Deprecated:  method.tags.Contains(tagDeprecated),
+					Deprecated:  method.isDeprecated(),
 					RequestBody: &swRequestBody{
 						Content: swContent{
 							contentJSON: swMedia{Schema: jsonrpcSchema("params", swSchema{Ref: "#/components/schemas/" + method.requestStructName()})},
@@ -226,7 +227,7 @@ services:
 					Description: method.tags.Value(tagDesc),
 					Parameters:  parameters,
 					Tags:        serviceTags,
-					Deprecated:  method.tags.Contains(tagDeprecated),
+					Deprecated:  method.isDeprecated(),
 					RequestBody: &swRequestBody{
 						Content: doc.clearContent(swContent{}),
 					},
@@ -253,7 +254,9 @@ services:
 				if httpMethod.RequestBody.Content == nil {
 					httpMethod.RequestBody = nil
 				}
-				reflect.ValueOf(&httpValue).Elem().FieldByName(utils.ToCamel(strings.ToLower(method.httpMethod()))).Set(reflect.ValueOf(httpMethod))
+				for _, verb := range method.httpMethods() {
+					httpValue.setOperation(verb, httpMethod)
+				}
 				swaggerDoc.Paths[method.httpPathSwagger()] = httpValue
 			}
 		}
@@ -270,7 +273,7 @@ services:
 		}
 	}
 	doc.log.Info("write to ", outFilePath)
-	return os.WriteFile(outFilePath, docData, 0600)
+	return generated.WriteFile(outFilePath, docData, 0600)
 }
 
 func (doc *swagger) fillErrors(responses swResponses, tags tags.DocTags) {
@@ -329,3 +332,42 @@ func (doc *swagger) clearContent(content swContent) swContent {
 	}
 	return content
 }
+
+func (p *swPath) setOperation(verb string, operation *swOperation) {
+
+	switch verb {
+	case fasthttp.MethodGet:
+		p.Get = operation
+	case fasthttp.MethodPost:
+		p.Post = operation
+	case fasthttp.MethodPatch:
+		p.Patch = operation
+	case fasthttp.MethodPut:
+		p.Put = operation
+	case fasthttp.MethodDelete:
+		p.Delete = operation
+	case fasthttp.MethodHead:
+		p.Head = operation.withoutContent()
+	case fasthttp.MethodOptions:
+		p.Options = operation
+	case fasthttp.MethodTrace:
+		p.Trace = operation
+	default:
+		if p.AdditionalOperations == nil {
+			p.AdditionalOperations = make(map[string]*swOperation)
+		}
+		p.AdditionalOperations[verb] = operation
+	}
+}
+
+// withoutContent returns copy of operation with responses without body, as HEAD responses have no content.
+func (op *swOperation) withoutContent() *swOperation {
+
+	head := *op
+	head.Responses = make(swResponses, len(op.Responses))
+	for code, response := range op.Responses {
+		response.Content = nil
+		head.Responses[code] = response
+	}
+	return &head
+}
@@ -0,0 +1,154 @@
+import Foundation
+
+/// Error of JSON-RPC call, returned by server.
+public struct RPCError: Error, Decodable, CustomStringConvertible {
+    public let code: Int
+    public let message: String
+    public let data: JSONValue?
+
+    public var description: String { "\(code): \(message)" }
+}
+
+/// HTTP request failed with status code.
+public struct HTTPError: Error, CustomStringConvertible {
+    public let code: Int
+    public let body: Data
+
+    public var description: String { "\(code): \(HTTPURLResponse.localizedString(forStatusCode: code))" }
+}
+
+/// Arbitrary JSON value.
+public enum JSONValue: Codable, Equatable {
+    case null
+    case bool(Bool)
+    case number(Double)
+    case string(String)
+    case array([JSONValue])
+    case object([String: JSONValue])
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.singleValueContainer()
+        if container.decodeNil() {
+            self = .null
+        } else if let value = try? container.decode(Bool.self) {
+            self = .bool(value)
+        } else if let value = try? container.decode(Double.self) {
+            self = .number(value)
+        } else if let value = try? container.decode(String.self) {
+            self = .string(value)
+        } else if let value = try? container.decode([JSONValue].self) {
+            self = .array(value)
+        } else {
+            self = .object(try container.decode([String: JSONValue].self))
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.singleValueContainer()
+        switch self {
+        case .null: try container.encodeNil()
+        case .bool(let value): try container.encode(value)
+        case .number(let value): try container.encode(value)
+        case .string(let value): try container.encode(value)
+        case .array(let value): try container.encode(value)
+        case .object(let value): try container.encode(value)
+        }
+    }
+}
+
+/// Transport sends JSON-RPC request and returns response body.
+public protocol Transport {
+    func send(_ body: Data) async throws -> Data
+}
+
+/// Transport by HTTP POST to endpoint.
+public struct HTTPTransport: Transport {
+    public let endpoint: URL
+    public var headers: () -> [String: String]
+    public var session: URLSession
+
+    public init(endpoint: URL, headers: @escaping () -> [String: String] = { [:] }, session: URLSession = .shared) {
+        self.endpoint = endpoint
+        self.headers = headers
+        self.session = session
+    }
+
+    public func send(_ body: Data) async throws -> Data {
+        var request = URLRequest(url: endpoint)
+        request.httpMethod = "POST"
+        request.setValue("application/json", forHTTPHeaderField: "Accept")
+        request.setValue("application/json", forHTTPHeaderField: "Content-Type")
+        for (key, value) in headers() {
+            request.setValue(value, forHTTPHeaderField: key)
+        }
+        request.httpBody = body
+        let (data, response) = try await session.data(for: request)
+        if let response = response as? HTTPURLResponse, !(200..<300).contains(response.statusCode) {
+            throw HTTPError(code: response.statusCode, body: data)
+        }
+        return data
+    }
+}
+
+struct RequestRPC<Params: Encodable>: Encodable {
+    let id: String
+    let method: String
+    let params: Params
+    let jsonrpc = "2.0"
+}
+
+/// Params and result of methods without arguments or results.
+struct EmptyRPC: Codable {}
+
+struct ResponseRPC<Result: Decodable>: Decodable {
+    let id: String?
+    let error: RPCError?
+    let result: Result?
+}
+
+/// Client of JSON-RPC 2.0 protocol.
+public final class JSONRPCClient {
+    let transport: Transport
+    let encoder: JSONEncoder
+    let decoder: JSONDecoder
+
+    public init(transport: Transport) {
+        self.transport = transport
+        encoder = JSONEncoder()
+        encoder.dateEncodingStrategy = .custom { date, encoder in
+            var container = encoder.singleValueContainer()
+            try container.encode(JSONRPCClient.fractional.string(from: date))
+        }
+        decoder = JSONDecoder()
+        decoder.dateDecodingStrategy = .custom { decoder in
+            let container = try decoder.singleValueContainer()
+            // formatter parses milliseconds, Go encodes nanoseconds and omits zero fraction
+            let value = try container.decode(String.self)
+                .replacingOccurrences(of: #"(\.\d{3})\d+"#, with: "$1", options: .regularExpression)
+            guard let date = JSONRPCClient.fractional.date(from: value) ?? JSONRPCClient.plain.date(from: value) else {
+                throw DecodingError.dataCorruptedError(in: container, debugDescription: "invalid date \(value)")
+            }
+            return date
+        }
+    }
+
+    public func call<Params: Encodable, Result: Decodable>(_ method: String, params: Params, as type: Result.Type) async throws -> Result {
+        let body = try encoder.encode(RequestRPC(id: UUID().uuidString, method: method, params: params))
+        let response = try decoder.decode(ResponseRPC<Result>.self, from: try await transport.send(body))
+        if let error = response.error {
+            throw error
+        }
+        guard let result = response.result else {
+            return try decoder.decode(Result.self, from: Data("{}".utf8))
+        }
+        return result
+    }
+
+    static let fractional: ISO8601DateFormatter = {
+        let formatter = ISO8601DateFormatter()
+        formatter.formatOptions = [.withInternetDateTime, .withFractionalSeconds]
+        return formatter
+    }()
+
+    static let plain = ISO8601DateFormatter()
+}
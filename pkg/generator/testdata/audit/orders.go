@@ -0,0 +1,14 @@
+package audit
+
+import "context"
+
+// @tg jsonRPC-server log
+type Orders interface {
+	// @tg audit audit-args=id,token
+	// @tg token.log-redact=jwt
+	Cancel(ctx context.Context, id int, token string, reason string) (err error)
+	// @tg audit log-skip=password
+	// @tg login.tags=dumper:hide,md
+	Login(ctx context.Context, login string, password string) (err error)
+	Get(ctx context.Context, id int) (status string, err error)
+}
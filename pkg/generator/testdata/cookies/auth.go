@@ -0,0 +1,14 @@
+package cookies
+
+import (
+	"context"
+
+	"github.com/seniorGolang/tg/v2/pkg/generator/testdata/cookies/types"
+)
+
+// @tg http-server
+type Auth interface {
+	// @tg http-method=POST
+	// @tg http-cookies=session|session,token|token,plain|plain,value|value
+	Login(ctx context.Context, login string) (session *types.Session, token types.Token, plain string, value types.Session, err error)
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Session struct {
+	Value string
+}
+
+func (s *Session) Cookie() *http.Cookie {
+	return &http.Cookie{Name: "session", Value: s.Value}
+}
+
+type Token string
+
+func (t Token) Cookie() *fiber.Cookie {
+	return &fiber.Cookie{Name: "token", Value: string(t)}
+}
@@ -0,0 +1,3 @@
+// @tg log
+// @tg http-prefix=api
+package discovery
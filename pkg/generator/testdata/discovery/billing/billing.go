@@ -0,0 +1,11 @@
+// @tg http-prefix=billing
+package billing
+
+import "context"
+
+// @tg http-server
+type Invoices interface {
+	// @tg http-method=GET
+	// @tg http-path=/invoices/:id
+	Get(ctx context.Context, id int) (sum int, err error)
+}
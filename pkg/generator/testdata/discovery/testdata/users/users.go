@@ -0,0 +1,5 @@
+package users
+
+// @tg jsonRPC-server
+type Users interface {
+}
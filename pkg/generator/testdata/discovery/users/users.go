@@ -0,0 +1,8 @@
+package users
+
+import "context"
+
+// @tg jsonRPC-server
+type Users interface {
+	Get(ctx context.Context, id int) (name string, err error)
+}
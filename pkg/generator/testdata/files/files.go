@@ -0,0 +1,17 @@
+package files
+
+import "context"
+
+// @tg http-server
+// @tg http-prefix=api
+type Files interface {
+	// @tg http-method=GET
+	// @tg http-path=/files/:id
+	// @tg http-headers=token|X-Token
+	// @tg http-args=limit|limit
+	// @tg http-success=200
+	Get(ctx context.Context, id int, token string, limit int) (name string, err error)
+	// @tg http-method=POST|PUT
+	// @tg http-success=201
+	Upload(ctx context.Context, name string, data []byte) (id int, err error)
+}
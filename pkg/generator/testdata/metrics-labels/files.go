@@ -0,0 +1,14 @@
+// @tg metrics
+package labels
+
+import "context"
+
+type Filter struct {
+	Name string
+}
+
+// @tg jsonRPC-server
+type Files interface {
+	// @tg metrics-labels=filter
+	Find(ctx context.Context, filter Filter) (names []string, err error)
+}
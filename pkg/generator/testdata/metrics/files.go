@@ -0,0 +1,16 @@
+// @tg metrics
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/seniorGolang/tg/v2/pkg/generator/testdata/metrics/types"
+)
+
+// @tg jsonRPC-server http-server
+type Files interface {
+	// @tg http-method=GET
+	// @tg metrics-labels=status,limit,timeout
+	Find(ctx context.Context, status types.Status, limit int, timeout time.Duration) (names []string, err error)
+}
@@ -0,0 +1,17 @@
+package types
+
+// @tg log-redact=card
+type Card string
+
+// @tg log-redact=drop
+type Password struct {
+	Value string
+}
+
+type User struct {
+	// @tg log-redact=email
+	Email    string
+	Card     Card
+	Password *Password
+	Friends  []User
+}
@@ -0,0 +1,13 @@
+package redact
+
+import (
+	"context"
+
+	"github.com/seniorGolang/tg/v2/pkg/generator/testdata/redact/types"
+)
+
+// @tg jsonRPC-server log
+type Users interface {
+	// @tg token.log-redact=jwt
+	Create(ctx context.Context, user types.User, token string) (id int, err error)
+}
@@ -0,0 +1,17 @@
+// @tg trace
+package trace
+
+import "context"
+
+// @tg jsonRPC-server http-server
+// @tg trace-sample=0.5
+type Orders interface {
+	// @tg http-method=GET
+	// @tg http-path=/orders/:id
+	Get(ctx context.Context, id int) (status string, err error)
+	// @tg trace-sample=2
+	Cancel(ctx context.Context, id int) (err error)
+	// @tg trace-sample=1
+	// @tg trace-attrs=id
+	Archive(ctx context.Context, id int) (err error)
+}
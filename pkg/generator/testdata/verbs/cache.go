@@ -0,0 +1,15 @@
+package verbs
+
+import "context"
+
+// @tg http-server
+type Cache interface {
+	// @tg http-method=GET|HEAD
+	// @tg http-path=/cache/:key
+	Get(ctx context.Context, key string) (value string, err error)
+	// @tg http-method=TRACE
+	Trace(ctx context.Context) (err error)
+	// @tg http-method=purge
+	// @tg http-path=/cache/:key
+	Purge(ctx context.Context, key string) (err error)
+}
@@ -0,0 +1,16 @@
+package versions
+
+import "context"
+
+// @tg jsonRPC-server http-server
+// @tg http-prefix=api
+// @tg version=v1,v2
+// @tg deprecated-versions=v1|2025-01-31
+type Orders interface {
+	// @tg http-method=GET
+	List(ctx context.Context, limit int) (orders []string, err error)
+	// @tg until=v2
+	Cancel(ctx context.Context, id int) (err error)
+	// @tg since=v2
+	Archive(ctx context.Context, id int) (err error)
+}
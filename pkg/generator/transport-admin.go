@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) renderAdmin(outDir string) (err error) {
+
+	if err = pkgCopyTo("profile", outDir); err != nil {
+		return err
+	}
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(fmt.Sprintf("%s/profile", tr.pkgPath(outDir)), "profile")
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportName(packageZeroLogLog, "log")
+	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
+
+	srcFile.Line().Comment("AdminService is service, served by server, with its methods.")
+	srcFile.Type().Id("AdminService").Struct(
+		Id("Name").String().Tag(map[string]string{"json": "name"}),
+		Id("Methods").Index().Id("AdminMethod").Tag(map[string]string{"json": "methods"}),
+	)
+	srcFile.Line().Type().Id("AdminMethod").Struct(
+		Id("Name").String().Tag(map[string]string{"json": "name"}),
+		Id("Routes").Index().String().Tag(map[string]string{"json": "routes"}),
+		Id("Annotations").Map(String()).String().Tag(map[string]string{"json": "annotations,omitempty"}),
+	)
+	srcFile.Line().Comment("AdminBuild is version of tg and build info of binary.")
+	srcFile.Type().Id("AdminBuild").Struct(
+		Id("VersionTg").String().Tag(map[string]string{"json": "versionTg"}),
+		Id("GoVersion").String().Tag(map[string]string{"json": "goVersion,omitempty"}),
+		Id("Path").String().Tag(map[string]string{"json": "path,omitempty"}),
+		Id("Version").String().Tag(map[string]string{"json": "version,omitempty"}),
+		Id("Settings").Map(String()).String().Tag(map[string]string{"json": "settings,omitempty"}),
+	)
+
+	srcFile.Line().Comment("AdminOption configures administrative server.")
+	srcFile.Type().Id("AdminOption").Func().Params(Id("cfg").Op("*").Id("adminConfig"))
+	srcFile.Line().Type().Id("adminConfig").Struct(
+		Id("readOnly").Bool(),
+		Id("middlewares").Index().Func().Params(Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler")),
+	)
+	srcFile.Line().Comment("AdminReadOnly forbids to change log level by administrative server.")
+	srcFile.Func().Id("AdminReadOnly").Params().Params(Id("AdminOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("adminConfig")).Block(
+			Id("cfg").Dot("readOnly").Op("=").True(),
+		)),
+	)
+	srcFile.Line().Comment("AdminMiddleware wraps all handlers of administrative server, e.g. to check authorization. The first middleware is the outermost.")
+	srcFile.Func().Id("AdminMiddleware").Params(Id("middleware").Func().Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler"))).Params(Id("AdminOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("adminConfig")).Block(
+			Id("cfg").Dot("middlewares").Op("=").Append(Id("cfg").Dot("middlewares"), Id("middleware")),
+		)),
+	)
+
+	srcFile.Line().Add(tr.adminServicesFunc())
+	srcFile.Line().Func().Id("adminBuild").Params().Params(Id("build").Id("AdminBuild")).Block(
+		Line(),
+		Id("build").Dot("VersionTg").Op("=").Id("VersionTg"),
+		List(Id("info"), Id("ok")).Op(":=").Qual("runtime/debug", "ReadBuildInfo").Call(),
+		If(Op("!").Id("ok")).Block(
+			Return(),
+		),
+		Id("build").Dot("GoVersion").Op("=").Id("info").Dot("GoVersion"),
+		Id("build").Dot("Path").Op("=").Id("info").Dot("Path"),
+		Id("build").Dot("Version").Op("=").Id("info").Dot("Main").Dot("Version"),
+		Id("build").Dot("Settings").Op("=").Make(Map(String()).String(), Len(Id("info").Dot("Settings"))),
+		For(List(Id("_"), Id("setting")).Op(":=").Range().Id("info").Dot("Settings")).Block(
+			Id("build").Dot("Settings").Index(Id("setting").Dot("Key")).Op("=").Id("setting").Dot("Value"),
+		),
+		Return(),
+	)
+	srcFile.Line().Add(tr.adminHandlerFunc(outDir))
+	srcFile.Line().Func().Id("adminResponse").Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request"), Id("statusCode").Int(), Id("resp").Interface()).Block(
+		Id("w").Dot("Header").Call().Dot("Set").Call(Lit("Content-Type"), Lit("application/json")),
+		Id("w").Dot("WriteHeader").Call(Id("statusCode")),
+		If(Err().Op(":=").Qual(tr.tags.Value(tagPackageJSON, packageStdJSON), "NewEncoder").Call(Id("w")).Dot("Encode").Call(Id("resp")).Op(";").Err().Op("!=").Nil()).Block(
+			Qual(packageZeroLogLog, "Ctx").Call(Id("r").Dot("Context").Call()).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("response write error")),
+		),
+	)
+	return srcFile.Save(path.Join(outDir, "admin.go"))
+}
+
+func (tr *Transport) adminServicesFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("adminServices").Params().Params(Id("services").Index().Id("AdminService")).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		for _, serviceName := range tr.serviceKeys() {
+			svc := tr.services[serviceName]
+			bg.If(Id("srv").Dot("http" + serviceName).Op("!=").Nil()).Block(
+				Id("services").Op("=").Append(Id("services"), Id("AdminService").Values(Dict{
+					Id("Name"): Lit(svc.lccName()),
+					Id("Methods"): Index().Id("AdminMethod").ValuesFunc(func(mg *Group) {
+						for _, method := range svc.methods {
+							mg.Line().Values(DictFunc(func(d Dict) {
+								d[Id("Name")] = Lit(method.fullName())
+								d[Id("Routes")] = Index().String().ValuesFunc(func(rg *Group) {
+									for _, route := range method.routes() {
+										rg.Lit(route.method + " " + route.path)
+									}
+								})
+								if len(method.tags) != 0 {
+									keys := make([]string, 0, len(method.tags))
+									for key := range method.tags {
+										keys = append(keys, key)
+									}
+									sort.Strings(keys)
+									d[Id("Annotations")] = Map(String()).String().Values(DictFunc(func(ad Dict) {
+										for _, key := range keys {
+											ad[Lit(key)] = Lit(method.tags[key])
+										}
+									}))
+								}
+							}))
+						}
+						mg.Line()
+					}),
+				})),
+			)
+		}
+		bg.Return()
+	})
+}
+
+func (tr *Transport) adminHandlerFunc(outDir string) Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("adminHandler").Params(Id("cfg").Id("adminConfig")).Params(Qual(packageHttp, "Handler")).Block(
+		Line(),
+		Id("mux").Op(":=").Qual(packageHttp, "NewServeMux").Call(),
+		Id("mux").Dot("HandleFunc").Call(Lit("/services"), Func().Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request")).Block(
+			Id("adminResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("srv").Dot("adminServices").Call()),
+		)),
+		Id("mux").Dot("HandleFunc").Call(Lit("/version"), Func().Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request")).Block(
+			Id("adminResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("adminBuild").Call()),
+		)),
+		Id("mux").Dot("HandleFunc").Call(Lit("/inflight"), Func().Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request")).Block(
+			Id("adminResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("srv").Dot("inflight").Call()),
+		)),
+		Id("mux").Dot("HandleFunc").Call(Lit("/log-level"), Func().Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request")).Block(
+			If(Id("r").Dot("Method").Op("==").Qual(packageHttp, "MethodPut").Op("||").Id("r").Dot("Method").Op("==").Qual(packageHttp, "MethodPost")).Block(
+				If(Id("cfg").Dot("readOnly")).Block(
+					Id("adminResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusMethodNotAllowed"), Map(String()).String().Values(Dict{Lit("error"): Lit("log level is read-only")})),
+					Return(),
+				),
+				List(Id("level"), Err()).Op(":=").Qual(packageZeroLog, "ParseLevel").Call(Id("r").Dot("URL").Dot("Query").Call().Dot("Get").Call(Lit("level"))),
+				If(Err().Op("!=").Nil()).Block(
+					Id("adminResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusBadRequest"), Map(String()).String().Values(Dict{Lit("error"): Err().Dot("Error").Call()})),
+					Return(),
+				),
+				Qual(packageZeroLog, "SetGlobalLevel").Call(Id("level")),
+			),
+			Id("adminResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Map(String()).String().Values(Dict{Lit("level"): Qual(packageZeroLog, "GlobalLevel").Call().Dot("String").Call()})),
+		)),
+		Id("mux").Dot("Handle").Call(Qual(fmt.Sprintf("%s/profile", tr.pkgPath(outDir)), "Prefix"), Qual(fmt.Sprintf("%s/profile", tr.pkgPath(outDir)), "Handler").Call()),
+		Var().Id("handler").Qual(packageHttp, "Handler").Op("=").Id("mux"),
+		For(Id("i").Op(":=").Len(Id("cfg").Dot("middlewares")).Op("-").Lit(1).Op(";").Id("i").Op(">=").Lit(0).Op(";").Id("i").Op("--")).Block(
+			Id("handler").Op("=").Id("cfg").Dot("middlewares").Index(Id("i")).Call(Id("handler")),
+		),
+		Return(Id("handler")),
+	)
+}
+
+func (tr *Transport) serveAdminFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeAdmin").Params(Id("address").String(), Id("options").Op("...").Id("AdminOption")).Block(
+		Var().Id("cfg").Id("adminConfig"),
+		For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+			Id("option").Call(Op("&").Id("cfg")),
+		),
+		Id("srv").Dot("srvAdmin").Op("=").Op("&").Qual(packageHttp, "Server").Values(Dict{
+			Id("Addr"):    Id("address"),
+			Id("Handler"): Id("srv").Dot("adminHandler").Call(Id("cfg")),
+		}),
+		Go().Func().Params().Block(
+			If(Err().Op(":=").Id("srv").Dot("srvAdmin").Dot("ListenAndServe").Call().Op(";").Err().Op("!=").Qual(packageHttp, "ErrServerClosed")).Block(
+				Id("ExitOnError").Call(Id("srv").Dot("log"), Err(), Lit("serve admin on ").Op("+").Id("address")),
+			),
+		).Call(),
+	)
+}
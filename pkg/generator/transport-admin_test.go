@@ -0,0 +1,28 @@
+package generator
+
+import "testing"
+
+func TestRenderAdmin(t *testing.T) {
+
+	for _, backend := range []string{backendNetHTTP, backendFiber} {
+		t.Run(backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/files", backend)
+			assertContains(t, files, "admin.go",
+				`Name:   "files.get",`,
+				`Routes: []string{"GET /api/files/{id}"},`,
+				`Routes: []string{"POST /api/files/upload", "PUT /api/files/upload"},`,
+				`"http-success": "201",`,
+				`mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {`,
+				`mux.HandleFunc("/inflight", func(w http.ResponseWriter, r *http.Request) {`,
+				`adminResponse(w, r, http.StatusMethodNotAllowed, map[string]string{"error": "log level is read-only"})`,
+				`mux.Handle(profile.Prefix, profile.Handler())`,
+				`handler = cfg.middlewares[i](handler)`,
+			)
+			assertNotContains(t, files, "admin.go", `net/http/pprof`)
+			assertContains(t, files, "server.go", `func (srv *Server) ServeAdmin(address string, options ...AdminOption) {`)
+			if _, found := files["profile/profile_test.go"]; found {
+				t.Error("tests of profile are copied to transport")
+			}
+		})
+	}
+}
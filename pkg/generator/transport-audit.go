@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) renderAudit(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportName(packageZeroLogLog, "log")
+	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
+
+	srcFile.Line().Comment("AuditRecord is a record of call of audited method.")
+	srcFile.Type().Id("AuditRecord").Struct(
+		Id("Time").Qual(packageTime, "Time").Tag(map[string]string{"json": "time"}),
+		Id("Actor").String().Tag(map[string]string{"json": "actor,omitempty"}),
+		Id("Service").String().Tag(map[string]string{"json": "service"}),
+		Id("Method").String().Tag(map[string]string{"json": "method"}),
+		Id("Args").Map(String()).String().Tag(map[string]string{"json": "args,omitempty"}),
+		Id("Success").Bool().Tag(map[string]string{"json": "success"}),
+		Id("Error").String().Tag(map[string]string{"json": "error,omitempty"}),
+		Id("Latency").Qual(packageTime, "Duration").Tag(map[string]string{"json": "latency"}),
+		Id("RequestID").String().Tag(map[string]string{"json": "requestID,omitempty"}),
+	)
+
+	srcFile.Line().Comment("AuditSink stores audit records.")
+	srcFile.Type().Id("AuditSink").Interface(
+		Id("Audit").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("record").Id("AuditRecord")).Params(Error()),
+	)
+
+	tr.renderAuditLogger(srcFile)
+	tr.renderAuditFile(srcFile)
+	tr.renderAuditConfig(srcFile)
+
+	return srcFile.Save(path.Join(outDir, "audit.go"))
+}
+
+func (tr *Transport) renderAuditLogger(srcFile goFile) {
+
+	srcFile.Line().Comment("AuditLogger writes audit records to zerolog logger.")
+	srcFile.Type().Id("AuditLogger").Struct(
+		Id("log").Qual(packageZeroLog, "Logger"),
+	)
+	srcFile.Line().Func().Id("NewAuditLogger").Params(Id("log").Qual(packageZeroLog, "Logger")).Params(Op("*").Id("AuditLogger")).Block(
+		Return(Op("&").Id("AuditLogger").Values(Dict{Id("log"): Id("log")})),
+	)
+	srcFile.Line().Func().Params(Id("sink").Op("*").Id("AuditLogger")).Id("Audit").Params(Id("_").Qual(packageContext, "Context"), Id("record").Id("AuditRecord")).Params(Error()).Block(
+		Line(),
+		Id("args").Op(":=").Qual(packageZeroLog, "Dict").Call(),
+		For(List(Id("name"), Id("value")).Op(":=").Range().Id("record").Dot("Args")).Block(
+			Id("args").Dot("Str").Call(Id("name"), Id("value")),
+		),
+		Id("sink").Dot("log").Dot("Info").Call().
+			Dot("Time").Call(Lit("time"), Id("record").Dot("Time")).
+			Dot("Str").Call(Lit("actor"), Id("record").Dot("Actor")).
+			Dot("Str").Call(Lit("service"), Id("record").Dot("Service")).
+			Dot("Str").Call(Lit("method"), Id("record").Dot("Method")).
+			Dot("Dict").Call(Lit("args"), Id("args")).
+			Dot("Bool").Call(Lit("success"), Id("record").Dot("Success")).
+			Dot("Str").Call(Lit("error"), Id("record").Dot("Error")).
+			Dot("Dur").Call(Lit("latency"), Id("record").Dot("Latency")).
+			Dot("Str").Call(Lit("requestID"), Id("record").Dot("RequestID")).
+			Dot("Msg").Call(Lit("audit")),
+		Return(Nil()),
+	)
+}
+
+func (tr *Transport) renderAuditFile(srcFile goFile) {
+
+	srcFile.Line().Comment("AuditFile appends audit records to file as JSON lines.")
+	srcFile.Type().Id("AuditFile").Struct(
+		Id("lock").Qual(packageSync, "Mutex"),
+		Id("file").Op("*").Qual(packageOS, "File"),
+	)
+	srcFile.Line().Func().Id("NewAuditFile").Params(Id("filename").String()).Params(Id("sink").Op("*").Id("AuditFile"), Err().Error()).Block(
+		Line(),
+		Var().Id("file").Op("*").Qual(packageOS, "File"),
+		If(List(Id("file"), Err()).Op("=").Qual(packageOS, "OpenFile").Call(Id("filename"), Qual(packageOS, "O_APPEND").Op("|").Qual(packageOS, "O_CREATE").Op("|").Qual(packageOS, "O_WRONLY"), Lit(0600)).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Return(Op("&").Id("AuditFile").Values(Dict{Id("file"): Id("file")}), Nil()),
+	)
+	srcFile.Line().Func().Params(Id("sink").Op("*").Id("AuditFile")).Id("Audit").Params(Id("_").Qual(packageContext, "Context"), Id("record").Id("AuditRecord")).Params(Err().Error()).Block(
+		Line(),
+		Var().Id("line").Index().Byte(),
+		If(List(Id("line"), Err()).Op("=").Qual(tr.tags.Value(tagPackageJSON, packageStdJSON), "Marshal").Call(Id("record")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("sink").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("sink").Dot("lock").Dot("Unlock").Call(),
+		List(Id("_"), Err()).Op("=").Id("sink").Dot("file").Dot("Write").Call(Append(Id("line"), LitRune('\n'))),
+		Return(),
+	)
+	srcFile.Line().Func().Params(Id("sink").Op("*").Id("AuditFile")).Id("Close").Params().Params(Error()).Block(
+		Return(Id("sink").Dot("file").Dot("Close").Call()),
+	)
+}
+
+func (tr *Transport) renderAuditConfig(srcFile goFile) {
+
+	srcFile.Line().Type().Id("auditActorKey").Struct()
+	srcFile.Line().Type().Id("auditConfig").Struct(
+		Id("sink").Id("AuditSink"),
+		Id("actorHeader").String(),
+		Id("actorKey").Interface(),
+	)
+	srcFile.Line().Comment("withActor puts value of actor header to context of request.")
+	srcFile.Func().Params(Id("cfg").Op("*").Id("auditConfig")).Id("withActor").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("header").Func().Params(String()).String()).Params(Qual(packageContext, "Context")).Block(
+		Line(),
+		If(Id("cfg").Op("==").Nil().Op("||").Id("cfg").Dot("sink").Op("==").Nil().Op("||").Id("cfg").Dot("actorHeader").Op("==").Lit("")).Block(
+			Return(Id(_ctx_)),
+		),
+		Return(Qual(packageContext, "WithValue").Call(Id(_ctx_), Id("auditActorKey").Values(), Id("header").Call(Id("cfg").Dot("actorHeader")))),
+	)
+	srcFile.Line().Func().Params(Id("cfg").Op("*").Id("auditConfig")).Id("actor").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(String()).Block(
+		Line(),
+		If(List(Id("actor"), Id("ok")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("auditActorKey").Values()).Assert(String()).Op(";").Id("ok").Op("&&").Id("actor").Op("!=").Lit("")).Block(
+			Return(Id("actor")),
+		),
+		If(Id("cfg").Dot("actorKey").Op("!=").Nil()).Block(
+			If(Id("actor").Op(":=").Id(_ctx_).Dot("Value").Call(Id("cfg").Dot("actorKey")).Op(";").Id("actor").Op("!=").Nil()).Block(
+				Return(Id("headerValue").Call(Id("actor"))),
+			),
+		),
+		Return(Lit("")),
+	)
+	srcFile.Line().Func().Params(Id("cfg").Op("*").Id("auditConfig")).Id("write").
+		Params(Id(_ctx_).Qual(packageContext, "Context"), Id("begin").Qual(packageTime, "Time"), List(Id("service"), Id("method")).String(), Id("args").Map(String()).String(), Err().Error()).Block(
+		Line(),
+		Id("record").Op(":=").Id("AuditRecord").Values(Dict{
+			Id("Time"):      Id("begin"),
+			Id("Actor"):     Id("cfg").Dot("actor").Call(Id(_ctx_)),
+			Id("Service"):   Id("service"),
+			Id("Method"):    Id("method"),
+			Id("Args"):      Id("args"),
+			Id("Success"):   Err().Op("==").Nil(),
+			Id("Latency"):   Qual(packageTime, "Since").Call(Id("begin")),
+			Id("RequestID"): Id("RequestID").Call(Id(_ctx_)),
+		}),
+		If(Err().Op("!=").Nil()).Block(
+			Id("record").Dot("Error").Op("=").Err().Dot("Error").Call(),
+		),
+		If(Err().Op("=").Id("cfg").Dot("sink").Dot("Audit").Call(Id(_ctx_), Id("record")).Op(";").Err().Op("!=").Nil()).Block(
+			Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_)).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Str").Call(Lit("method"), Id("method")).Dot("Msg").Call(Lit("audit")),
+		),
+	)
+
+	srcFile.Line().Type().Id("AuditOption").Func().Params(Id("cfg").Op("*").Id("auditConfig"))
+
+	srcFile.Line().Comment("AuditActorHeader sets header of request, which value is actor of audit records.")
+	srcFile.Func().Id("AuditActorHeader").Params(Id("header").String()).Params(Id("AuditOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("auditConfig")).Block(
+			Id("cfg").Dot("actorHeader").Op("=").Id("header"),
+		)),
+	)
+	srcFile.Line().Comment("AuditActorKey sets key of context value, which is actor of audit records, when actor header is empty.")
+	srcFile.Func().Id("AuditActorKey").Params(Id("key").Interface()).Params(Id("AuditOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("auditConfig")).Block(
+			Id("cfg").Dot("actorKey").Op("=").Id("key"),
+		)),
+	)
+}
+
+func (tr *Transport) withAuditFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("WithAudit").Params(Id("sink").Id("AuditSink"), Id("options").Op("...").Id("AuditOption")).Params(Op("*").Id("Server")).BlockFunc(func(bg *Group) {
+
+		bg.Line().Id("srv").Dot("audit").Op("=").Op("&").Id("auditConfig").Values(Dict{Id("sink"): Id("sink")})
+		bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+			Id("option").Call(Id("srv").Dot("audit")),
+		)
+		for _, serviceName := range tr.serviceKeys() {
+			if tr.services[serviceName].hasAudit() {
+				bg.If(Id("srv").Dot("http" + serviceName).Op("!=").Nil()).Block(
+					Id("srv").Dot("http" + serviceName).Op("=").Id("srv").Dot(serviceName).Call().Dot("WithAudit").Call(Id("srv").Dot("audit")),
+				)
+			}
+		}
+		bg.Return(Id("srv"))
+	})
+}
@@ -22,9 +22,14 @@ func (tr *Transport) renderHeader(outDir string) (err error) {
 	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
 
 	tr.renderHeaderTypes(srcFile)
-	tr.renderHeaderHandler(srcFile)
+	if tr.isNetHTTP() {
+		srcFile.Line().Add(tr.headersHandlerNetHTTP())
+	} else {
+		tr.renderHeaderHandler(srcFile)
+	}
 	tr.renderHeaderValue(srcFile)
 	tr.renderHeaderValueInterface(srcFile)
+	tr.renderRequestID(srcFile)
 
 	return srcFile.Save(path.Join(outDir, "header.go"))
 }
@@ -40,6 +45,8 @@ func (tr *Transport) renderHeaderTypes(srcFile goFile) {
 		Id("ResponseValue").Interface(),
 		Id("LogKey").String(),
 		Id("LogValue").Interface(),
+		Id("ContextKey").Interface(),
+		Id("ContextValue").Interface(),
 	).Line().
 		Line().Type().Id("HeaderHandler").Func().Params(Id("value").String()).Params(Id("Header"))
 }
@@ -49,25 +56,51 @@ func (tr *Transport) renderHeaderHandler(srcFile goFile) {
 	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).
 		Id("headersHandler").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).BlockFunc(func(g *Group) {
 		g.Line()
-		g.For(List(Id("headerName"), Id("handler")).Op(":=").Range().Id("srv").Dot("headerHandlers")).Block(
-			Id("value").Op(":=").Id(_ctx_).Dot("Request").Call().Dot("Header").Dot("Peek").Call(Id("headerName")),
-			Id("header").Op(":=").Id("handler").Call(String().Call(Id("value"))),
-			If(Id("header").Dot("RequestValue").Op("!=").Nil()).Block(
+		g.For(List(Id("headerName"), Id("handler")).Op(":=").Range().Id("srv").Dot("headerHandlers")).BlockFunc(func(fg *Group) {
+			fg.Id("value").Op(":=").Id(_ctx_).Dot("Request").Call().Dot("Header").Dot("Peek").Call(Id("headerName"))
+			fg.Id("header").Op(":=").Id("handler").Call(String().Call(Id("value")))
+			if tr.hasTrace() {
+				fg.Add(tr.headerSpanAttribute(Id(_ctx_).Dot("UserContext").Call()))
+			}
+			fg.If(Id("header").Dot("RequestValue").Op("!=").Nil()).Block(
 				Id(_ctx_).Dot("Request").Call().Dot("Header").Dot("Set").Call(Id("header").Dot("RequestKey"), Id("headerValue").Call(Id("header").Dot("RequestValue"))),
-			),
-			If(Id("header").Dot("ResponseValue").Op("!=").Nil()).Block(
+			)
+			fg.If(Id("header").Dot("ResponseValue").Op("!=").Nil()).Block(
 				Id(_ctx_).Dot("Response").Call().Dot("Header").Dot("Set").Call(Id("header").Dot("ResponseKey"), Id("headerValue").Call(Id("header").Dot("ResponseValue"))),
-			),
-			If(Id("header").Dot("LogValue").Op("!=").Nil()).Block(
+			)
+			fg.If(Id("header").Dot("LogValue").Op("!=").Nil()).Block(
 				Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_).Dot("UserContext").Call()).
 					Dot("With").Call().Dot("Interface").Call(Id("header").Dot("LogKey"), Id("header").Dot("LogValue")).Dot("Logger").Call(),
 				Id(_ctx_).Dot("SetUserContext").Call(Id("logger").Dot("WithContext").Call(Id(_ctx_).Dot("UserContext").Call())),
-			),
-		)
+			)
+			fg.If(Id("header").Dot("ContextValue").Op("!=").Nil()).Block(
+				Id(_ctx_).Dot("SetUserContext").Call(Qual(packageContext, "WithValue").Call(Id(_ctx_).Dot("UserContext").Call(), Id("header").Dot("ContextKey"), Id("header").Dot("ContextValue"))),
+			)
+		})
+		if tr.hasAudit() {
+			g.Id(_ctx_).Dot("SetUserContext").Call(Id("srv").Dot("audit").Dot("withActor").Call(Id(_ctx_).Dot("UserContext").Call(), Func().Params(Id("name").String()).String().Block(
+				Return(String().Call(Id(_ctx_).Dot("Request").Call().Dot("Header").Dot("Peek").Call(Id("name")))),
+			)))
+		}
+		if tr.hasMetrics() {
+			g.Id(_ctx_).Dot("SetUserContext").Call(Id("srv").Dot("metrics").Dot("withHeaderValues").Call(Id(_ctx_).Dot("UserContext").Call(), Func().Params(Id("name").String()).String().Block(
+				Return(String().Call(Id(_ctx_).Dot("Request").Call().Dot("Header").Dot("Peek").Call(Id("name")))),
+			)))
+		}
 		g.Return(Id(_ctx_).Dot("Next").Call())
 	})
 }
 
+// headerSpanAttribute records value of header, e.g. request ID, to span of request, so traces may be found by it.
+func (tr *Transport) headerSpanAttribute(ctx Code) Code {
+
+	return If(Id("header").Dot("SpanValue").Op("!=").Nil()).Block(
+		Qual(packageTrace, "SpanFromContext").Call(ctx).Dot("SetAttributes").Call(
+			Qual(packageAttributeOTEL, "String").Call(Id("header").Dot("SpanKey"), Id("headerValue").Call(Id("header").Dot("SpanValue"))),
+		),
+	)
+}
+
 func (tr *Transport) renderHeaderValue(srcFile goFile) {
 
 	srcFile.Line().Func().Id("headerValue").Params(Id("src").Interface()).Params(Id("value").String()).Block(
@@ -88,3 +121,13 @@ func (tr *Transport) renderHeaderValueInterface(srcFile goFile) {
 		Id("Header").Params().Params(String()),
 	)
 }
+
+func (tr *Transport) renderRequestID(srcFile goFile) {
+
+	srcFile.Line().Type().Id("requestIDKey").Struct()
+	srcFile.Line().Comment("RequestID returns ID of request, set by WithRequestID option.")
+	srcFile.Func().Id("RequestID").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(String()).Block(
+		List(Id("requestID"), Id("_")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("requestIDKey").Values()).Assert(String()),
+		Return(Id("requestID")),
+	)
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) renderHealth(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.Line().Const().Defs(
+		Id("healthOK").Op("=").Lit("ok"),
+		Id("healthFail").Op("=").Lit("fail"),
+		Id("healthDraining").Op("=").Lit("draining"),
+		Line().Id("defaultHealthTimeout").Op("=").Qual(packageTime, "Second").Op("*").Lit(5),
+		Id("defaultHealthCacheTTL").Op("=").Qual(packageTime, "Second"),
+	)
+
+	srcFile.Line().Comment("HealthCheck checks dependency of service, error means that service is not ready.")
+	srcFile.Type().Id("HealthCheck").Func().Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Error())
+
+	srcFile.Line().Comment("HealthStatus is response of readiness probe.")
+	srcFile.Type().Id("HealthStatus").Struct(
+		Id("Status").String().Tag(map[string]string{"json": "status"}),
+		Id("Checks").Map(String()).Id("HealthCheckStatus").Tag(map[string]string{"json": "checks,omitempty"}),
+	)
+	srcFile.Line().Type().Id("HealthCheckStatus").Struct(
+		Id("Status").String().Tag(map[string]string{"json": "status"}),
+		Id("Error").String().Tag(map[string]string{"json": "error,omitempty"}),
+		Id("Duration").String().Tag(map[string]string{"json": "duration"}),
+		Id("CheckedAt").Qual(packageTime, "Time").Tag(map[string]string{"json": "checkedAt"}),
+	)
+
+	srcFile.Line().Comment("HealthChecker is registry of named checks of readiness probe.")
+	srcFile.Type().Id("HealthChecker").Struct(
+		Id("lock").Qual(packageSync, "RWMutex"),
+		Id("checks").Index().Op("*").Id("healthCheck"),
+		Id("draining").Qual(packageSyncAtomic, "Bool"),
+	)
+	srcFile.Line().Type().Id("healthCheck").Struct(
+		Id("name").String(),
+		Id("check").Id("HealthCheck"),
+		Id("timeout").Qual(packageTime, "Duration"),
+		Id("cacheTTL").Qual(packageTime, "Duration"),
+		Line().Id("lock").Qual(packageSync, "Mutex"),
+		Id("result").Id("HealthCheckStatus"),
+	)
+
+	tr.renderHealthOptions(srcFile)
+	tr.renderHealthChecker(srcFile)
+	tr.renderHealthChecks(srcFile)
+
+	return srcFile.Save(path.Join(outDir, "health.go"))
+}
+
+func (tr *Transport) renderHealthOptions(srcFile goFile) {
+
+	srcFile.Line().Type().Id("HealthOption").Func().Params(Id("check").Op("*").Id("healthCheck"))
+
+	srcFile.Line().Comment("HealthTimeout limits duration of check, 5 seconds by default.")
+	srcFile.Func().Id("HealthTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Params(Id("HealthOption")).Block(
+		Return(Func().Params(Id("check").Op("*").Id("healthCheck")).Block(
+			Id("check").Dot("timeout").Op("=").Id("timeout"),
+		)),
+	)
+	srcFile.Line().Comment("HealthCacheTTL sets duration, while result of check is reused by probes, 1 second by default.")
+	srcFile.Func().Id("HealthCacheTTL").Params(Id("ttl").Qual(packageTime, "Duration")).Params(Id("HealthOption")).Block(
+		Return(Func().Params(Id("check").Op("*").Id("healthCheck")).Block(
+			Id("check").Dot("cacheTTL").Op("=").Id("ttl"),
+		)),
+	)
+}
+
+func (tr *Transport) renderHealthChecker(srcFile goFile) {
+
+	srcFile.Line().Comment("Register adds named check of readiness probe.")
+	srcFile.Func().Params(Id("hc").Op("*").Id("HealthChecker")).Id("Register").Params(Id("name").String(), Id("check").Id("HealthCheck"), Id("options").Op("...").Id("HealthOption")).Block(
+		Line(),
+		Id("item").Op(":=").Op("&").Id("healthCheck").Values(Dict{
+			Id("name"):     Id("name"),
+			Id("check"):    Id("check"),
+			Id("timeout"):  Id("defaultHealthTimeout"),
+			Id("cacheTTL"): Id("defaultHealthCacheTTL"),
+		}),
+		For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+			Id("option").Call(Id("item")),
+		),
+		Id("hc").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("hc").Dot("lock").Dot("Unlock").Call(),
+		Id("hc").Dot("checks").Op("=").Append(Id("hc").Dot("checks"), Id("item")),
+	)
+
+	srcFile.Line().Comment("Check runs all checks concurrently. Service is not ready, when any check fails or server is draining.")
+	srcFile.Func().Params(Id("hc").Op("*").Id("HealthChecker")).Id("Check").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("status").Id("HealthStatus"), Id("ready").Bool()).Block(
+		Line(),
+		Id("hc").Dot("lock").Dot("RLock").Call(),
+		Id("checks").Op(":=").Qual("slices", "Clone").Call(Id("hc").Dot("checks")),
+		Id("hc").Dot("lock").Dot("RUnlock").Call(),
+		Id("results").Op(":=").Make(Index().Id("HealthCheckStatus"), Len(Id("checks"))),
+		Var().Id("wg").Qual(packageSync, "WaitGroup"),
+		For(List(Id("i"), Id("check")).Op(":=").Range().Id("checks")).Block(
+			Id("wg").Dot("Add").Call(Lit(1)),
+			Go().Func().Params().Block(
+				Defer().Id("wg").Dot("Done").Call(),
+				Id("results").Index(Id("i")).Op("=").Id("check").Dot("run").Call(Id(_ctx_)),
+			).Call(),
+		),
+		Id("wg").Dot("Wait").Call(),
+		Id("ready").Op("=").True(),
+		Id("status").Op("=").Id("HealthStatus").Values(Dict{Id("Status"): Id("healthOK")}),
+		If(Len(Id("checks")).Op("!=").Lit(0)).Block(
+			Id("status").Dot("Checks").Op("=").Make(Map(String()).Id("HealthCheckStatus"), Len(Id("checks"))),
+		),
+		For(List(Id("i"), Id("check")).Op(":=").Range().Id("checks")).Block(
+			Id("status").Dot("Checks").Index(Id("check").Dot("name")).Op("=").Id("results").Index(Id("i")),
+			If(Id("results").Index(Id("i")).Dot("Status").Op("!=").Id("healthOK")).Block(
+				Id("ready").Op("=").False(),
+				Id("status").Dot("Status").Op("=").Id("healthFail"),
+			),
+		),
+		If(Id("hc").Dot("draining").Dot("Load").Call()).Block(
+			Id("ready").Op("=").False(),
+			Id("status").Dot("Status").Op("=").Id("healthDraining"),
+		),
+		Return(),
+	)
+
+	srcFile.Line().Func().Params(Id("check").Op("*").Id("healthCheck")).Id("run").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("HealthCheckStatus")).Block(
+		Line(),
+		Id("check").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("check").Dot("lock").Dot("Unlock").Call(),
+		If(Op("!").Id("check").Dot("result").Dot("CheckedAt").Dot("IsZero").Call().Op("&&").Qual(packageTime, "Since").Call(Id("check").Dot("result").Dot("CheckedAt")).Op("<").Id("check").Dot("cacheTTL")).Block(
+			Return(Id("check").Dot("result")),
+		),
+		List(Id(_ctx_), Id("cancel")).Op(":=").Qual(packageContext, "WithTimeout").Call(Id(_ctx_), Id("check").Dot("timeout")),
+		Defer().Id("cancel").Call(),
+		Id("begin").Op(":=").Qual(packageTime, "Now").Call(),
+		Id("done").Op(":=").Make(Chan().Error(), Lit(1)),
+		Go().Func().Params().Block(
+			Id("done").Op("<-").Id("check").Dot("check").Call(Id(_ctx_)),
+		).Call(),
+		Var().Err().Error(),
+		Select().Block(
+			Case(Err().Op("=").Op("<-").Id("done")),
+			Case(Op("<-").Id(_ctx_).Dot("Done").Call()).Block(
+				Err().Op("=").Id(_ctx_).Dot("Err").Call(),
+			),
+		),
+		Id("check").Dot("result").Op("=").Id("HealthCheckStatus").Values(Dict{
+			Id("Status"):    Id("healthOK"),
+			Id("Duration"):  Qual(packageTime, "Since").Call(Id("begin")).Dot("String").Call(),
+			Id("CheckedAt"): Id("begin"),
+		}),
+		If(Err().Op("!=").Nil()).Block(
+			Id("check").Dot("result").Dot("Status").Op("=").Id("healthFail"),
+			Id("check").Dot("result").Dot("Error").Op("=").Err().Dot("Error").Call(),
+		),
+		Return(Id("check").Dot("result")),
+	)
+
+	srcFile.Line().Comment("drain makes service not ready, e.g. while server shuts down.")
+	srcFile.Func().Params(Id("hc").Op("*").Id("HealthChecker")).Id("drain").Params().Block(
+		Id("hc").Dot("draining").Dot("Store").Call(True()),
+	)
+}
+
+func (tr *Transport) renderHealthChecks(srcFile goFile) {
+
+	srcFile.Line().Comment("PingCheck checks dependency by its Ping method, e.g. cache or message broker client.")
+	srcFile.Func().Id("PingCheck").Params(Id("pinger").Interface(
+		Id("Ping").Params(Qual(packageContext, "Context")).Params(Error()),
+	)).Params(Id("HealthCheck")).Block(
+		Return(Id("pinger").Dot("Ping")),
+	)
+	srcFile.Line().Comment("SQLCheck checks connection to database, e.g. *sql.DB.")
+	srcFile.Func().Id("SQLCheck").Params(Id("db").Interface(
+		Id("PingContext").Params(Qual(packageContext, "Context")).Params(Error()),
+	)).Params(Id("HealthCheck")).Block(
+		Return(Id("db").Dot("PingContext")),
+	)
+}
+
+func (tr *Transport) healthFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Health").Params().Params(Op("*").Id("HealthChecker")).Block(
+		Return(Id("srv").Dot("health")),
+	)
+}
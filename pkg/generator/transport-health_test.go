@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderHealth(t *testing.T) {
+
+	tests := []struct {
+		backend string
+		readyz  []string
+	}{
+		{backend: backendNetHTTP, readyz: []string{
+			`mux.HandleFunc("GET /livez", func(w http.ResponseWriter, r *http.Request) {`,
+			`mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {`,
+			`status, ready := srv.health.Check(r.Context())`,
+			`statusCode = http.StatusServiceUnavailable`,
+		}},
+		{backend: backendFiber, readyz: []string{
+			`srv.srvHealth.Get("/livez", func(ctx *fiber.Ctx) error {`,
+			`srv.srvHealth.Get("/readyz", func(ctx *fiber.Ctx) error {`,
+			`status, ready := srv.health.Check(ctx.UserContext())`,
+			`ctx.Status(fiber.StatusServiceUnavailable)`,
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/files", test.backend)
+			assertContains(t, files, "server.go", test.readyz...)
+			assertContains(t, files, "server.go", `func (srv *Server) Health() *HealthChecker {`)
+			assertContains(t, files, "health.go",
+				`func (hc *HealthChecker) Register(name string, check HealthCheck, options ...HealthOption) {`,
+				`func HealthTimeout(timeout time.Duration) HealthOption {`,
+				`func PingCheck(pinger interface {`,
+				`func SQLCheck(db interface {`,
+			)
+		})
+	}
+}
+
+// healthTest runs in package of generated health.go, which depends on standard library only.
+const healthTest = `package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker(t *testing.T) {
+
+	var hc HealthChecker
+	if status, ready := hc.Check(context.Background()); !ready || status.Status != healthOK || status.Checks != nil {
+		t.Fatalf("status %+v, ready %v without checks", status, ready)
+	}
+	var calls atomic.Int32
+	hc.Register("cache", func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}, HealthCacheTTL(time.Minute))
+	hc.Register("db", func(ctx context.Context) error {
+		<-ctx.Done()
+		return errors.New("not reached")
+	}, HealthTimeout(10*time.Millisecond))
+	status, ready := hc.Check(context.Background())
+	if ready || status.Status != healthFail || status.Checks["cache"].Status != healthOK {
+		t.Errorf("status %+v, ready %v, want failed db", status, ready)
+	}
+	if db := status.Checks["db"]; db.Status != healthFail || db.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("db %+v, want timeout", db)
+	}
+	// result of check is cached
+	_, _ = hc.Check(context.Background())
+	if calls.Load() != 1 {
+		t.Errorf("cache is checked %d times, want once", calls.Load())
+	}
+	hc.drain()
+	if status, ready = hc.Check(context.Background()); ready || status.Status != healthDraining {
+		t.Errorf("status %+v, ready %v, want draining", status, ready)
+	}
+}
+`
+
+func TestHealthChecker(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("go test of generated code is skipped in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not found")
+	}
+	tr, err := NewTransport(testLog(), "test", "testdata/files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "health")
+	if err = os.MkdirAll(outDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.renderHealth(outDir); err != nil {
+		t.Fatal(err)
+	}
+	for fileName, content := range map[string]string{"go.mod": "module health\n\ngo 1.24\n", "health_test.go": healthTest} {
+		if err = os.WriteFile(filepath.Join(outDir, fileName), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goTool, "test", "-count=1", ".")
+	cmd.Dir = outDir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, output)
+	}
+}
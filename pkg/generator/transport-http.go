@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
 )
 
 func (tr *Transport) renderHTTP(outDir string) (err error) {
@@ -23,9 +25,95 @@ func (tr *Transport) renderHTTP(outDir string) (err error) {
 		Id("RedirectTo").Call().String(),
 	)
 
+	if tr.isNetHTTP() {
+		srcFile.Line().Type().Id("cookieType").Interface(
+			Id("Cookie").Params().Params(Op("*").Qual(packageHttp, "Cookie")),
+		)
+		srcFile.Line().Func().Id("cookieValue").Params(Id("r").Op("*").Qual(packageHttp, "Request"), Id("name").String()).Params(String()).Block(
+			If(List(Id("cookie"), Err()).Op(":=").Id("r").Dot("Cookie").Call(Id("name")).Op(";").Err().Op("==").Nil()).Block(
+				Return(Id("cookie").Dot("Value")),
+			),
+			Return(Lit("")),
+		)
+		return srcFile.Save(path.Join(outDir, "http.go"))
+	}
 	srcFile.Line().Type().Id("cookieType").Interface(
 		Id("Cookie").Params().Params(Op("*").Qual(packageFiber, "Cookie")),
 	)
 
 	return srcFile.Save(path.Join(outDir, "http.go"))
 }
+
+// checkCookies warns about results of http-cookies annotation, which have no Cookie method of backend, such cookies are not set.
+func (tr *Transport) checkCookies() {
+
+	backend, cookiePkg, cookieType := backendFiber, packageFiber, "*fiber.Cookie"
+	if tr.isNetHTTP() {
+		backend, cookiePkg, cookieType = backendNetHTTP, packageHttp, "*http.Cookie"
+	}
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		for _, method := range svc.methods {
+			for _, retName := range sortedKeys(method.retCookieMap()) {
+				if !hasCookieMethod(svc.pkgPath, method.resultByName(retName).Type, cookiePkg) {
+					tr.log.Warnf("%s: result '%s' of %s has no method 'Cookie() %s' for %s backend, cookie is not set", method.fullName(), retName, tagHttpCookies, cookieType, backend)
+				}
+			}
+		}
+	}
+}
+
+func hasCookieMethod(pkgPath string, varType types.Type, cookiePkg string) bool {
+
+	pointer, isPointer := varType.(types.TPointer)
+	if isPointer {
+		varType = pointer.Next
+	}
+	var typeName string
+	switch vType := varType.(type) {
+	case types.TName:
+		if types.IsBuiltin(vType) {
+			return false
+		}
+		typeName = vType.TypeName
+	case types.TImport:
+		pkgPath, typeName = vType.Import.Package, vType.Next.String()
+	case types.TInterface:
+		return hasCookieFunc(vType.Interface.Methods, cookiePkg)
+	default:
+		return false
+	}
+	if iface, isInterface := searchType(pkgPath, typeName).(types.TInterface); isInterface {
+		return hasCookieFunc(iface.Interface.Methods, cookiePkg)
+	}
+	for _, method := range searchMethods(pkgPath, typeName) {
+		if isCookieFunc(method.Function, cookiePkg) && (isPointer || !isPointerType(method.Receiver.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCookieFunc(methods []*types.Function, cookiePkg string) bool {
+
+	for _, method := range methods {
+		if isCookieFunc(*method, cookiePkg) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCookieFunc checks signature 'Cookie() *Cookie', where Cookie is type of cookie package.
+func isCookieFunc(fn types.Function, cookiePkg string) bool {
+
+	if fn.Name != "Cookie" || len(fn.Args) != 0 || len(fn.Results) != 1 {
+		return false
+	}
+	pointer, isPointer := fn.Results[0].Type.(types.TPointer)
+	if !isPointer {
+		return false
+	}
+	cookie, isImport := pointer.Next.(types.TImport)
+	return isImport && cookie.Import.Package == cookiePkg && cookie.Next.String() == "Cookie"
+}
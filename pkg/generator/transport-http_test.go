@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestCheckCookies(t *testing.T) {
+
+	const warning = "auth.login: result '%s' of http-cookies has no method 'Cookie() %s' for %s backend, cookie is not set"
+	tests := []struct {
+		backend    string
+		cookieType string
+		warned     []string
+	}{
+		{backend: backendNetHTTP, cookieType: "*http.Cookie", warned: []string{"plain", "token", "value"}},
+		{backend: backendFiber, cookieType: "*fiber.Cookie", warned: []string{"plain", "session", "value"}},
+	}
+	for _, test := range tests {
+		t.Run(test.backend, func(t *testing.T) {
+			log, hook := logtest.NewNullLogger()
+			tr, err := NewTransport(log, "test", "testdata/cookies")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = tr.SetBackend(test.backend); err != nil {
+				t.Fatal(err)
+			}
+			if err = tr.RenderServer(filepath.Join(t.TempDir(), "transport")); err != nil {
+				t.Fatal(err)
+			}
+			var warnings []string
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warnings = append(warnings, entry.Message)
+				}
+			}
+			for _, retName := range test.warned {
+				if warning := fmt.Sprintf(warning, retName, test.cookieType, test.backend); !slices.Contains(warnings, warning) {
+					t.Errorf("warning %q is not logged, warnings: %q", warning, warnings)
+				}
+			}
+			if len(warnings) != len(test.warned) {
+				t.Errorf("warnings = %q, want %d", warnings, len(test.warned))
+			}
+		})
+	}
+}
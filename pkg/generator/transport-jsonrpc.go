@@ -18,6 +18,7 @@ func (tr *Transport) renderJsonRPC(outDir string) (err error) {
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(packageHttp, "http")
 	srcFile.ImportName(packageErrors, "errors")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
@@ -28,11 +29,18 @@ func (tr *Transport) renderJsonRPC(outDir string) (err error) {
 	srcFile.Add(tr.errorJsonRPC()).Line()
 	srcFile.Add(tr.jsonrpcResponsesTypeFunc())
 
-	srcFile.Add(tr.serveBatchFunc())
+	if tr.isNetHTTP() {
+		srcFile.Add(serveBatchFuncNetHTTP(Id("srv").Op("*").Id("Server"), "srv", tr.deprecatedJsonRPC(), "deprecatedJsonRPC", tr.tags.Value(tagPackageJSON, packageStdJSON)))
+	} else {
+		srcFile.Add(tr.serveBatchFunc())
+	}
 	srcFile.Add(tr.batchFunc())
 	srcFile.Add(tr.singleBatchFunc())
+	if tr.hasTrace() {
+		srcFile.Line().Add(tr.traceBatchFunc())
+	}
 
-	srcFile.Line().Type().Id("methodJsonRPC").Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("requestBase").Id("baseJsonRPC")).Params(Id("responseBase").Op("*").Id("baseJsonRPC"))
+	srcFile.Line().Type().Id("methodJsonRPC").Func().Params(tr.handlerParams(), Id("requestBase").Id("baseJsonRPC")).Params(Id("responseBase").Op("*").Id("baseJsonRPC"))
 	srcFile.Line().Add(tr.makeErrorResponseJsonRPCFunc())
 	return srcFile.Save(path.Join(outDir, "jsonrpc.go"))
 }
@@ -139,7 +147,7 @@ func (tr *Transport) jsonrpcConstants(exportErrors bool) Code {
 func (tr *Transport) singleBatchFunc() Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("doSingleBatch").
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("request").Id("baseJsonRPC")).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
+		Params(tr.handlerParams(), Id("request").Id("baseJsonRPC")).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
@@ -152,8 +160,12 @@ func (tr *Transport) singleBatchFunc() Code {
 							if !method.isJsonRPC() {
 								continue
 							}
-							sg.Case(Lit(svc.lcName() + "." + method.lcName())).Block(
-								Return(Id("srv").Dot("http"+serviceName).Dot(utils.ToLowerCamel(method.Name)).Call(Id(_ctx_), Id("request"))),
+							names := method.jsonrpcCase(svc.lcName() + "." + method.lcName())
+							if len(names) == 0 {
+								continue
+							}
+							sg.Case(names...).Block(
+								Return(Id("srv").Dot("http"+serviceName).Dot(utils.ToLowerCamel(method.Name)).Call(tr.handlerArgs(), Id("request"))),
 							)
 						}
 					}
@@ -167,16 +179,19 @@ func (tr *Transport) singleBatchFunc() Code {
 func (tr *Transport) batchFunc() Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("doBatch").
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("requests").Op("[]").Id("baseJsonRPC")).Params(Id("responses").Id("jsonrpcResponses")).BlockFunc(
+		Params(tr.handlerParams(), Id("requests").Op("[]").Id("baseJsonRPC")).Params(Id("responses").Id("jsonrpcResponses")).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.If(Len(Id("requests")).Op(">").Id("srv").Dot("maxBatchSize")).Block(
 				Id("responses").Dot("append").Call(Id("makeErrorResponseJsonRPC").Call(Nil(), Id("invalidRequestError"), Lit("batch size exceeded"), Nil())),
 				Return(),
 			)
-			bg.If(Qual(packageStrings, "EqualFold").Call(Id(_ctx_).Dot("Get").Call(Lit(syncHeader)), Lit("true"))).Block(
+			if tr.hasTrace() {
+				bg.Id("traceBatch").Call(tr.userContext(), Id("requests"))
+			}
+			bg.If(Qual(packageStrings, "EqualFold").Call(tr.requestHeader(Lit(syncHeader)), Lit("true"))).Block(
 				For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
-					Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(Id(_ctx_), Id("request")),
+					Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(tr.handlerArgs(), Id("request")),
 					If(Id("request").Dot("ID").Op("!=").Nil()).Block(
 						Id("responses").Dot("append").Call(Id("response")),
 					),
@@ -195,7 +210,7 @@ func (tr *Transport) batchFunc() Code {
 				Go().Func().Params().Block(
 					Defer().Id("wg").Dot("Done").Call(),
 					For(Id("request").Op(":=").Range().Id("callCh").Block(
-						Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(Id(_ctx_), Id("request")),
+						Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(tr.handlerArgs(), Id("request")),
 						If(Id("request").Dot("ID").Op("!=").Nil()).Block(
 							Id("responses").Dot("append").Call(Id("response")),
 						),
@@ -211,6 +226,20 @@ func (tr *Transport) batchFunc() Code {
 		})
 }
 
+func (tr *Transport) traceBatchFunc() Code {
+
+	return Func().Id("traceBatch").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("requests").Op("[]").Id("baseJsonRPC")).Block(
+		Id("span").Op(":=").Qual(packageTrace, "SpanFromContext").Call(Id(_ctx_)),
+		For(List(Id("idx"), Id("request")).Op(":=").Range().Id("requests")).Block(
+			Id("span").Dot("AddEvent").Call(Lit("jsonrpc.request"), Qual(packageTrace, "WithAttributes").Call(
+				Qual(packageAttributeOTEL, "Int").Call(Lit("rpc.jsonrpc.batch_position"), Id("idx")),
+				Qual(packageAttributeOTEL, "String").Call(Lit("rpc.jsonrpc.request_id"), String().Call(Id("request").Dot("ID"))),
+				Qual(packageAttributeOTEL, "String").Call(Lit("rpc.method"), Id("request").Dot("Method")),
+			)),
+		),
+	)
+}
+
 func (tr *Transport) serveBatchFunc() Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("serveBatch").
@@ -235,6 +264,7 @@ func (tr *Transport) serveBatchFunc() Code {
 				ig.Id("single").Op("=").True()
 				ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
 			})
+			bg.Add(deprecationJsonRPC(Id(_ctx_), "deprecatedJsonRPC", tr.deprecatedJsonRPC()))
 			bg.If(Id("single")).Block(
 				Return(Id("sendResponse").Call(Id(_ctx_), Id("srv").Dot("doSingleBatch").
 					Call(Id(_ctx_), Id("requests").Op("[").Lit(0).Op("]")),
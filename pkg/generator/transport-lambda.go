@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) renderLambda(outDir string) (err error) {
+
+	if err = pkgCopyTo("lambda", outDir); err != nil {
+		return
+	}
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageFiberAdaptor, "adaptor")
+	srcFile.ImportName(fmt.Sprintf("%s/lambda", tr.pkgPath(outDir)), "lambda")
+
+	handler := Qual(packageFiberAdaptor, "FiberApp").Call(Id("srv").Dot("srvHTTP"))
+	if tr.isNetHTTP() {
+		handler = Id("srv").Dot("handler")
+	}
+	srcFile.Line().Comment("ServeLambda serves API Gateway HTTP API events when the binary is deployed as AWS Lambda 'bootstrap'.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeLambda").Params().Params(Error()).Block(
+		Return(Qual(fmt.Sprintf("%s/lambda", tr.pkgPath(outDir)), "Start").Call(handler)),
+	)
+	return srcFile.Save(path.Join(outDir, "lambda.go"))
+}
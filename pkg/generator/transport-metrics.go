@@ -4,10 +4,19 @@
 package generator
 
 import (
+	"fmt"
+	"go/build"
+	"go/importer"
+	"go/token"
+	gotypes "go/types"
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
 )
 
 func (tr *Transport) renderMetrics(outDir string) (err error) {
@@ -23,12 +32,26 @@ func (tr *Transport) renderMetrics(outDir string) (err error) {
 	srcFile.ImportName(packageFiberAdaptor, "adaptor")
 	srcFile.ImportName(packagePrometheusHttp, "promhttp")
 
+	srcFile.ImportName(packageMetricOTEL, "metric")
+	srcFile.ImportName(packageAttributeOTEL, "attribute")
+
+	srcFile.Comment("Collectors of server metrics, which was registered last, they are shared by servers with the same configuration.")
 	srcFile.Add(Var().Id("VersionGauge").Op("*").Qual(packagePrometheus, "GaugeVec"))
 	srcFile.Add(Var().Id("RequestCount").Op("*").Qual(packagePrometheus, "CounterVec"))
 	srcFile.Add(Var().Id("RequestCountAll").Op("*").Qual(packagePrometheus, "CounterVec"))
 	srcFile.Add(Var().Id("RequestLatency").Op("*").Qual(packagePrometheus, "HistogramVec"))
 
-	srcFile.Add(tr.serveMetricsFunc())
+	tr.renderMetricsConfig(srcFile)
+	tr.renderMetricsOptions(srcFile)
+	tr.renderMetricsMeter(srcFile)
+	tr.renderMetricsRegister(srcFile)
+	tr.renderObserveRequest(srcFile)
+
+	if tr.isNetHTTP() {
+		srcFile.Add(tr.serveMetricsFuncNetHTTP())
+	} else {
+		srcFile.Add(tr.serveMetricsFunc())
+	}
 
 	return srcFile.Save(path.Join(outDir, "metrics.go"))
 }
@@ -43,3 +66,348 @@ func (tr *Transport) serveMetricsFunc() Code {
 		).Call(),
 	)
 }
+
+// metricsArgLabels returns sorted names of arguments, listed by metrics-labels annotations of all methods.
+// Label set of metrics is common, so methods without such argument have empty value of label.
+func (tr *Transport) metricsArgLabels() (labels []string) {
+
+	found := make(map[string]bool)
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		if !svc.tags.IsSet(tagMetrics) {
+			continue
+		}
+		for _, method := range svc.methods {
+			for _, name := range method.metricsLabels() {
+				if !found[name] {
+					found[name] = true
+					labels = append(labels, name)
+				}
+			}
+		}
+	}
+	sort.Strings(labels)
+	return
+}
+
+// scalarTypes are types of arguments, which may be used as labels of metrics.
+// Values of other types would make labels of unbounded cardinality.
+var scalarTypes = map[string]bool{
+	"string": true, "bool": true, "byte": true, "rune": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+}
+
+// checkMetricsLabels returns error, if argument listed by metrics-labels annotation is not string, integer or bool.
+func (tr *Transport) checkMetricsLabels() (err error) {
+
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		if !svc.tags.IsSet(tagMetrics) {
+			continue
+		}
+		for _, method := range svc.methods {
+			for _, name := range method.metricsLabels() {
+				if arg := method.argByName(name); !isScalarType(svc.pkgPath, arg.Type) {
+					return fmt.Errorf("%s.%s: argument '%s' of %s must be string, integer or bool, got %s", svc.Name, method.Name, name, tagMetricsLabels, arg.Type)
+				}
+			}
+		}
+	}
+	return
+}
+
+func isScalarType(pkgPath string, varType types.Type) bool {
+
+	switch vType := varType.(type) {
+	case types.TName:
+		if types.IsBuiltin(vType) {
+			return scalarTypes[vType.TypeName]
+		}
+		if nextType := searchType(pkgPath, vType.TypeName); nextType != nil {
+			return isScalarType(pkgPath, nextType)
+		}
+	case types.TImport:
+		if pkg, err := build.Default.Import(vType.Import.Package, "", build.FindOnly); err == nil && pkg.Goroot {
+			return isScalarStd(vType.Import.Package, vType.Next.String())
+		}
+		if nextType := searchType(vType.Import.Package, vType.Next.String()); nextType != nil {
+			return isScalarType(vType.Import.Package, nextType)
+		}
+	}
+	return false
+}
+
+// isScalarStd checks type of standard library, e.g. time.Duration, by type checker, because astra does not parse it.
+func isScalarStd(pkgPath, typeName string) bool {
+
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import(pkgPath)
+	if err != nil {
+		return false
+	}
+	typeObj := pkg.Scope().Lookup(typeName)
+	if typeObj == nil {
+		return false
+	}
+	basic, isBasic := typeObj.Type().Underlying().(*gotypes.Basic)
+	return isBasic && basic.Info()&(gotypes.IsInteger|gotypes.IsString|gotypes.IsBoolean) != 0
+}
+
+// metricsLabels returns names of arguments of method listed by metrics-labels annotation.
+func (m *method) metricsLabels() (labels []string) {
+
+	names := strings.Split(m.tags.Value(tagMetricsLabels), ",")
+	for _, arg := range m.argsWithoutContext() {
+		for _, name := range names {
+			if arg.Name == name {
+				labels = append(labels, name)
+			}
+		}
+	}
+	return
+}
+
+func (tr *Transport) renderMetricsConfig(srcFile goFile) {
+
+	srcFile.Line().Var().Id("metricsArgLabels").Op("=").Index().String().ValuesFunc(func(vg *Group) {
+		for _, label := range tr.metricsArgLabels() {
+			vg.Lit(label)
+		}
+	})
+	srcFile.Line().Type().Id("metricsHeadersKey").Struct()
+	srcFile.Line().Type().Id("metricsHeader").Struct(
+		Id("label").String(),
+		Id("header").String(),
+	)
+	srcFile.Line().Type().Id("metricsConfig").Struct(
+		Id("namespace").String(),
+		Id("subsystem").String(),
+		Id("buckets").Index().Float64(),
+		Id("headers").Index().Id("metricsHeader"),
+		Line().Id("versions").Op("*").Qual(packagePrometheus, "GaugeVec"),
+		Id("count").Op("*").Qual(packagePrometheus, "CounterVec"),
+		Id("countAll").Op("*").Qual(packagePrometheus, "CounterVec"),
+		Id("latency").Op("*").Qual(packagePrometheus, "HistogramVec"),
+		Line().Id("meterProvider").Qual(packageMetricOTEL, "MeterProvider"),
+		Id("requestCount").Qual(packageMetricOTEL, "Int64Counter"),
+		Id("requestCountAll").Qual(packageMetricOTEL, "Int64Counter"),
+		Id("requestLatency").Qual(packageMetricOTEL, "Float64Histogram"),
+	)
+	srcFile.Line().Func().Id("newMetricsConfig").Params(Id("options").Op("...").Id("MetricsOption")).Params(Id("cfg").Op("*").Id("metricsConfig")).Block(
+		Line(),
+		Id("cfg").Op("=").Op("&").Id("metricsConfig").Values(Dict{
+			Id("namespace"): Lit("service"),
+			Id("subsystem"): Lit("requests"),
+			Id("buckets"):   Qual(packagePrometheus, "DefBuckets"),
+		}),
+		For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+			Id("option").Call(Id("cfg")),
+		),
+		Return(),
+	)
+	srcFile.Line().Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Id("labels").Params().Params(Id("labels").Index().String()).Block(
+		Line(),
+		Id("labels").Op("=").Append(Index().String().Values(Lit("service"), Lit("method"), Lit("success"), Lit("errCode")), Id("metricsArgLabels").Op("...")),
+		For(List(Id("_"), Id("header")).Op(":=").Range().Id("cfg").Dot("headers")).Block(
+			Id("labels").Op("=").Append(Id("labels"), Id("header").Dot("label")),
+		),
+		Return(),
+	)
+	srcFile.Line().Comment("withHeaderValues puts values of headers, used as labels of metrics, to context of request.")
+	srcFile.Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Id("withHeaderValues").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("header").Func().Params(String()).String()).Params(Qual(packageContext, "Context")).Block(
+		Line(),
+		If(Id("cfg").Op("==").Nil().Op("||").Len(Id("cfg").Dot("headers")).Op("==").Lit(0)).Block(
+			Return(Id(_ctx_)),
+		),
+		Id("values").Op(":=").Make(Index().String(), Lit(0), Len(Id("cfg").Dot("headers"))),
+		For(List(Id("_"), Id("h")).Op(":=").Range().Id("cfg").Dot("headers")).Block(
+			Id("values").Op("=").Append(Id("values"), Id("header").Call(Id("h").Dot("header"))),
+		),
+		Return(Qual(packageContext, "WithValue").Call(Id(_ctx_), Id("metricsHeadersKey").Values(), Id("values"))),
+	)
+}
+
+func (tr *Transport) renderMetricsOptions(srcFile goFile) {
+
+	srcFile.Line().Type().Id("MetricsOption").Func().Params(Id("cfg").Op("*").Id("metricsConfig"))
+
+	if tr.hasMetrics() {
+		srcFile.Line().Comment("Metrics enables metrics of services, collectors are registered once by New.")
+		srcFile.Func().Id("Metrics").Params(Id("options").Op("...").Id("MetricsOption")).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("metrics").Op("=").Id("newMetricsConfig").Call(Id("options").Op("...")),
+			)),
+		)
+	}
+
+	srcFile.Line().Comment("MetricsNamespace sets namespace of metrics, 'service' by default.")
+	srcFile.Func().Id("MetricsNamespace").Params(Id("namespace").String()).Params(Id("MetricsOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Block(
+			Id("cfg").Dot("namespace").Op("=").Id("namespace"),
+		)),
+	)
+	srcFile.Line().Comment("MetricsSubsystem sets subsystem of request metrics, 'requests' by default.")
+	srcFile.Func().Id("MetricsSubsystem").Params(Id("subsystem").String()).Params(Id("MetricsOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Block(
+			Id("cfg").Dot("subsystem").Op("=").Id("subsystem"),
+		)),
+	)
+	srcFile.Line().Comment("MetricsBuckets sets buckets of latency histogram in seconds, prometheus.DefBuckets by default.")
+	srcFile.Func().Id("MetricsBuckets").Params(Id("buckets").Op("...").Float64()).Params(Id("MetricsOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Block(
+			Id("cfg").Dot("buckets").Op("=").Id("buckets"),
+		)),
+	)
+	srcFile.Line().Comment("MetricsHeader adds label with value of request header.")
+	srcFile.Func().Id("MetricsHeader").Params(Id("label"), Id("header").String()).Params(Id("MetricsOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Block(
+			Id("cfg").Dot("headers").Op("=").Append(Id("cfg").Dot("headers"), Id("metricsHeader").Values(Dict{
+				Id("label"):  Id("label"),
+				Id("header"): Id("header"),
+			})),
+		)),
+	)
+	srcFile.Line().Comment("MetricsMeterProvider records metrics by OpenTelemetry instruments of provider instead of Prometheus collectors.")
+	srcFile.Func().Id("MetricsMeterProvider").Params(Id("provider").Qual(packageMetricOTEL, "MeterProvider")).Params(Id("MetricsOption")).Block(
+		Return(Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Block(
+			Id("cfg").Dot("meterProvider").Op("=").Id("provider"),
+		)),
+	)
+}
+
+func (tr *Transport) renderMetricsMeter(srcFile goFile) {
+
+	name := func(suffix string) Code {
+		return Id("cfg").Dot("namespace").Op("+").Lit(".").Op("+").Id("cfg").Dot("subsystem").Op("+").Lit(suffix)
+	}
+	srcFile.Line().Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Id("initMeter").Params(Id("hostname").String()).Params(Err().Error()).Block(
+		Line(),
+		Id("meter").Op(":=").Id("cfg").Dot("meterProvider").Dot("Meter").Call(Lit("tg"), Qual(packageMetricOTEL, "WithInstrumentationVersion").Call(Id("VersionTg"))),
+		If(List(Id("cfg").Dot("requestCount"), Err()).Op("=").Id("meter").Dot("Int64Counter").Call(name(".count"),
+			Qual(packageMetricOTEL, "WithDescription").Call(Lit("Number of requests received"))).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		If(List(Id("cfg").Dot("requestCountAll"), Err()).Op("=").Id("meter").Dot("Int64Counter").Call(name(".all_count"),
+			Qual(packageMetricOTEL, "WithDescription").Call(Lit("Number of all requests received"))).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		If(List(Id("cfg").Dot("requestLatency"), Err()).Op("=").Id("meter").Dot("Float64Histogram").Call(name(".duration"),
+			Qual(packageMetricOTEL, "WithDescription").Call(Lit("Duration of requests")),
+			Qual(packageMetricOTEL, "WithUnit").Call(Lit("s")),
+			Qual(packageMetricOTEL, "WithExplicitBucketBoundaries").Call(Id("cfg").Dot("buckets").Op("...")),
+		).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Var().Id("versions").Qual(packageMetricOTEL, "Int64Gauge"),
+		If(List(Id("versions"), Err()).Op("=").Id("meter").Dot("Int64Gauge").Call(Id("cfg").Dot("namespace").Op("+").Lit(".versions.count"),
+			Qual(packageMetricOTEL, "WithDescription").Call(Lit("Versions of service parts"))).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("versions").Dot("Record").Call(Qual(packageContext, "Background").Call(), Lit(1), Qual(packageMetricOTEL, "WithAttributes").Call(
+			Qual(packageAttributeOTEL, "String").Call(Lit("part"), Lit("tg")),
+			Qual(packageAttributeOTEL, "String").Call(Lit("version"), Id("VersionTg")),
+			Qual(packageAttributeOTEL, "String").Call(Lit("hostname"), Id("hostname")),
+		)),
+		Return(),
+	)
+}
+
+// renderMetricsRegister renders registration of Prometheus collectors. Collectors, which are registered already
+// with the same description, are shared, registration with other labels fails.
+func (tr *Transport) renderMetricsRegister(srcFile goFile) {
+
+	opts := func(kind, name, subsystem, help string, buckets bool) Code {
+		return Qual(packagePrometheus, kind).Values(DictFunc(func(d Dict) {
+			d[Id("Name")] = Lit(name)
+			d[Id("Namespace")] = Id("cfg").Dot("namespace")
+			if subsystem != "" {
+				d[Id("Subsystem")] = Lit(subsystem)
+			} else {
+				d[Id("Subsystem")] = Id("cfg").Dot("subsystem")
+			}
+			d[Id("Help")] = Lit(help)
+			if buckets {
+				d[Id("Buckets")] = Id("cfg").Dot("buckets")
+			}
+		}))
+	}
+	register := func(field, constructor string, opts Code, labels Code) Code {
+		return If(List(Id("cfg").Dot(field), Err()).Op("=").Id("registerCollector").Call(Qual(packagePrometheus, constructor).Call(opts, labels)).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+	}
+	srcFile.Line().Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Id("init").Params().Params(Err().Error()).Block(
+		Line(),
+		List(Id("hostname"), Id("_")).Op(":=").Qual(packageOS, "Hostname").Call(),
+		If(Id("cfg").Dot("meterProvider").Op("!=").Nil()).Block(
+			Return(Id("cfg").Dot("initMeter").Call(Id("hostname"))),
+		),
+		Id("labels").Op(":=").Id("cfg").Dot("labels").Call(),
+		register("versions", "NewGaugeVec", opts("GaugeOpts", "count", "versions", "Versions of service parts", false), Index().String().Values(Lit("part"), Lit("version"), Lit("hostname"))),
+		register("count", "NewCounterVec", opts("CounterOpts", "count", "", "Number of requests received", false), Id("labels")),
+		register("countAll", "NewCounterVec", opts("CounterOpts", "all_count", "", "Number of all requests received", false), Id("labels")),
+		register("latency", "NewHistogramVec", opts("HistogramOpts", "latency_microseconds", "", "Total duration of requests in seconds", true), Id("labels")),
+		Id("cfg").Dot("versions").Dot("WithLabelValues").Call(Lit("tg"), Id("VersionTg"), Id("hostname")).Dot("Set").Call(Lit(1)),
+		List(Id("VersionGauge"), Id("RequestCount"), Id("RequestCountAll"), Id("RequestLatency")).Op("=").
+			List(Id("cfg").Dot("versions"), Id("cfg").Dot("count"), Id("cfg").Dot("countAll"), Id("cfg").Dot("latency")),
+		Return(),
+	)
+
+	srcFile.Line().Func().Id("registerCollector").Types(Id("C").Qual(packagePrometheus, "Collector")).
+		Params(Id("collector").Id("C")).Params(Id("C"), Error()).Block(
+		Line(),
+		If(Err().Op(":=").Qual(packagePrometheus, "Register").Call(Id("collector")).Op(";").Err().Op("!=").Nil()).Block(
+			Var().Id("registered").Qual(packagePrometheus, "AlreadyRegisteredError"),
+			If(Qual("errors", "As").Call(Err(), Op("&").Id("registered"))).Block(
+				If(List(Id("existing"), Id("ok")).Op(":=").Id("registered").Dot("ExistingCollector").Assert(Id("C")).Op(";").Id("ok")).Block(
+					Return(Id("existing"), Nil()),
+				),
+			),
+			Return(Id("collector"), Err()),
+		),
+		Return(Id("collector"), Nil()),
+	)
+}
+
+// metricsInit renders initialization of metrics, enabled by Metrics option of New.
+func (tr *Transport) metricsInit() Code {
+
+	if !tr.hasMetrics() {
+		return Null()
+	}
+	return If(Id("srv").Dot("metrics").Op("!=").Nil()).Block(
+		If(Err().Op(":=").Id("srv").Dot("initMetrics").Call().Op(";").Err().Op("!=").Nil()).Block(
+			Id("srv").Dot("log").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("metrics init")),
+		),
+	)
+}
+
+// renderObserveRequest renders function, which records request to metrics. Values of labels
+// are passed in order of metricsConfig.labels, values of header labels are taken from context.
+func (tr *Transport) renderObserveRequest(srcFile goFile) {
+
+	srcFile.Line().Func().Params(Id("cfg").Op("*").Id("metricsConfig")).Id("observeRequest").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("begin").Qual(packageTime, "Time"), Id("values").Op("...").String()).Block(
+		Line(),
+		If(List(Id("headers"), Id("ok")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("metricsHeadersKey").Values()).Assert(Index().String()).Op(";").Id("ok")).Block(
+			Id("values").Op("=").Append(Id("values"), Id("headers").Op("...")),
+		),
+		Id("labels").Op(":=").Id("cfg").Dot("labels").Call(),
+		For(Len(Id("values")).Op("<").Len(Id("labels"))).Block(
+			Id("values").Op("=").Append(Id("values"), Lit("")),
+		),
+		If(Id("cfg").Dot("meterProvider").Op("!=").Nil()).Block(
+			Id("attrs").Op(":=").Make(Index().Qual(packageAttributeOTEL, "KeyValue"), Lit(0), Len(Id("labels"))),
+			For(List(Id("i"), Id("label")).Op(":=").Range().Id("labels")).Block(
+				Id("attrs").Op("=").Append(Id("attrs"), Qual(packageAttributeOTEL, "String").Call(Id("label"), Id("values").Index(Id("i")))),
+			),
+			Id("options").Op(":=").Qual(packageMetricOTEL, "WithAttributes").Call(Id("attrs").Op("...")),
+			Id("cfg").Dot("requestCount").Dot("Add").Call(Id(_ctx_), Lit(1), Id("options")),
+			Id("cfg").Dot("requestCountAll").Dot("Add").Call(Id(_ctx_), Lit(1), Id("options")),
+			Id("cfg").Dot("requestLatency").Dot("Record").Call(Id(_ctx_), Qual(packageTime, "Since").Call(Id("begin")).Dot("Seconds").Call(), Id("options")),
+			Return(),
+		),
+		Id("cfg").Dot("count").Dot("WithLabelValues").Call(Id("values").Op("...")).Dot("Add").Call(Lit(1)),
+		Id("cfg").Dot("countAll").Dot("WithLabelValues").Call(Id("values").Op("...")).Dot("Add").Call(Lit(1)),
+		Id("cfg").Dot("latency").Dot("WithLabelValues").Call(Id("values").Op("...")).Dot("Observe").Call(Qual(packageTime, "Since").Call(Id("begin")).Dot("Seconds").Call()),
+	)
+}
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMetrics(t *testing.T) {
+
+	for _, backend := range []string{backendNetHTTP, backendFiber} {
+		t.Run(backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/metrics", backend)
+			assertContains(t, files, "metrics.go",
+				`var metricsArgLabels = []string{"limit", "status", "timeout"}`,
+				`func Metrics(options ...MetricsOption) Option {`,
+				`func registerCollector[C prometheus.Collector](collector C) (C, error) {`,
+				`if existing, ok := registered.ExistingCollector.(C); ok {`,
+				`VersionGauge, RequestCount, RequestCountAll, RequestLatency = cfg.versions, cfg.count, cfg.countAll, cfg.latency`,
+			)
+			assertNotContains(t, files, "metrics.go", "promauto")
+			assertContains(t, files, "server.go",
+				`srv.log.Warn().Msg("metrics already enabled")`,
+				`srv.log.Error().Err(err).Msg("metrics init")`,
+				`srv.httpFiles = srv.Files().WithMetrics(srv.metrics)`,
+			)
+			assertContains(t, files, "files-metrics.go",
+				`func metricsMiddlewareFiles(metrics *metricsConfig) MiddlewareFiles {`,
+				`m.metrics.observeRequest(ctx, _begin, "files", "find", strconv.FormatBool(success), strconv.Itoa(errCode), fmt.Sprint(limit), fmt.Sprint(status), fmt.Sprint(timeout))`,
+			)
+		})
+	}
+	t.Run("disabled", func(t *testing.T) {
+		files := renderServer(t, "testdata/versions", backendNetHTTP)
+		assertNotContains(t, files, "metrics.go", `func Metrics(`)
+	})
+}
+
+func TestCheckMetricsLabels(t *testing.T) {
+
+	tr, err := NewTransport(testLog(), "test", "testdata/metrics-labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tr.RenderServer(t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "Files.Find: argument 'filter' of metrics-labels must be string, integer or bool") {
+		t.Errorf("RenderServer() error = %v, want error of metrics-labels", err)
+	}
+}
@@ -0,0 +1,492 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) handlerParams() *Statement {
+
+	if tr.isNetHTTP() {
+		return List(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request"))
+	}
+	return Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")
+}
+
+func (tr *Transport) handlerArgs() *Statement {
+
+	if tr.isNetHTTP() {
+		return List(Id("w"), Id("r"))
+	}
+	return Id(_ctx_)
+}
+
+func (tr *Transport) userContext() *Statement {
+
+	if tr.isNetHTTP() {
+		return Id("r").Dot("Context").Call()
+	}
+	return Id(_ctx_).Dot("UserContext").Call()
+}
+
+func (tr *Transport) requestHeader(name Code) *Statement {
+
+	if tr.isNetHTTP() {
+		return Id("r").Dot("Header").Dot("Get").Call(name)
+	}
+	return Id(_ctx_).Dot("Get").Call(name)
+}
+
+func (tr *Transport) setCookie(cookie Code) *Statement {
+
+	if tr.isNetHTTP() {
+		return Qual(packageHttp, "SetCookie").Call(Id("w"), cookie)
+	}
+	return Id(_ctx_).Dot("Cookie").Call(cookie)
+}
+
+func (tr *Transport) renderNetHTTP(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(packageErrors, "errors")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportName(packageZeroLogLog, "log")
+
+	srcFile.Line().Const().Id("logLevelHeader").Op("=").Lit("X-Log-Level")
+
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("setLogger").Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler")).Block(
+		Return(Qual(packageHttp, "HandlerFunc").Call(Func().Params(tr.handlerParams()).Block(
+			Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r").Dot("WithContext").Call(Id("srv").Dot("log").Dot("WithContext").Call(Id("r").Dot("Context").Call()))),
+		))),
+	)
+	srcFile.Line().Func().Id("methodCallHandler").Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler")).Block(
+		Return(Qual(packageHttp, "HandlerFunc").Call(Func().Params(tr.handlerParams()).Block(
+			Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r").Dot("WithContext").Call(Id("withMethodCall").Call(Id("r").Dot("Context").Call()))),
+		))),
+	)
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("logLevelHandler").Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler")).Block(
+		Return(Qual(packageHttp, "HandlerFunc").Call(Func().Params(tr.handlerParams()).Block(
+			If(Id("levelName").Op(":=").Id("r").Dot("Header").Dot("Get").Call(Id("logLevelHeader")).Op(";").Id("levelName").Op("!=").Lit("")).Block(
+				If(List(Id("level"), Err()).Op(":=").Qual(packageZeroLog, "ParseLevel").Call(Id("levelName")).Op(";").Err().Op("==").Nil()).Block(
+					Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(Id("r").Dot("Context").Call()).Dot("Level").Call(Id("level")),
+					Id("r").Op("=").Id("r").Dot("WithContext").Call(Id("logger").Dot("WithContext").Call(Id("r").Dot("Context").Call())),
+				),
+			),
+			Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r")),
+		))),
+	)
+	srcFile.Line().Func().Id("recoverHandler").Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler")).Block(
+		Return(Qual(packageHttp, "HandlerFunc").Call(Func().Params(tr.handlerParams()).Block(
+			Defer().Func().Params().Block(
+				If(Id("rec").Op(":=").Recover().Op(";").Id("rec").Op("!=").Nil()).Block(
+					List(Err(), Id("ok")).Op(":=").Id("rec").Op(".").Call(Error()),
+					If(Op("!").Id("ok")).Block(
+						Err().Op("=").Qual(packageErrors, "New").Call(Qual(packageFmt, "Sprintf").Call(Lit("%v"), Id("rec"))),
+					),
+					Qual(packageZeroLogLog, "Ctx").Call(Id("r").Dot("Context").Call()).Dot("Error").Call().Dot("Stack").Call().Dot("Err").Call(Qual(packageErrors, "Wrap").Call(Err(), Lit("recover"))).
+						Dot("Str").Call(Lit("method"), Id("r").Dot("Method")).
+						Dot("Str").Call(Lit("path"), Id("r").Dot("URL").Dot("RequestURI").Call()).
+						Dot("Msg").Call(Lit("panic occurred")),
+					Id("w").Dot("WriteHeader").Call(Qual(packageHttp, "StatusInternalServerError")),
+				),
+			).Call(),
+			Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r")),
+		))),
+	)
+	return srcFile.Save(path.Join(outDir, "nethttp.go"))
+}
+
+func (tr *Transport) renderServerNetHTTP(outDir string) (err error) {
+
+	if tr.hasTrace() {
+		if err = pkgCopyTo("tracer", outDir); err != nil {
+			return
+		}
+	}
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(packageZeroLogLog, "log")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportName(packagePrometheus, "prometheus")
+	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
+	if tr.hasTrace() {
+		srcFile.ImportName(fmt.Sprintf("%s/tracer", tr.pkgPath(outDir)), "tracer")
+	}
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+	}
+
+	srcFile.Line().Add(tr.serverTypeNetHTTP())
+	srcFile.Line().Add(tr.serverNewFuncNetHTTP(outDir))
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("Handler").Params().Params(Qual(packageHttp, "Handler")).Block(
+		Return(Id("srv").Dot("handler")),
+	)
+	srcFile.Line().Add(tr.listenFuncNetHTTP())
+	srcFile.Line().Add(tr.withLogFunc())
+	srcFile.Line().Add(tr.serveHealthFuncNetHTTP())
+	srcFile.Line().Add(tr.healthFunc())
+	srcFile.Line().Add(tr.serveAdminFunc())
+	srcFile.Line().Add(tr.sendResponseFuncNetHTTP())
+	srcFile.Line().Add(tr.shutdownFuncNetHTTP())
+	if tr.hasTrace() {
+		srcFile.Line().Add(tr.withTraceFunc(outDir))
+		srcFile.Line().Add(tr.withTraceOptionsFunc(outDir))
+	}
+	if tr.hasMetrics() {
+		srcFile.Line().Add(tr.withMetricsFunc())
+		srcFile.Line().Add(tr.initMetricsFunc())
+	}
+	if tr.hasAudit() {
+		srcFile.Line().Add(tr.withAuditFunc())
+	}
+	for _, serviceName := range tr.serviceKeys() {
+		srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id(serviceName).Params().Params(Op("*").Id("http" + serviceName)).Block(
+			Return(Id("srv").Dot("http" + serviceName)),
+		)
+	}
+	return srcFile.Save(path.Join(outDir, "server.go"))
+}
+
+func (tr *Transport) serverTypeNetHTTP() Code {
+
+	return Type().Id("Server").StructFunc(func(g *Group) {
+		g.Id("log").Qual(packageZeroLog, "Logger")
+		g.Line().Id("middlewares").Op("[]").Id("Handler")
+		g.Line().Id("config").Op("*").Qual(packageHttp, "Server")
+		g.Id("maxBodySize").Int64()
+		g.Line().Id("mux").Op("*").Qual(packageHttp, "ServeMux")
+		g.Id("handler").Qual(packageHttp, "Handler")
+		g.Line().Id("srvHTTP").Op("*").Qual(packageHttp, "Server")
+		g.Id("srvHealth").Op("*").Qual(packageHttp, "Server")
+		g.Id("srvMetrics").Op("*").Qual(packageHttp, "Server")
+		g.Id("srvAdmin").Op("*").Qual(packageHttp, "Server")
+		g.Id("health").Op("*").Id("HealthChecker")
+		g.Id("shutdownHooks").Index().Id("ShutdownHook")
+		g.Id("drainDelay").Qual(packageTime, "Duration")
+		if tr.hasMetrics() {
+			g.Id("metrics").Op("*").Id("metricsConfig")
+		}
+		if tr.hasAudit() {
+			g.Id("audit").Op("*").Id("auditConfig")
+		}
+		if tr.hasTrace() {
+			g.Line().Id("traceShutdown").Func().Params(Qual(packageContext, "Context")).Error()
+		}
+		if tr.hasJsonRPC {
+			g.Line().Id("maxBatchSize").Int()
+			g.Id("maxParallelBatch").Int().Line()
+		}
+		for _, serviceName := range tr.serviceKeys() {
+			g.Id("http" + serviceName).Op("*").Id("http" + serviceName)
+		}
+		g.Id("headerHandlers").Map(String()).Id("HeaderHandler")
+	})
+}
+
+func (tr *Transport) serverNewFuncNetHTTP(outDir string) Code {
+
+	return Func().Id("New").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("options").Op("...").Id("Option")).Params(Id("srv").Op("*").Id("Server")).
+		BlockFunc(func(bg *Group) {
+			bg.Line().Id("srv").Op("=").Op("&").Id("Server").Values(DictFunc(func(dict Dict) {
+				dict[Id("log")] = Id("log")
+				if tr.hasJsonRPC {
+					dict[Id("maxBatchSize")] = Id("defaultMaxBatchSize")
+					dict[Id("maxParallelBatch")] = Id("defaultMaxParallelBatch")
+				}
+				dict[Id("headerHandlers")] = Make(Map(String()).Id("HeaderHandler"))
+				dict[Id("health")] = Op("&").Id("HealthChecker").Values()
+				dict[Id("config")] = Op("&").Qual(packageHttp, "Server").Values()
+			}))
+			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+				Id("option").Call(Id("srv")),
+			)
+			bg.Id("srv").Dot("mux").Op("=").Qual(packageHttp, "NewServeMux").Call()
+			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+				Id("option").Call(Id("srv")),
+			)
+			bg.Add(tr.metricsInit())
+			if tr.hasJsonRPC {
+				bg.Id("srv").Dot("mux").Dot("HandleFunc").Call(Lit(tr.batchPatternNetHTTP()), Id("srv").Dot("serveBatch"))
+			}
+			bg.Var().Id("handler").Qual(packageHttp, "Handler").Op("=").Id("srv").Dot("mux")
+			bg.For(Id("i").Op(":=").Len(Id("srv").Dot("middlewares")).Op("-").Lit(1).Op(";").Id("i").Op(">=").Lit(0).Op(";").Id("i").Op("--")).Block(
+				Id("handler").Op("=").Id("srv").Dot("middlewares").Index(Id("i")).Call(Id("handler")),
+			)
+			bg.Id("handler").Op("=").Id("methodCallHandler").Call(Id("handler"))
+			bg.Id("handler").Op("=").Id("srv").Dot("headersHandler").Call(Id("handler"))
+			bg.Id("handler").Op("=").Id("srv").Dot("logLevelHandler").Call(Id("handler"))
+			bg.Id("handler").Op("=").Id("srv").Dot("setLogger").Call(Id("handler"))
+			if tr.hasTrace() {
+				bg.Id("handler").Op("=").Qual(fmt.Sprintf("%s/tracer", tr.pkgPath(outDir)), "HTTPMiddleware").Call().Call(Id("handler"))
+			}
+			bg.Id("handler").Op("=").Id("recoverHandler").Call(Id("handler"))
+			bg.If(Id("srv").Dot("maxBodySize").Op(">").Lit(0)).Block(
+				Id("handler").Op("=").Qual(packageHttp, "MaxBytesHandler").Call(Id("handler"), Id("srv").Dot("maxBodySize")),
+			)
+			bg.Id("srv").Dot("handler").Op("=").Id("handler")
+			bg.Return()
+		})
+}
+
+func (tr *Transport) batchPatternNetHTTP() string {
+
+	batchPath := path.Join("/", tr.tags.Value(tagHttpPrefix, ""))
+	if batchPath == "/" {
+		batchPath = "/{$}"
+	}
+	return "POST " + batchPath
+}
+
+func (tr *Transport) listenFuncNetHTTP() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ListenAndServe").Params(Id("address").String()).Params(Err().Error()).Block(
+		Line().Id("srv").Dot("srvHTTP").Op("=").Id("srv").Dot("config"),
+		Id("srv").Dot("srvHTTP").Dot("Addr").Op("=").Id("address"),
+		Id("srv").Dot("srvHTTP").Dot("Handler").Op("=").Id("srv").Dot("handler"),
+		If(Err().Op("=").Id("srv").Dot("srvHTTP").Dot("ListenAndServe").Call().Op(";").Err().Op("==").Qual(packageHttp, "ErrServerClosed")).Block(
+			Err().Op("=").Nil(),
+		),
+		Return(),
+	).Line().Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).Id("ListenAndServeTLS").Params(Id("address"), Id("certFile"), Id("keyFile").String()).Params(Err().Error()).Block(
+		Line().Id("srv").Dot("srvHTTP").Op("=").Id("srv").Dot("config"),
+		Id("srv").Dot("srvHTTP").Dot("Addr").Op("=").Id("address"),
+		Id("srv").Dot("srvHTTP").Dot("Handler").Op("=").Id("srv").Dot("handler"),
+		If(Err().Op("=").Id("srv").Dot("srvHTTP").Dot("ListenAndServeTLS").Call(Id("certFile"), Id("keyFile")).Op(";").Err().Op("==").Qual(packageHttp, "ErrServerClosed")).Block(
+			Err().Op("=").Nil(),
+		),
+		Return(),
+	)
+}
+
+func (tr *Transport) serveHealthFuncNetHTTP() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeHealth").Params(Id("address").String(), Id("response").Interface()).Block(
+
+		Id("mux").Op(":=").Qual(packageHttp, "NewServeMux").Call(),
+		Id("mux").Dot("HandleFunc").Call(Lit("GET /health"),
+			Func().Params(tr.handlerParams()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("response")),
+			)),
+		Id("mux").Dot("HandleFunc").Call(Lit("GET /livez"),
+			Func().Params(tr.handlerParams()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("HealthStatus").Values(Dict{Id("Status"): Id("healthOK")})),
+			)),
+		Id("mux").Dot("HandleFunc").Call(Lit("GET /readyz"),
+			Func().Params(tr.handlerParams()).Block(
+				List(Id("status"), Id("ready")).Op(":=").Id("srv").Dot("health").Dot("Check").Call(Id("r").Dot("Context").Call()),
+				Id("statusCode").Op(":=").Qual(packageHttp, "StatusOK"),
+				If(Op("!").Id("ready")).Block(
+					Id("statusCode").Op("=").Qual(packageHttp, "StatusServiceUnavailable"),
+				),
+				Id("sendResponse").Call(Id("w"), Id("r"), Id("statusCode"), Id("status")),
+			)),
+		Id("srv").Dot("srvHealth").Op("=").Op("&").Qual(packageHttp, "Server").Values(Dict{
+			Id("Addr"):    Id("address"),
+			Id("Handler"): Id("mux"),
+		}),
+		Go().Func().Params().Block(
+			If(Err().Op(":=").Id("srv").Dot("srvHealth").Dot("ListenAndServe").Call().Op(";").Err().Op("!=").Qual(packageHttp, "ErrServerClosed")).Block(
+				Id("ExitOnError").Call(Id("srv").Dot("log"), Err(), Lit("serve health on ").Op("+").Id("address")),
+			),
+		).Call(),
+	)
+}
+
+func (tr *Transport) shutdownFuncNetHTTP() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Shutdown").Params().Block(
+		If(Err().Op(":=").Id("srv").Dot("ShutdownWithContext").Call(Qual(packageContext, "Background").Call()).Op(";").Err().Op("!=").Nil()).Block(
+			Id("srv").Dot("log").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("shutdown")),
+		),
+	)
+}
+
+func (tr *Transport) sendResponseFuncNetHTTP() Code {
+
+	return Func().Id("sendResponse").Params(tr.handlerParams(), Id("statusCode").Int(), Id("resp").Interface()).Block(
+		Id("w").Dot("Header").Call().Dot("Set").Call(Lit("Content-Type"), Lit("application/json")),
+		Id("w").Dot("WriteHeader").Call(Id("statusCode")),
+		If(Err().Op(":=").Qual(tr.tags.Value(tagPackageJSON, packageStdJSON), "NewEncoder").Call(Id("w")).Dot("Encode").Call(Id("resp")).Op(";").Err().Op("!=").Nil()).Block(
+			Qual(packageZeroLogLog, "Ctx").Call(Id("r").Dot("Context").Call()).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("response write error")),
+		),
+	)
+}
+
+func (tr *Transport) renderOptionsNetHTTP(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageHttp, "http")
+
+	srcFile.Line().Type().Id("ServiceRoute").Interface(
+		Id("SetRoutes").Params(Id("mux").Op("*").Qual(packageHttp, "ServeMux")),
+	)
+
+	srcFile.Line().Type().Id("Option").Func().Params(Id("srv").Op("*").Id("Server"))
+	srcFile.Type().Id("Handler").Op("=").Func().Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler"))
+	srcFile.Type().Id("ErrorHandler").Func().Params(Err().Error()).Params(Error())
+
+	srcFile.Line().Func().Id("Service").Params(Id("svc").Id("ServiceRoute")).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			If(Id("srv").Dot("mux").Op("!=").Nil()).Block(
+				Id("svc").Dot("SetRoutes").Call(Id("srv").Dot("mux")),
+			),
+		)),
+	)
+	for _, serviceName := range tr.serviceKeys() {
+		srcFile.Line().Func().Id(serviceName).Params(Id("svc").Op("*").Id("http" + serviceName)).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				If(Id("srv").Dot("mux").Op("!=").Nil()).BlockFunc(func(gr *Group) {
+					gr.Id("srv").Dot("http" + serviceName).Op("=").Id("svc")
+					if tr.hasJsonRPC {
+						gr.Id("svc").Dot("maxBatchSize").Op("=").Id("srv").Dot("maxBatchSize")
+						gr.Id("svc").Dot("maxParallelBatch").Op("=").Id("srv").Dot("maxParallelBatch")
+					}
+					gr.Id("svc").Dot("SetRoutes").Call(Id("srv").Dot("mux"))
+				}),
+			)),
+		)
+	}
+	srcFile.Line().Func().Id("SetServerCfg").Params(Id("cfg").Op("*").Qual(packageHttp, "Server")).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			Id("srv").Dot("config").Op("=").Id("cfg"),
+		)),
+	)
+	srcFile.Line().Func().Id("MaxBodySize").Params(Id("max").Int()).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			Id("srv").Dot("maxBodySize").Op("=").Int64().Call(Id("max")),
+		)),
+	)
+	if tr.hasJsonRPC {
+		srcFile.Line().Add(tr.maxBatchSizeFunc())
+		srcFile.Line().Add(tr.maxBatchWorkersFunc())
+	}
+	srcFile.Line().Func().Id("ReadTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			Id("srv").Dot("config").Dot("ReadTimeout").Op("=").Id("timeout"),
+		)),
+	)
+	srcFile.Line().Func().Id("WriteTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			Id("srv").Dot("config").Dot("WriteTimeout").Op("=").Id("timeout"),
+		)),
+	)
+	srcFile.Line().Add(tr.withRequestIDFunc())
+	srcFile.Line().Add(tr.withHeaderFunc())
+	srcFile.Line().Func().Id("Use").Params(Id("handlers").Op("...").Id("Handler")).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			If(Id("srv").Dot("mux").Op("!=").Nil()).Block(
+				Id("srv").Dot("middlewares").Op("=").Append(Id("srv").Dot("middlewares"), Id("handlers").Op("...")),
+			),
+		)),
+	)
+	return srcFile.Save(path.Join(outDir, "options.go"))
+}
+
+func (tr *Transport) headersHandlerNetHTTP() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("headersHandler").Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler")).Block(
+		Return(Qual(packageHttp, "HandlerFunc").Call(Func().Params(tr.handlerParams()).Block(
+			Id(_ctx_).Op(":=").Id("r").Dot("Context").Call(),
+			For(List(Id("headerName"), Id("handler")).Op(":=").Range().Id("srv").Dot("headerHandlers")).BlockFunc(func(fg *Group) {
+				fg.Id("header").Op(":=").Id("handler").Call(Id("r").Dot("Header").Dot("Get").Call(Id("headerName")))
+				if tr.hasTrace() {
+					fg.Add(tr.headerSpanAttribute(Id(_ctx_)))
+				}
+				fg.If(Id("header").Dot("RequestValue").Op("!=").Nil()).Block(
+					Id("r").Dot("Header").Dot("Set").Call(Id("header").Dot("RequestKey"), Id("headerValue").Call(Id("header").Dot("RequestValue"))),
+				)
+				fg.If(Id("header").Dot("ResponseValue").Op("!=").Nil()).Block(
+					Id("w").Dot("Header").Call().Dot("Set").Call(Id("header").Dot("ResponseKey"), Id("headerValue").Call(Id("header").Dot("ResponseValue"))),
+				)
+				fg.If(Id("header").Dot("LogValue").Op("!=").Nil()).Block(
+					Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_)).
+						Dot("With").Call().Dot("Interface").Call(Id("header").Dot("LogKey"), Id("header").Dot("LogValue")).Dot("Logger").Call(),
+					Id(_ctx_).Op("=").Id("logger").Dot("WithContext").Call(Id(_ctx_)),
+				)
+				fg.If(Id("header").Dot("ContextValue").Op("!=").Nil()).Block(
+					Id(_ctx_).Op("=").Qual(packageContext, "WithValue").Call(Id(_ctx_), Id("header").Dot("ContextKey"), Id("header").Dot("ContextValue")),
+				)
+			}),
+			tr.auditActor(),
+			tr.metricsHeaderValues(),
+			Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r").Dot("WithContext").Call(Id(_ctx_))),
+		))),
+	)
+}
+
+func (tr *Transport) auditActor() Code {
+
+	if !tr.hasAudit() {
+		return Null()
+	}
+	return Id(_ctx_).Op("=").Id("srv").Dot("audit").Dot("withActor").Call(Id(_ctx_), Id("r").Dot("Header").Dot("Get"))
+}
+
+func (tr *Transport) metricsHeaderValues() Code {
+
+	if !tr.hasMetrics() {
+		return Null()
+	}
+	return Id(_ctx_).Op("=").Id("srv").Dot("metrics").Dot("withHeaderValues").Call(Id(_ctx_), Id("r").Dot("Header").Dot("Get"))
+}
+
+func (tr *Transport) serveMetricsFuncNetHTTP() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeMetrics").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("path").String(), Id("address").String()).Block(
+		Id("mux").Op(":=").Qual(packageHttp, "NewServeMux").Call(),
+		Id("mux").Dot("Handle").Call(Id("path"), Qual(packagePrometheusHttp, "Handler").Call()),
+		Id("srv").Dot("srvMetrics").Op("=").Op("&").Qual(packageHttp, "Server").Values(Dict{
+			Id("Addr"):    Id("address"),
+			Id("Handler"): Id("mux"),
+		}),
+		Go().Func().Params().Block(
+			If(Err().Op(":=").Id("srv").Dot("srvMetrics").Dot("ListenAndServe").Call().Op(";").Err().Op("!=").Qual(packageHttp, "ErrServerClosed")).Block(
+				Id("ExitOnError").Call(Id("log"), Err(), Lit("serve metrics on ").Op("+").Id("address")),
+			),
+		).Call(),
+	)
+}
+
+func serveBatchFuncNetHTTP(recv *Statement, recvName string, deprecated map[string]string, deprecatedName, packageJSON string) Code {
+
+	return Func().Params(recv).Id("serveBatch").
+		Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request")).BlockFunc(
+		func(bg *Group) {
+			bg.Line()
+			bg.Var().Err().Error()
+			bg.Var().Id("body").Op("[]").Byte()
+			bg.Var().Id("single").Bool()
+			bg.Var().Id("requests").Op("[]").Id("baseJsonRPC")
+			bg.If(List(Id("body"), Err()).Op("=").Qual(packageIO, "ReadAll").Call(Id("r").Dot("Body")).Op(";").Err().Op("!=").Nil()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("makeErrorResponseJsonRPC").Call(Op("[]").Byte().Call(Lit(`"0"`)), Id("parseError"), Lit("request body could not be read: ").Op("+").Err().Dot("Error").Call(), Nil())),
+				Return(),
+			)
+			bg.If(Err().Op("=").Qual(packageJSON, "Unmarshal").Call(Id("body"), Op("&").Id("requests")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				ig.Var().Id("request").Id("baseJsonRPC")
+				ig.If(Err().Op("=").Qual(packageJSON, "Unmarshal").Call(Id("body"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+					Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("makeErrorResponseJsonRPC").Call(Op("[]").Byte().Call(Lit(`"0"`)), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil())),
+					Return(),
+				)
+				ig.Id("single").Op("=").True()
+				ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
+			})
+			bg.Add(deprecationJsonRPC(Id("w"), deprecatedName, deprecated))
+			bg.If(Id("single")).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id(recvName).Dot("doSingleBatch").Call(Id("w"), Id("r"), Id("requests").Index(Lit(0)))),
+				Return(),
+			)
+			bg.Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id(recvName).Dot("doBatch").Call(Id("w"), Id("r"), Id("requests")))
+		})
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetBackend(t *testing.T) {
+
+	tests := []struct {
+		backend string
+		want    string
+		err     string
+	}{
+		{backend: "", want: backendFiber},
+		{backend: backendFiber, want: backendFiber},
+		{backend: backendNetHTTP, want: backendNetHTTP},
+		{backend: "gin", err: "unknown backend 'gin' (supported: fiber, nethttp)"},
+	}
+	for _, test := range tests {
+		var tr Transport
+		err := tr.SetBackend(test.backend)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("backend %q: error %v, want %q", test.backend, err, test.err)
+			}
+			continue
+		}
+		if err != nil || tr.backend != test.want {
+			t.Errorf("backend %q: %s, error %v, want %s", test.backend, tr.backend, err, test.want)
+		}
+	}
+}
+
+func TestRenderNetHTTP(t *testing.T) {
+
+	files := renderServer(t, "testdata/files", backendNetHTTP)
+	for fileName, content := range files {
+		if strings.Contains(content, "github.com/gofiber/fiber") {
+			t.Errorf("%s imports fiber", fileName)
+		}
+	}
+	assertContains(t, files, "server.go",
+		`func (srv *Server) Handler() http.Handler {`,
+		`func (srv *Server) ListenAndServe(address string) (err error) {`,
+		`func (srv *Server) ListenAndServeTLS(address, certFile, keyFile string) (err error) {`,
+	)
+	assertContains(t, files, "nethttp.go",
+		`func recoverHandler(next http.Handler) http.Handler {`,
+		`if levelName := r.Header.Get(logLevelHeader); levelName != "" {`,
+	)
+	if _, found := files["fiber.go"]; found {
+		t.Error("fiber.go is generated for nethttp backend")
+	}
+}
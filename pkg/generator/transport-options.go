@@ -12,6 +12,9 @@ import (
 
 func (tr *Transport) renderOptions(outDir string) (err error) {
 
+	if tr.isNetHTTP() {
+		return tr.renderOptionsNetHTTP(outDir)
+	}
 	srcFile := newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
@@ -68,16 +71,8 @@ func (tr *Transport) renderOptions(outDir string) (err error) {
 		)),
 	)
 	if tr.hasJsonRPC {
-		srcFile.Line().Func().Id("MaxBatchSize").Params(Id("size").Int()).Id("Option").Block(
-			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-				Id("srv").Dot("maxBatchSize").Op("=").Id("size"),
-			)),
-		)
-		srcFile.Line().Func().Id("MaxBatchWorkers").Params(Id("size").Int()).Id("Option").Block(
-			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-				Id("srv").Dot("maxParallelBatch").Op("=").Id("size"),
-			)),
-		)
+		srcFile.Line().Add(tr.maxBatchSizeFunc())
+		srcFile.Line().Add(tr.maxBatchWorkersFunc())
 	}
 	srcFile.Line().Func().Id("ReadTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
@@ -89,7 +84,39 @@ func (tr *Transport) renderOptions(outDir string) (err error) {
 			Id("srv").Dot("config").Dot("WriteTimeout").Op("=").Id("timeout"),
 		)),
 	)
-	srcFile.Line().Func().Id("WithRequestID").Params(Id("headerName").String()).Id("Option").Block(
+	srcFile.Line().Add(tr.withRequestIDFunc())
+	srcFile.Line().Add(tr.withHeaderFunc())
+	srcFile.Line().Func().Id("Use").Params(Id("args").Op("...").Interface()).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
+				Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("args").Op("...")),
+			),
+		)),
+	)
+	return srcFile.Save(path.Join(outDir, "options.go"))
+}
+
+func (tr *Transport) maxBatchSizeFunc() Code {
+
+	return Func().Id("MaxBatchSize").Params(Id("size").Int()).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			Id("srv").Dot("maxBatchSize").Op("=").Id("size"),
+		)),
+	)
+}
+
+func (tr *Transport) maxBatchWorkersFunc() Code {
+
+	return Func().Id("MaxBatchWorkers").Params(Id("size").Int()).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			Id("srv").Dot("maxParallelBatch").Op("=").Id("size"),
+		)),
+	)
+}
+
+func (tr *Transport) withRequestIDFunc() Code {
+
+	return Func().Id("WithRequestID").Params(Id("headerName").String()).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
 			Id("srv").Dot("headerHandlers").Op("[").Id("headerName").Op("]").Op("=").
 				Func().Params(Id("value").String()).Params(Id("Header")).Block(
@@ -103,21 +130,19 @@ func (tr *Transport) renderOptions(outDir string) (err error) {
 					Id("ResponseValue"): Id("value"),
 					Id("LogKey"):        Lit("requestID"),
 					Id("LogValue"):      Id("value"),
+					Id("ContextKey"):    Id("requestIDKey").Values(),
+					Id("ContextValue"):  Id("value"),
 				})),
 			),
 		)),
 	)
-	srcFile.Line().Func().Id("WithHeader").Params(Id("headerName").String(), Id("handler").Id("HeaderHandler")).Id("Option").Block(
+}
+
+func (tr *Transport) withHeaderFunc() Code {
+
+	return Func().Id("WithHeader").Params(Id("headerName").String(), Id("handler").Id("HeaderHandler")).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
 			Id("srv").Dot("headerHandlers").Op("[").Id("headerName").Op("]").Op("=").Id("handler"),
 		)),
 	)
-	srcFile.Line().Func().Id("Use").Params(Id("args").Op("...").Interface()).Id("Option").Block(
-		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
-				Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("args").Op("...")),
-			),
-		)),
-	)
-	return srcFile.Save(path.Join(outDir, "options.go"))
 }
@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"path/filepath"
+	"sort"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// redactRule is log-redact annotation of type or of its field, when field is set.
+type redactRule struct {
+	pkgPath  string
+	typeName string
+	field    string
+	policy   string
+}
+
+// renderRedact registers redaction policies of annotated types in viewer. Client gets redactor of request dumps.
+func (tr *Transport) renderRedact(outDir string, client bool) (err error) {
+
+	rules := tr.redactRules()
+	if len(rules) == 0 && !client {
+		return
+	}
+	if err = pkgCopyTo("viewer", outDir); err != nil {
+		return err
+	}
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	pkgViewer := fmt.Sprintf("%s/viewer", tr.pkgPath(outDir))
+	srcFile.ImportName(pkgViewer, "viewer")
+
+	if len(rules) != 0 {
+		srcFile.Line().Func().Id("init").Params().BlockFunc(func(g *Group) {
+			for _, rule := range rules {
+				typeOf := Qual("reflect", "TypeFor").Index(Qual(rule.pkgPath, rule.typeName)).Call()
+				if rule.field == "" {
+					g.Qual(pkgViewer, "RedactType").Call(typeOf, Lit(rule.policy))
+					continue
+				}
+				g.Qual(pkgViewer, "RedactTypeField").Call(typeOf, Lit(rule.field), Lit(rule.policy))
+			}
+		})
+	}
+	if client {
+		srcFile.Line().Comment("redactor masks requests, dumped by LogRequest and LogOnError, by policies of viewer.")
+		srcFile.Type().Id("redactor").Struct()
+		srcFile.Line().Func().Params(Id("redactor")).Id("RedactHeader").Params(List(Id("name"), Id("value")).String()).Params(String()).Block(
+			Return(Qual(pkgViewer, "RedactHeader").Call(Id("name"), Id("value"))),
+		)
+		srcFile.Line().Func().Params(Id("redactor")).Id("RedactJSON").Params(Id("data").Index().Byte()).Params(Index().Byte()).Block(
+			Return(Qual(pkgViewer, "RedactJSON").Call(Id("data"))),
+		)
+	}
+	return srcFile.Save(path.Join(outDir, "redact.go"))
+}
+
+// redactRules returns log-redact annotations of types, used by arguments and results of methods.
+func (tr *Transport) redactRules() (rules []redactRule) {
+
+	visited := make(map[string]bool)
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		for _, method := range svc.methods {
+			for _, variable := range append(method.argsWithoutContext(), method.resultsWithoutError()...) {
+				rules = append(rules, tr.walkRedact(svc.pkgPath, variable.Type, visited)...)
+			}
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.pkgPath+"."+a.typeName != b.pkgPath+"."+b.typeName {
+			return a.pkgPath+"."+a.typeName < b.pkgPath+"."+b.typeName
+		}
+		return a.field < b.field
+	})
+	return
+}
+
+func (tr *Transport) walkRedact(pkgPath string, varType types.Type, visited map[string]bool) (rules []redactRule) {
+
+	switch vType := varType.(type) {
+	case types.TName:
+		if types.IsBuiltin(vType) {
+			return
+		}
+		return tr.walkRedactNamed(pkgPath, vType.TypeName, visited)
+	case types.TImport:
+		return tr.walkRedactNamed(vType.Import.Package, vType.Next.String(), visited)
+	case types.TPointer:
+		return tr.walkRedact(pkgPath, vType.Next, visited)
+	case types.TArray:
+		return tr.walkRedact(pkgPath, vType.Next, visited)
+	case types.TEllipsis:
+		return tr.walkRedact(pkgPath, vType.Next, visited)
+	case types.TMap:
+		return tr.walkRedact(pkgPath, vType.Value, visited)
+	}
+	return
+}
+
+func (tr *Transport) walkRedactNamed(pkgPath, typeName string, visited map[string]bool) (rules []redactRule) {
+
+	if visited[pkgPath+"."+typeName] || !ast.IsExported(typeName) {
+		return
+	}
+	visited[pkgPath+"."+typeName] = true
+	nextType := searchType(pkgPath, typeName)
+	if nextType == nil {
+		return
+	}
+	structType, isStruct := nextType.(types.Struct)
+	if !isStruct {
+		if policy := tags.ParseTags(searchTypeDocs(pkgPath, typeName)).Value(tagLogRedact); policy != "" {
+			rules = append(rules, redactRule{pkgPath: pkgPath, typeName: typeName, policy: policy})
+		}
+		return append(rules, tr.walkRedact(pkgPath, nextType, visited)...)
+	}
+	if policy := tags.ParseTags(structType.Docs).Value(tagLogRedact); policy != "" {
+		rules = append(rules, redactRule{pkgPath: pkgPath, typeName: typeName, policy: policy})
+	}
+	for _, field := range structType.Fields {
+		if policy := tags.ParseTags(field.Docs).Value(tagLogRedact); policy != "" {
+			rules = append(rules, redactRule{pkgPath: pkgPath, typeName: typeName, field: field.Name, policy: policy})
+		}
+		rules = append(rules, tr.walkRedact(pkgPath, field.Type, visited)...)
+	}
+	return
+}
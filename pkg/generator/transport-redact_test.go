@@ -0,0 +1,17 @@
+package generator
+
+import "testing"
+
+func TestRenderRedact(t *testing.T) {
+
+	files := renderServer(t, "testdata/redact", backendNetHTTP)
+	assertContains(t, files, "redact.go",
+		`viewer.RedactType(reflect.TypeFor[types.Card](), "card")`,
+		`viewer.RedactType(reflect.TypeFor[types.Password](), "drop")`,
+		`viewer.RedactTypeField(reflect.TypeFor[types.User](), "Email", "email")`,
+	)
+	assertContains(t, files, "users-exchange.go", `dumper:"jwt"`)
+	if _, found := files["viewer/redact_test.go"]; found {
+		t.Error("tests of viewer are copied to transport")
+	}
+}
@@ -7,12 +7,18 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"strconv"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
 )
 
 func (tr *Transport) renderServer(outDir string) (err error) {
 
+	if tr.isNetHTTP() {
+		return tr.renderServerNetHTTP(outDir)
+	}
 	if tr.hasTrace() {
 		if err = pkgCopyTo("tracer", outDir); err != nil {
 			return
@@ -26,7 +32,6 @@ func (tr *Transport) renderServer(outDir string) (err error) {
 	srcFile.ImportName(packageZeroLogLog, "log")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(packagePrometheus, "prometheus")
-	srcFile.ImportName(packagePrometheusAuto, "promauto")
 	srcFile.ImportName(packagePrometheusHttp, "promhttp")
 	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
 	if tr.hasTrace() {
@@ -41,15 +46,25 @@ func (tr *Transport) renderServer(outDir string) (err error) {
 	srcFile.Line().Add(tr.serverType())
 	srcFile.Line().Add(tr.serverNewFunc(outDir))
 	srcFile.Line().Add(tr.fiberFunc())
+	if len(tr.customHTTPMethods()) != 0 {
+		srcFile.Line().Add(tr.withMethodsFunc())
+	}
 	srcFile.Line().Add(tr.withLogFunc())
 	srcFile.Line().Add(tr.serveHealthFunc())
+	srcFile.Line().Add(tr.healthFunc())
+	srcFile.Line().Add(tr.serveAdminFunc())
 	srcFile.Line().Add(tr.sendResponseFunc())
 	srcFile.Line().Add(tr.shutdownFunc())
 	if tr.hasTrace() {
 		srcFile.Line().Add(tr.withTraceFunc(outDir))
+		srcFile.Line().Add(tr.withTraceOptionsFunc(outDir))
 	}
 	if tr.hasMetrics() {
 		srcFile.Line().Add(tr.withMetricsFunc())
+		srcFile.Line().Add(tr.initMetricsFunc())
+	}
+	if tr.hasAudit() {
+		srcFile.Line().Add(tr.withAuditFunc())
 	}
 	for _, serviceName := range tr.serviceKeys() {
 		srcFile.Line().Add(Func().Params(Id("srv").Op("*").Id("Server")).Id(serviceName).Params().Params(Op("*").Id("http" + serviceName)).Block(
@@ -60,6 +75,46 @@ func (tr *Transport) renderServer(outDir string) (err error) {
 	return srcFile.Save(path.Join(outDir, "server.go"))
 }
 
+// customHTTPMethods returns verbs of http-method tags unknown to fiber by default.
+func (tr *Transport) customHTTPMethods() (methods []Code) {
+
+	known := make(map[string]bool)
+	for _, serviceName := range tr.serviceKeys() {
+		for _, method := range tr.services[serviceName].methods {
+			if !method.isHTTP() {
+				continue
+			}
+			for _, verb := range method.httpMethods() {
+				if !isStandardHTTPMethod(verb) && !known[verb] {
+					known[verb] = true
+					methods = append(methods, Lit(verb))
+				}
+			}
+		}
+	}
+	return
+}
+
+func (tr *Transport) withMethodsFunc() Code {
+
+	return Func().Id("withMethods").Params(Id("methods").Index().String(), Id("custom").Op("...").String()).Params(Index().String()).BlockFunc(func(bg *Group) {
+		bg.If(Len(Id("methods")).Op("==").Lit(0)).Block(
+			Id("methods").Op("=").Qual(packageFiber, "DefaultMethods"),
+		)
+		bg.Id("methods").Op("=").Append(Index().String().Values(), Id("methods").Op("..."))
+		bg.Id("known").Op(":=").Make(Map(String()).Bool())
+		bg.For(List(Id("_"), Id("method")).Op(":=").Range().Id("methods")).Block(
+			Id("known").Index(Id("method")).Op("=").True(),
+		)
+		bg.For(List(Id("_"), Id("method")).Op(":=").Range().Id("custom")).Block(
+			If(Op("!").Id("known").Index(Id("method"))).Block(
+				Id("methods").Op("=").Append(Id("methods"), Id("method")),
+			),
+		)
+		bg.Return(Id("methods"))
+	})
+}
+
 func (tr *Transport) fiberFunc() Code {
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Fiber").Params().Params(Op("*").Qual(packageFiber, "App")).Block(
 		Return(Id("srv").Dot("srvHTTP")),
@@ -81,11 +136,33 @@ func (tr *Transport) withLogFunc() Code {
 
 func (tr *Transport) withTraceFunc(outDir string) Code {
 
+	pkgTracer := fmt.Sprintf("%s/tracer", tr.pkgPath(outDir))
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("WithTrace").
 		Params(Id(_ctx_).Qual(packageContext, "Context"), Id("appName").String(), Id("endpoint").String(), Id("attributes").Op("...").Qual(packageAttributeOTEL, "KeyValue")).
+		Params(Op("*").Id("Server")).Block(
+		Return(Id("srv").Dot("WithTraceOptions").Call(Id(_ctx_), Id("appName"), Qual(pkgTracer, "WithEndpoint").Call(Id("endpoint")), Qual(pkgTracer, "WithAttributes").Call(Id("attributes").Op("...")))),
+	)
+}
+
+func (tr *Transport) withTraceOptionsFunc(outDir string) Code {
+
+	pkgTracer := fmt.Sprintf("%s/tracer", tr.pkgPath(outDir))
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("WithTraceOptions").
+		Params(Id(_ctx_).Qual(packageContext, "Context"), Id("appName").String(), Id("options").Op("...").Qual(pkgTracer, "InitOption")).
 		Params(Op("*").Id("Server")).BlockFunc(func(bg *Group) {
 
-		bg.Line().Qual(fmt.Sprintf("%s/tracer", tr.pkgPath(outDir)), "Init").Call(Id(_ctx_), Id("appName"), Id("endpoint"), Id("attributes").Op("..."))
+		if samplings := tr.methodSamplings(); len(samplings) != 0 {
+			bg.Line().Id("options").Op("=").Append(Index().Qual(pkgTracer, "InitOption").ValuesFunc(func(vg *Group) {
+				for _, sampling := range samplings {
+					vg.Line().Qual(pkgTracer, "WithMethodSampling").Call(sampling...)
+				}
+				vg.Line()
+			}), Id("options").Op("..."))
+		}
+		bg.Var().Err().Error()
+		bg.If(List(Id("srv").Dot("traceShutdown"), Err()).Op("=").Qual(pkgTracer, "InitWithOptions").Call(Id(_ctx_), Id("appName"), Id("options").Op("...")).Op(";").Err().Op("!=").Nil()).Block(
+			Id("srv").Dot("log").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("tracer init")),
+		)
 		for _, serviceName := range tr.serviceKeys() {
 			svc := tr.services[serviceName]
 			if svc.tags.IsSet(tagTrace) {
@@ -98,61 +175,71 @@ func (tr *Transport) withTraceFunc(outDir string) Code {
 	})
 }
 
+// methodSamplings returns arguments of tracer.WithMethodSampling for methods with trace-sample annotation.
+func (tr *Transport) methodSamplings() (samplings [][]Code) {
+
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		for _, method := range svc.methods {
+			// annotations of interface override merged tags of method, so annotation of method is read from its docs
+			ratio := tags.ParseTags(method.Docs).Value(tagTraceSample, svc.tags.Value(tagTraceSample))
+			if ratio == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(ratio, 64)
+			if err != nil || value < 0 || value > 1 {
+				tr.log.Warnf("%s: invalid %s '%s', expected ratio 0..1, default ratio is used", method.fullName(), tagTraceSample, ratio)
+				continue
+			}
+			args := []Code{Lit(method.fullName()), Lit(value)}
+			for _, version := range method.routeVersions() {
+				if method.isJsonRPC() {
+					args = append(args, Lit(method.jsonrpcPathVersion(version)))
+					continue
+				}
+				if method.isHTTP() {
+					args = append(args, Lit(method.httpPathSwaggerVersion(version)))
+				}
+			}
+			samplings = append(samplings, args)
+		}
+	}
+	return
+}
+
 func (tr *Transport) withMetricsFunc() Code {
 
-	return Func().Params(Id("srv").Op("*").Id("Server")).Id("WithMetrics").Params().Params(Op("*").Id("Server")).BlockFunc(func(bg *Group) {
-
-		bg.If(Id("VersionGauge").Op("==").Nil()).Block(
-			Id("VersionGauge").Op("=").Qual(packagePrometheusAuto, "NewGaugeVec").Call(Qual(packagePrometheus, "GaugeOpts").Values(
-				DictFunc(func(d Dict) {
-					d[Id("Name")] = Lit("count")
-					d[Id("Namespace")] = Lit("service")
-					d[Id("Subsystem")] = Lit("versions")
-					d[Id("Help")] = Lit("Versions of service parts")
-				}),
-			), Index().String().Values(Lit("part"), Lit("version"), Lit("hostname"))),
-		)
-		bg.If(Id("RequestCount").Op("==").Nil()).Block(
-			Id("RequestCount").Op("=").Qual(packagePrometheusAuto, "NewCounterVec").Call(Qual(packagePrometheus, "CounterOpts").Values(
-				DictFunc(func(d Dict) {
-					d[Id("Name")] = Lit("count")
-					d[Id("Namespace")] = Lit("service")
-					d[Id("Subsystem")] = Lit("requests")
-					d[Id("Help")] = Lit("Number of requests received")
-				}),
-			), Index().String().Values(Lit("service"), Lit("method"), Lit("success"), Lit("errCode"))),
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("WithMetrics").Params(Id("options").Op("...").Id("MetricsOption")).Params(Op("*").Id("Server")).BlockFunc(func(bg *Group) {
+
+		bg.Line().If(Id("srv").Dot("metrics").Op("!=").Nil()).Block(
+			Id("srv").Dot("log").Dot("Warn").Call().Dot("Msg").Call(Lit("metrics already enabled")),
+			Return(Id("srv")),
 		)
-		bg.If(Id("RequestCountAll").Op("==").Nil()).Block(
-			Id("RequestCountAll").Op("=").Qual(packagePrometheusAuto, "NewCounterVec").Call(Qual(packagePrometheus, "CounterOpts").Values(
-				DictFunc(func(d Dict) {
-					d[Id("Name")] = Lit("all_count")
-					d[Id("Namespace")] = Lit("service")
-					d[Id("Subsystem")] = Lit("requests")
-					d[Id("Help")] = Lit("Number of all requests received")
-				}),
-			), Index().String().Values(Lit("service"), Lit("method"), Lit("success"), Lit("errCode"))),
+		bg.Id("srv").Dot("metrics").Op("=").Id("newMetricsConfig").Call(Id("options").Op("..."))
+		bg.If(Err().Op(":=").Id("srv").Dot("initMetrics").Call().Op(";").Err().Op("!=").Nil()).Block(
+			Id("srv").Dot("log").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("metrics init")),
 		)
-		bg.If(Id("RequestLatency").Op("==").Nil()).Block(
-			Id("RequestLatency").Op("=").Qual(packagePrometheusAuto, "NewHistogramVec").Call(Qual(packagePrometheus, "HistogramOpts").Values(
-				DictFunc(func(d Dict) {
-					d[Id("Name")] = Lit("latency_microseconds")
-					d[Id("Namespace")] = Lit("service")
-					d[Id("Subsystem")] = Lit("requests")
-					d[Id("Help")] = Lit("Total duration of requests in microseconds")
-				}),
-			), Index().String().Values(Lit("service"), Lit("method"), Lit("success"), Lit("errCode"))),
+		bg.Return(Id("srv"))
+	})
+}
+
+func (tr *Transport) initMetricsFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("initMetrics").Params().Params(Err().Error()).BlockFunc(func(bg *Group) {
+
+		bg.Line().If(Err().Op("=").Id("srv").Dot("metrics").Dot("init").Call().Op(";").Err().Op("!=").Nil()).Block(
+			Id("srv").Dot("metrics").Op("=").Nil(),
+			Return(),
 		)
-		bg.List(Id("hostname"), Id("_")).Op(":=").Qual(packageOS, "Hostname").Call()
-		bg.Id("VersionGauge").Dot("WithLabelValues").Call(Lit("tg"), Id("VersionTg"), Id("hostname")).Dot("Set").Call(Lit(1))
 		for _, serviceName := range tr.serviceKeys() {
 			svc := tr.services[serviceName]
 			if svc.tags.IsSet(tagMetrics) {
 				bg.If(Id("srv").Dot("http" + serviceName).Op("!=").Nil()).Block(
-					Id("srv").Dot("http" + serviceName).Op("=").Id("srv").Dot(serviceName).Call().Dot("WithMetrics").Call(),
+					Id("srv").Dot("http" + serviceName).Op("=").Id("srv").Dot(serviceName).Call().Dot("WithMetrics").Call(Id("srv").Dot("metrics")),
 				)
 			}
 		}
-		bg.Return(Id("srv"))
+		bg.Return()
 	})
 }
 
@@ -166,7 +253,20 @@ func (tr *Transport) serverType() Code {
 		g.Line().Id("srvHTTP").Op("*").Qual(packageFiber, "App")
 		g.Id("srvHealth").Op("*").Qual(packageFiber, "App")
 		g.Id("srvMetrics").Op("*").Qual(packageFiber, "App")
+		g.Id("srvAdmin").Op("*").Qual(packageHttp, "Server")
+		g.Id("health").Op("*").Id("HealthChecker")
+		g.Id("shutdownHooks").Index().Id("ShutdownHook")
+		g.Id("drainDelay").Qual(packageTime, "Duration")
+		if tr.hasMetrics() {
+			g.Id("metrics").Op("*").Id("metricsConfig")
+		}
+		if tr.hasAudit() {
+			g.Id("audit").Op("*").Id("auditConfig")
+		}
 		g.Line().Id("reporterCloser").Qual(packageIO, "Closer")
+		if tr.hasTrace() {
+			g.Id("traceShutdown").Func().Params(Qual(packageContext, "Context")).Error()
+		}
 		if tr.hasJsonRPC {
 			g.Line().Id("maxBatchSize").Int()
 			g.Id("maxParallelBatch").Int().Line()
@@ -190,6 +290,7 @@ func (tr *Transport) serverNewFunc(outDir string) Code {
 					dict[Id("maxParallelBatch")] = Id("defaultMaxParallelBatch")
 				}
 				dict[Id("headerHandlers")] = Make(Map(String()).Id("HeaderHandler"))
+				dict[Id("health")] = Op("&").Id("HealthChecker").Values()
 				dict[Id("config")] = Qual(packageFiber, "Config").Values(Dict{
 					Id("DisableStartupMessage"): True(),
 				})
@@ -198,6 +299,9 @@ func (tr *Transport) serverNewFunc(outDir string) Code {
 			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
 				Id("option").Call(Id("srv")),
 			)
+			if methods := tr.customHTTPMethods(); len(methods) != 0 {
+				bg.Id("srv").Dot("config").Dot("RequestMethods").Op("=").Id("withMethods").Call(append([]Code{Id("srv").Dot("config").Dot("RequestMethods")}, methods...)...)
+			}
 			bg.Id("srv").Dot("srvHTTP").Op("=").Qual(packageFiber, "New").Call(Id("srv").Dot("config"))
 			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("recoverHandler"))
 			if tr.hasTrace() {
@@ -209,6 +313,7 @@ func (tr *Transport) serverNewFunc(outDir string) Code {
 			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
 				Id("option").Call(Id("srv")),
 			)
+			bg.Add(tr.metricsInit())
 			if tr.hasJsonRPC {
 				bg.Id("srv").Dot("srvHTTP").Dot("Post").Call(Lit("/"+tr.tags.Value(tagHttpPrefix, "")), Id("srv").Dot("serveBatch"))
 			}
@@ -225,6 +330,18 @@ func (tr *Transport) serveHealthFunc() Code {
 			Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).Block(
 				Return().Id(_ctx_).Dot("JSON").Call(Id("response")),
 			)),
+		Id("srv").Dot("srvHealth").Dot("Get").Call(Lit("/livez"),
+			Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).Block(
+				Return().Id(_ctx_).Dot("JSON").Call(Id("HealthStatus").Values(Dict{Id("Status"): Id("healthOK")})),
+			)),
+		Id("srv").Dot("srvHealth").Dot("Get").Call(Lit("/readyz"),
+			Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).Block(
+				List(Id("status"), Id("ready")).Op(":=").Id("srv").Dot("health").Dot("Check").Call(Id(_ctx_).Dot("UserContext").Call()),
+				If(Op("!").Id("ready")).Block(
+					Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusServiceUnavailable")),
+				),
+				Return().Id(_ctx_).Dot("JSON").Call(Id("status")),
+			)),
 		Go().Func().Params().Block(
 			Err().Op(":=").Id("srv").Dot("srvHealth").Dot("Listen").Call(Id("address")),
 			Id("ExitOnError").Call(Id("srv").Dot("log"), Err(), Lit("serve health on ").Op("+").Id("address")),
@@ -234,19 +351,11 @@ func (tr *Transport) serveHealthFunc() Code {
 
 func (tr *Transport) shutdownFunc() Code {
 
-	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Shutdown").Params().BlockFunc(func(bg *Group) {
-		bg.If(Id("srv").Dot("srvHTTP").Op("!=").Id("nil")).Block(
-			Id("_").Op("=").Id("srv").Dot("srvHTTP").Dot("Shutdown").Call(),
-		)
-		bg.If(Id("srv").Dot("srvHealth").Op("!=").Id("nil")).Block(
-			Id("_").Op("=").Id("srv").Dot("srvHealth").Dot("Shutdown").Call(),
-		)
-		if tr.hasMetrics() {
-			bg.If(Id("srv").Dot("srvMetrics").Op("!=").Id("nil")).Block(
-				Id("_").Op("=").Id("srv").Dot("srvMetrics").Dot("Shutdown").Call(),
-			)
-		}
-	})
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Shutdown").Params().Block(
+		If(Err().Op(":=").Id("srv").Dot("ShutdownWithContext").Call(Qual(packageContext, "Background").Call()).Op(";").Err().Op("!=").Nil()).Block(
+			Id("srv").Dot("log").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("shutdown")),
+		),
+	)
 }
 
 func (tr *Transport) sendResponseFunc() Code {
@@ -0,0 +1,28 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMethodSamplings(t *testing.T) {
+
+	tr, err := NewTransport(testLog(), "test", "testdata/trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var samplings []string
+	for _, args := range tr.methodSamplings() {
+		sampling := ""
+		for _, arg := range args {
+			sampling += fmt.Sprintf(" %#v", arg)
+		}
+		samplings = append(samplings, sampling[1:])
+	}
+	// ratio of interface is applied to Get, Cancel has invalid ratio and uses default one, Archive overrides ratio
+	want := []string{`"orders.get" 0.5 "/orders/{id}"`, `"orders.archive" 1.0 "/orders/archive"`}
+	if !slices.Equal(samplings, want) {
+		t.Errorf("samplings = %q, want %q", samplings, want)
+	}
+}
@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+func (tr *Transport) renderShutdown(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.Line().Const().Id("inflightPollInterval").Op("=").Qual(packageTime, "Millisecond").Op("*").Lit(10)
+
+	srcFile.Line().Comment("ShutdownHook releases resources of service, e.g. flushes tracer or closes cache.")
+	srcFile.Type().Id("ShutdownHook").Func().Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Error())
+
+	srcFile.Line().Comment("ShutdownError reports errors of shutdown and calls of methods, which were running when deadline hit.")
+	srcFile.Type().Id("ShutdownError").Struct(
+		Id("Err").Error(),
+		Id("Running").Index().String(),
+	)
+	srcFile.Line().Func().Params(Id("e").Op("*").Id("ShutdownError")).Id("Error").Params().Params(String()).Block(
+		Line(),
+		If(Len(Id("e").Dot("Running")).Op("==").Lit(0)).Block(
+			Return(Lit("shutdown: ").Op("+").Id("e").Dot("Err").Dot("Error").Call()),
+		),
+		Return(Qual(packageFmt, "Sprintf").Call(Lit("shutdown: %v, still running: %s"), Id("e").Dot("Err"), Qual(packageStrings, "Join").Call(Id("e").Dot("Running"), Lit(", ")))),
+	)
+	srcFile.Line().Func().Params(Id("e").Op("*").Id("ShutdownError")).Id("Unwrap").Params().Params(Error()).Block(
+		Return(Id("e").Dot("Err")),
+	)
+
+	tr.renderInflight(srcFile)
+
+	srcFile.Line().Comment("DrainDelay sets time between readiness probe starts to report draining and HTTP server stops accepting connections,")
+	srcFile.Comment("so load balancer may stop sending requests to server before it shuts down.")
+	srcFile.Func().Id("DrainDelay").Params(Id("delay").Qual(packageTime, "Duration")).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			Id("srv").Dot("drainDelay").Op("=").Id("delay"),
+		)),
+	)
+
+	srcFile.Line().Comment("OnShutdown registers hook, which runs after in-flight calls are finished.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("OnShutdown").Params(Id("hook").Id("ShutdownHook")).Params(Op("*").Id("Server")).Block(
+		Id("srv").Dot("shutdownHooks").Op("=").Append(Id("srv").Dot("shutdownHooks"), Id("hook")),
+		Return(Id("srv")),
+	)
+	srcFile.Line().Add(tr.shutdownWithContextFunc())
+
+	return srcFile.Save(path.Join(outDir, "shutdown.go"))
+}
+
+func (tr *Transport) renderInflight(srcFile goFile) {
+
+	srcFile.Line().Comment("InflightCall is call of method, which is running now.")
+	srcFile.Type().Id("InflightCall").Struct(
+		Id("Method").String().Tag(map[string]string{"json": "method"}),
+		Id("Begin").Qual(packageTime, "Time").Tag(map[string]string{"json": "begin"}),
+		Id("Duration").String().Tag(map[string]string{"json": "duration"}),
+	)
+	srcFile.Line().Comment("inflightCalls tracks calls of methods of service, which are running now.")
+	srcFile.Type().Id("inflightCalls").Struct(
+		Id("lock").Qual(packageSync, "Mutex"),
+		Id("next").Uint64(),
+		Id("calls").Map(Uint64()).Id("InflightCall"),
+	)
+	srcFile.Line().Func().Params(Id("c").Op("*").Id("inflightCalls")).Id("begin").Params(Id("method").String()).Params(Func().Params()).Block(
+		Line(),
+		Id("c").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("c").Dot("lock").Dot("Unlock").Call(),
+		Id("c").Dot("next").Op("++"),
+		Id("id").Op(":=").Id("c").Dot("next"),
+		Id("c").Dot("calls").Index(Id("id")).Op("=").Id("InflightCall").Values(Dict{
+			Id("Method"): Id("method"),
+			Id("Begin"):  Qual(packageTime, "Now").Call(),
+		}),
+		Return(Func().Params().Block(
+			Id("c").Dot("lock").Dot("Lock").Call(),
+			Defer().Id("c").Dot("lock").Dot("Unlock").Call(),
+			Delete(Id("c").Dot("calls"), Id("id")),
+		)),
+	)
+	srcFile.Line().Comment("snapshot appends running calls to calls.")
+	srcFile.Func().Params(Id("c").Op("*").Id("inflightCalls")).Id("snapshot").Params(Id("calls").Index().Id("InflightCall")).Params(Index().Id("InflightCall")).Block(
+		Line(),
+		Id("c").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("c").Dot("lock").Dot("Unlock").Call(),
+		For(List(Id("_"), Id("call")).Op(":=").Range().Id("c").Dot("calls")).Block(
+			Id("call").Dot("Duration").Op("=").Qual(packageTime, "Since").Call(Id("call").Dot("Begin")).Dot("String").Call(),
+			Id("calls").Op("=").Append(Id("calls"), Id("call")),
+		),
+		Return(Id("calls")),
+	)
+	srcFile.Line().Comment("inflight returns running calls of services of server, the longest ones first.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("inflight").Params().Params(Id("calls").Index().Id("InflightCall")).BlockFunc(func(bg *Group) {
+		bg.Line()
+		for _, serviceName := range tr.serviceKeys() {
+			bg.If(Id("srv").Dot("http" + serviceName).Op("!=").Nil()).Block(
+				Id("calls").Op("=").Id("srv").Dot("http" + serviceName).Dot("svc").Dot("inflight").Dot("snapshot").Call(Id("calls")),
+			)
+		}
+		bg.Qual("slices", "SortFunc").Call(Id("calls"), Func().Params(List(Id("a"), Id("b")).Id("InflightCall")).Int().Block(
+			Return(Id("a").Dot("Begin").Dot("Compare").Call(Id("b").Dot("Begin"))),
+		))
+		bg.Return()
+	})
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("running").Params().Params(Id("methods").Index().String()).Block(
+		Line(),
+		For(List(Id("_"), Id("call")).Op(":=").Range().Id("srv").Dot("inflight").Call()).Block(
+			Id("methods").Op("=").Append(Id("methods"), Id("call").Dot("Method")),
+		),
+		Return(),
+	)
+	srcFile.Line().Comment("waitInflight returns methods, which are still running, when context is done.")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("waitInflight").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("running").Index().String(), Err().Error()).Block(
+		Line(),
+		Id("ticker").Op(":=").Qual(packageTime, "NewTicker").Call(Id("inflightPollInterval")),
+		Defer().Id("ticker").Dot("Stop").Call(),
+		For().Block(
+			If(Id("running").Op("=").Id("srv").Dot("running").Call().Op(";").Len(Id("running")).Op("==").Lit(0)).Block(
+				Return(Nil(), Nil()),
+			),
+			Select().Block(
+				Case(Op("<-").Id(_ctx_).Dot("Done").Call()).Block(
+					Return(Id("running"), Id(_ctx_).Dot("Err").Call()),
+				),
+				Case(Op("<-").Id("ticker").Dot("C")),
+			),
+		),
+	)
+}
+
+func (tr *Transport) shutdownWithContextFunc() Code {
+
+	shutdown := "ShutdownWithContext"
+	if tr.isNetHTTP() {
+		shutdown = "Shutdown"
+	}
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ShutdownWithContext").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Err().Error()).BlockFunc(func(bg *Group) {
+
+		bg.Line().Var().Id("errs").Index().Error()
+		bg.Id("srv").Dot("health").Dot("drain").Call()
+		bg.If(Id("srv").Dot("drainDelay").Op(">").Lit(0)).Block(
+			Id("timer").Op(":=").Qual(packageTime, "NewTimer").Call(Id("srv").Dot("drainDelay")),
+			Select().Block(
+				Case(Op("<-").Id("timer").Dot("C")),
+				Case(Op("<-").Id(_ctx_).Dot("Done").Call()),
+			),
+			Id("timer").Dot("Stop").Call(),
+		)
+		bg.If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
+			If(Err().Op("=").Id("srv").Dot("srvHTTP").Dot(shutdown).Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+				Id("errs").Op("=").Append(Id("errs"), Err()),
+			),
+		)
+		bg.List(Id("running"), Err()).Op(":=").Id("srv").Dot("waitInflight").Call(Id(_ctx_))
+		bg.If(Err().Op("!=").Nil()).Block(
+			Id("errs").Op("=").Append(Id("errs"), Err()),
+		)
+		bg.For(List(Id("_"), Id("hook")).Op(":=").Range().Id("srv").Dot("shutdownHooks")).Block(
+			If(Err().Op("=").Id("hook").Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+				Id("errs").Op("=").Append(Id("errs"), Err()),
+			),
+		)
+		bg.If(Id("srv").Dot("srvHealth").Op("!=").Nil()).Block(
+			If(Err().Op("=").Id("srv").Dot("srvHealth").Dot(shutdown).Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+				Id("errs").Op("=").Append(Id("errs"), Err()),
+			),
+		)
+		if tr.hasMetrics() {
+			bg.If(Id("srv").Dot("srvMetrics").Op("!=").Nil()).Block(
+				If(Err().Op("=").Id("srv").Dot("srvMetrics").Dot(shutdown).Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+					Id("errs").Op("=").Append(Id("errs"), Err()),
+				),
+			)
+		}
+		if tr.hasTrace() {
+			bg.If(Id("srv").Dot("traceShutdown").Op("!=").Nil()).Block(
+				If(Err().Op("=").Id("srv").Dot("traceShutdown").Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+					Id("errs").Op("=").Append(Id("errs"), Err()),
+				),
+			)
+		}
+		bg.If(Id("srv").Dot("srvAdmin").Op("!=").Nil()).Block(
+			If(Err().Op("=").Id("srv").Dot("srvAdmin").Dot("Shutdown").Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+				Id("errs").Op("=").Append(Id("errs"), Err()),
+			),
+		)
+		if !tr.isNetHTTP() {
+			bg.If(Id("srv").Dot("reporterCloser").Op("!=").Nil()).Block(
+				If(Err().Op("=").Id("srv").Dot("reporterCloser").Dot("Close").Call().Op(";").Err().Op("!=").Nil()).Block(
+					Id("errs").Op("=").Append(Id("errs"), Err()),
+				),
+			)
+		}
+		bg.If(Len(Id("errs")).Op("==").Lit(0)).Block(
+			Return(Nil()),
+		)
+		bg.Return(Op("&").Id("ShutdownError").Values(Dict{
+			Id("Err"):     Qual("errors", "Join").Call(Id("errs").Op("...")),
+			Id("Running"): Id("running"),
+		}))
+	})
+}
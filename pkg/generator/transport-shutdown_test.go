@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderShutdown(t *testing.T) {
+
+	tests := []struct {
+		backend  string
+		shutdown string
+	}{
+		{backend: backendNetHTTP, shutdown: "Shutdown(ctx)"},
+		{backend: backendFiber, shutdown: "ShutdownWithContext(ctx)"},
+	}
+	for _, test := range tests {
+		t.Run(test.backend, func(t *testing.T) {
+			files := renderServer(t, "testdata/files", test.backend)
+			// server is drained, then stopped, then in-flight calls are awaited before hooks and probes
+			steps := []string{
+				`srv.health.drain()`,
+				`timer := time.NewTimer(srv.drainDelay)`,
+				`srv.srvHTTP.` + test.shutdown,
+				`running, err := srv.waitInflight(ctx)`,
+				`for _, hook := range srv.shutdownHooks {`,
+				`srv.srvHealth.` + test.shutdown,
+				`srv.srvAdmin.Shutdown(ctx)`,
+				`return &ShutdownError{`,
+			}
+			assertContains(t, files, "shutdown.go", steps...)
+			content, last := files["shutdown.go"], -1
+			for _, step := range steps {
+				idx := strings.Index(content, step)
+				if idx < last {
+					t.Errorf("%q is out of order", step)
+				}
+				last = idx
+			}
+			assertContains(t, files, "shutdown.go",
+				`func DrainDelay(delay time.Duration) Option {`,
+				`func (srv *Server) OnShutdown(hook ShutdownHook) *Server {`,
+				`return fmt.Sprintf("shutdown: %v, still running: %s", e.Err, strings.Join(e.Running, ", "))`,
+				`calls = srv.httpFiles.svc.inflight.snapshot(calls)`,
+			)
+			assertContains(t, files, "files-inflight.go",
+				`defer m.calls.begin("files.get")()`,
+				`defer m.calls.begin("files.upload")()`,
+			)
+		})
+	}
+}
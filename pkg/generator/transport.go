@@ -26,6 +26,11 @@ const keyCode = "code"
 
 const doNotEdit = "GENERATED BY 'T'ransport 'G'enerator. DO NOT EDIT."
 
+const (
+	backendFiber   = "fiber"
+	backendNetHTTP = "nethttp"
+)
+
 const (
 	tagLogger              = "log"
 	tagDesc                = "desc"
@@ -33,6 +38,8 @@ const (
 	tagTag                 = "tags"
 	tagTests               = "tests"
 	tagTrace               = "trace"
+	tagTraceSample         = "trace-sample"
+	tagTraceAttrs          = "trace-attrs"
 	tagEnums               = "enums"
 	tagFormat              = "format"
 	tagRequired            = "required"
@@ -40,6 +47,7 @@ const (
 	tagHandler             = "handler"
 	tagExample             = "example"
 	tagMetrics             = "metrics"
+	tagMetricsLabels       = "metrics-labels"
 	tagHttpArg             = "http-args"
 	tagHttpPath            = "http-path"
 	tagDeprecated          = "deprecated"
@@ -55,10 +63,17 @@ const (
 	tagPackageUUID         = "uuidPackage"
 	tagSwaggerTags         = "swaggerTags"
 	tagLogSkip             = "log-skip"
+	tagLogRedact           = "log-redact"
+	tagAudit               = "audit"
+	tagAuditArgs           = "audit-args"
 	tagEnableClientCB      = "clientWithCB"
 	tagDisableOmitEmpty    = "tagNoOmitempty"
 	tagRequestContentType  = "requestContentType"
 	tagResponseContentType = "responseContentType"
+	tagSince               = "since"
+	tagUntil               = "until"
+	tagDeprecatedVersions  = "deprecated-versions"
+	tagLambda              = "lambda"
 
 	tagTitle       = "title"
 	tagNameNPM     = "npmName"
@@ -72,72 +87,80 @@ const (
 )
 
 type Transport struct {
-	hasHTTP    bool
-	hasJsonRPC bool
-	version    string
-	modPath    string
-	tags       tags.DocTags
-	module     *modfile.File
-	log        logrus.FieldLogger
-	services   map[string]*service
+	hasHTTP       bool
+	hasJsonRPC    bool
+	zod           bool
+	kotlinPackage string
+	version       string
+	backend       string
+	apiVersion    string
+	modPath       string
+	tags          tags.DocTags
+	module        *modfile.File
+	log           logrus.FieldLogger
+	services      map[string]*service
 }
 
 func NewTransport(log logrus.FieldLogger, version, svcDir string, ifaces ...string) (tr Transport, err error) {
 
 	tr.log = log
 	tr.version = version
-	var files []os.DirEntry
+	tr.backend = backendFiber
 	tr.services = make(map[string]*service)
-	var include, exclude = make([]string, 0, len(ifaces)), make([]string, 0, len(ifaces))
-	for _, iface := range ifaces {
-		if strings.HasPrefix(iface, "!") {
-			exclude = append(exclude, strings.TrimPrefix(iface, "!"))
-			continue
-		}
-		include = append(include, iface)
-	}
-	if len(include) != 0 && len(exclude) != 0 {
-		err = fmt.Errorf("include and exclude cannot be set at same time (%v | %v)", include, exclude)
+	var filter ifaceFilter
+	if filter, err = newIfaceFilter(ifaces...); err != nil {
 		return
 	}
-	if err = tr.goMod(svcDir); err != nil {
+	if err = tr.goMod(ServicesRoot(svcDir)); err != nil {
 		return
 	}
-	if files, err = os.ReadDir(svcDir); err != nil {
+	var dirs []string
+	if dirs, err = ServiceDirs(svcDir); err != nil {
 		return
 	}
+	rootDir, _ := filepath.Abs(ServicesRoot(svcDir))
+	pkgTags := make(map[string]tags.DocTags)
 	var interfaces []pair[string, types.Interface]
-	for _, file := range files {
-		if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") {
-			continue
-		}
-		var serviceAst *types.File
-		svcDir, _ = filepath.Abs(svcDir)
-		filePath := path.Join(svcDir, file.Name())
-		if serviceAst, err = astra.ParseFile(filePath); err != nil {
+	for _, dir := range dirs {
+		dir, _ = filepath.Abs(dir)
+		var files []os.DirEntry
+		if files, err = os.ReadDir(dir); err != nil {
 			return
 		}
-		tr.tags = tr.tags.Merge(tags.ParseTags(serviceAst.Docs))
-		for _, iface := range serviceAst.Interfaces {
-			interfaces = append(interfaces, newPair(filePath, iface))
-		}
-	}
-	for _, ifacePair := range interfaces {
-		filePath, iface := ifacePair.Key, ifacePair.Value
-		if len(include) != 0 {
-			if !slices.Contains(include, iface.Name) {
-				log.WithField("iface", iface.Name).Info("skip")
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") {
 				continue
 			}
+			var serviceAst *types.File
+			filePath := path.Join(dir, file.Name())
+			if serviceAst, err = astra.ParseFile(filePath); err != nil {
+				return
+			}
+			pkgTags[dir] = pkgTags[dir].Merge(tags.ParseTags(serviceAst.Docs))
+			for _, iface := range serviceAst.Interfaces {
+				interfaces = append(interfaces, newPair(filePath, iface))
+			}
 		}
-		if len(exclude) != 0 {
-			if slices.Contains(exclude, iface.Name) {
-				log.WithField("iface", iface.Name).Info("skip")
-				continue
+		// http-prefix of nested package is default for its services only
+		for key, value := range pkgTags[dir] {
+			if key != tagHttpPrefix || dir == rootDir {
+				tr.tags = tr.tags.Merge(tags.DocTags{key: value})
 			}
 		}
+	}
+	for _, ifacePair := range interfaces {
+		filePath, iface := ifacePair.Key, ifacePair.Value
+		if filter.skip(iface.Name) {
+			log.WithField("iface", iface.Name).Info("skip")
+			continue
+		}
 		if len(tags.ParseTags(iface.Docs)) != 0 {
-			service := newService(log, &tr, filePath, iface)
+			dir := filepath.Dir(filePath)
+			service := newService(log, &tr, filePath, iface, tags.DocTags{}.Merge(pkgTags[rootDir]).Merge(pkgTags[dir]))
+			if known, found := tr.services[iface.Name]; found {
+				err = fmt.Errorf("interface %s is declared in packages %s and %s", iface.Name, known.pkgPath, service.pkgPath)
+				return
+			}
 			tr.services[iface.Name] = service
 			if service.tags.Contains(tagServerHTTP) {
 				tr.hasHTTP = true
@@ -150,12 +173,115 @@ func NewTransport(log logrus.FieldLogger, version, svcDir string, ifaces ...stri
 	return
 }
 
+// Select returns transport with services of listed interfaces, interfaces prefixed with '!' are excluded.
+func (tr *Transport) Select(ifaces ...string) (sub Transport, err error) {
+
+	var filter ifaceFilter
+	if filter, err = newIfaceFilter(ifaces...); err != nil {
+		return
+	}
+	sub = *tr
+	sub.hasHTTP, sub.hasJsonRPC = false, false
+	sub.services = make(map[string]*service)
+	for name, svc := range tr.services {
+		if filter.skip(name) {
+			continue
+		}
+		sub.services[name] = svc
+		if svc.tags.Contains(tagServerHTTP) {
+			sub.hasHTTP = true
+		}
+		if svc.tags.Contains(tagServerJsonRPC) {
+			sub.hasJsonRPC = true
+		}
+	}
+	return
+}
+
+type ifaceFilter struct {
+	include []string
+	exclude []string
+}
+
+func newIfaceFilter(ifaces ...string) (filter ifaceFilter, err error) {
+
+	for _, iface := range ifaces {
+		if strings.HasPrefix(iface, "!") {
+			filter.exclude = append(filter.exclude, strings.TrimPrefix(iface, "!"))
+			continue
+		}
+		filter.include = append(filter.include, iface)
+	}
+	if len(filter.include) != 0 && len(filter.exclude) != 0 {
+		err = fmt.Errorf("include and exclude cannot be set at same time (%v | %v)", filter.include, filter.exclude)
+	}
+	return
+}
+
+func (filter ifaceFilter) skip(name string) bool {
+
+	if len(filter.include) != 0 && !slices.Contains(filter.include, name) {
+		return true
+	}
+	return slices.Contains(filter.exclude, name)
+}
+
+func (tr *Transport) SetBackend(backend string) (err error) {
+
+	switch backend {
+	case "", backendFiber:
+		tr.backend = backendFiber
+	case backendNetHTTP:
+		tr.backend = backendNetHTTP
+	default:
+		return fmt.Errorf("unknown backend '%s' (supported: %s, %s)", backend, backendFiber, backendNetHTTP)
+	}
+	tr.attachServices()
+	return
+}
+
+// attachServices points services to tr, as NewTransport returns a copy of the transport they were created with.
+func (tr *Transport) attachServices() {
+
+	for _, svc := range tr.services {
+		svc.tr = tr
+	}
+}
+
+func (tr *Transport) isNetHTTP() bool {
+	return tr.backend == backendNetHTTP
+}
+
 func (tr *Transport) RenderAzure(appName, routePrefix, outDir, logLevel string, enableHealth bool) (err error) {
 	return newAzure(tr).render(appName, routePrefix, outDir, logLevel, enableHealth)
 }
 
+func (tr *Transport) RenderLambda(appName, codeURI, outDir string, memorySize, timeout int) (err error) {
+	if !tr.hasLambda() {
+		tr.log.Warnf("services have no '%s' annotation, transport will not contain ServeLambda", tagLambda)
+	}
+	return newLambda(tr).render(appName, codeURI, outDir, memorySize, timeout)
+}
+
+func (tr *Transport) RenderK8s(cfg K8sConfig, outDir string) (err error) {
+	return newK8s(tr).render(cfg, outDir)
+}
+
 func (tr *Transport) RenderSwagger(outDir string, interfaces ...string) (err error) {
-	return newSwagger(tr).render(outDir, interfaces...)
+
+	tr.attachServices()
+	if err = newSwagger(tr).render(outDir, interfaces...); err != nil || tr.apiVersion != "" {
+		return
+	}
+	for _, version := range tr.apiVersions() {
+		tr.apiVersion = version
+		err = newSwagger(tr).render(versionFilePath(outDir, version), interfaces...)
+		tr.apiVersion = ""
+		if err != nil {
+			return
+		}
+	}
+	return
 }
 
 func (tr *Transport) serviceKeys() (keys []string) {
@@ -169,7 +295,7 @@ func (tr *Transport) serviceKeys() (keys []string) {
 
 func (tr *Transport) RenderClient(outDir string) (err error) {
 
-	tr.cleanup(outDir)
+	defer tr.cleanup(outDir)
 	if err = os.MkdirAll(outDir, 0777); err != nil {
 		return
 	}
@@ -177,6 +303,7 @@ func (tr *Transport) RenderClient(outDir string) (err error) {
 	if tr.hasHTTP {
 		showError(tr.log, tr.renderVersion(outDir, false), "renderVersion")
 		showError(tr.log, tr.renderClientError(outDir), "renderClientError")
+		showError(tr.log, tr.renderClientHTTPOptions(outDir), "renderClientHTTPOptions")
 	}
 	if tr.hasJsonRPC {
 		showError(tr.log, tr.renderClientOptions(outDir), "renderClientOptions")
@@ -186,6 +313,10 @@ func (tr *Transport) RenderClient(outDir string) (err error) {
 		showError(tr.log, tr.renderClientBatch(outDir), "renderClientBatch")
 		showError(tr.log, tr.renderClientCache(outDir), "renderClientCache")
 	}
+	if tr.hasHTTP || tr.hasJsonRPC {
+		showError(tr.log, tr.renderClientMetrics(outDir), "renderClientMetrics")
+		showError(tr.log, tr.renderRedact(outDir, true), "renderRedact")
+	}
 	for _, serviceName := range tr.serviceKeys() {
 		svc := tr.services[serviceName]
 		showError(tr.log, svc.renderClient(outDir), "renderHTTP")
@@ -195,7 +326,11 @@ func (tr *Transport) RenderClient(outDir string) (err error) {
 
 func (tr *Transport) RenderServer(outDir string) (err error) {
 
-	tr.cleanup(outDir)
+	if err = tr.checkMetricsLabels(); err != nil {
+		return
+	}
+	tr.checkCookies()
+	defer tr.cleanup(outDir)
 
 	if err = os.MkdirAll(outDir, 0777); err != nil {
 		return
@@ -203,19 +338,36 @@ func (tr *Transport) RenderServer(outDir string) (err error) {
 
 	showError(tr.log, tr.renderHTTP(outDir), "renderHTTP")
 	showError(tr.log, tr.renderContext(outDir), "renderCtx")
-	showError(tr.log, tr.renderFiber(outDir), "renderFiber")
+	if tr.isNetHTTP() {
+		showError(tr.log, tr.renderNetHTTP(outDir), "renderNetHTTP")
+	} else {
+		showError(tr.log, tr.renderFiber(outDir), "renderFiber")
+	}
 	showError(tr.log, tr.renderHeader(outDir), "renderHeader")
 	showError(tr.log, tr.renderErrors(outDir), "renderErrors")
 	showError(tr.log, tr.renderServer(outDir), "renderServer")
 	showError(tr.log, tr.renderOptions(outDir), "renderOptions")
 	showError(tr.log, tr.renderMetrics(outDir), "renderMetrics")
 	showError(tr.log, tr.renderVersion(outDir, false), "renderVersion")
+	if tr.hasLambda() {
+		showError(tr.log, tr.renderLambda(outDir), "renderLambda")
+	}
 	if tr.hasMetrics() {
 		showError(tr.log, tr.renderMetrics(outDir), "renderMetrics")
 	}
+	if tr.hasAudit() {
+		showError(tr.log, tr.renderAudit(outDir), "renderAudit")
+	}
+	showError(tr.log, tr.renderRedact(outDir, false), "renderRedact")
+	showError(tr.log, tr.renderHealth(outDir), "renderHealth")
+	showError(tr.log, tr.renderShutdown(outDir), "renderShutdown")
+	showError(tr.log, tr.renderAdmin(outDir), "renderAdmin")
 	if tr.hasJsonRPC {
 		showError(tr.log, tr.renderJsonRPC(outDir), "renderJsonRPC")
 	}
+	if tr.hasVersions() {
+		showError(tr.log, tr.renderDeprecation(outDir), "renderDeprecation")
+	}
 	for _, serviceName := range tr.serviceKeys() {
 		svc := tr.services[serviceName]
 		err = svc.render(outDir)
@@ -243,6 +395,24 @@ func (tr *Transport) hasMetrics() (hasMetric bool) {
 	return
 }
 
+func (tr *Transport) hasLambda() bool {
+	for _, serviceName := range tr.serviceKeys() {
+		if tr.services[serviceName].tags.IsSet(tagLambda) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tr *Transport) hasAudit() bool {
+	for _, serviceName := range tr.serviceKeys() {
+		if tr.services[serviceName].hasAudit() {
+			return true
+		}
+	}
+	return false
+}
+
 func showError(log logrus.FieldLogger, err error, msg string) {
 	if err != nil {
 		log.WithError(err).Error(msg)
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func testLog() logrus.FieldLogger {
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+// renderServer generates transport of services in svcDir for backend and returns generated files by relative paths.
+func renderServer(t *testing.T, svcDir, backend string) (files map[string]string) {
+
+	t.Helper()
+	tr, err := NewTransport(testLog(), "test", svcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.SetBackend(backend); err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "transport")
+	if err = tr.RenderServer(outDir); err != nil {
+		t.Fatal(err)
+	}
+	return readFiles(t, outDir)
+}
+
+// renderClient generates Go client of services in svcDir and returns generated files by relative paths.
+func renderClient(t *testing.T, svcDir string) (files map[string]string) {
+
+	t.Helper()
+	tr, err := NewTransport(testLog(), "test", svcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(t.TempDir(), "client")
+	if err = tr.RenderClient(outDir); err != nil {
+		t.Fatal(err)
+	}
+	return readFiles(t, outDir)
+}
+
+func readFiles(t *testing.T, outDir string) (files map[string]string) {
+
+	t.Helper()
+	files = make(map[string]string)
+	err := filepath.WalkDir(outDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(outDir, filePath)
+		files[filepath.ToSlash(relPath)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+// assertContains checks that generated file contains every snippet.
+func assertContains(t *testing.T, files map[string]string, fileName string, snippets ...string) {
+
+	t.Helper()
+	content, found := files[fileName]
+	if !found {
+		t.Fatalf("file %s is not generated", fileName)
+	}
+	for _, snippet := range snippets {
+		if !strings.Contains(content, snippet) {
+			t.Errorf("%s does not contain %q", fileName, snippet)
+		}
+	}
+}
+
+// assertNotContains checks that generated file contains none of snippets.
+func assertNotContains(t *testing.T, files map[string]string, fileName string, snippets ...string) {
+
+	t.Helper()
+	for _, snippet := range snippets {
+		if strings.Contains(files[fileName], snippet) {
+			t.Errorf("%s contains %q", fileName, snippet)
+		}
+	}
+}
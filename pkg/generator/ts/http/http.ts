@@ -0,0 +1,141 @@
+export class HttpError extends Error {
+    status: number;
+    data?: unknown;
+
+    constructor(message: string, status: number, data?: unknown) {
+        super(message);
+        this.status = status;
+        this.data = data;
+        Object.setPrototypeOf(this, HttpError.prototype);
+    }
+}
+
+export type HttpOptions =
+    | string
+    | {
+    baseURL: string;
+    credentials?: RequestCredentials;
+    getHeaders?():
+        | Record<string, string>
+        | Promise<Record<string, string>>
+        | undefined;
+};
+
+export type HttpRequest = {
+    method: string;
+    path: string;
+    query?: Record<string, unknown>;
+    headers?: Record<string, unknown>;
+    cookies?: Record<string, unknown>;
+    body?: Record<string, unknown>;
+    success: number;
+    signal?: AbortSignal;
+};
+
+export type HttpResponse = {
+    body: any;
+    headers: Headers;
+    cookies: Record<string, string>;
+};
+
+export type HttpTransport = (req: HttpRequest) => Promise<HttpResponse>;
+
+export function httpClient(options: HttpOptions): HttpTransport {
+
+    if (typeof options === "string") {
+        options = {baseURL: options};
+    }
+    const opts = options;
+    return async (req: HttpRequest): Promise<HttpResponse> => {
+        const query = new URLSearchParams();
+        for (const [key, value] of Object.entries(req.query ?? {})) {
+            if (value !== undefined && value !== null) {
+                query.set(key, toString(value));
+            }
+        }
+        const headers: Record<string, string> = {
+            Accept: "application/json",
+            ...(opts.getHeaders ? await opts.getHeaders() : {}),
+        };
+        for (const [key, value] of Object.entries(req.headers ?? {})) {
+            if (value !== undefined && value !== null) {
+                headers[key] = toString(value);
+            }
+        }
+        // browsers send cookies by credentials option and ignore Cookie header
+        const cookies = Object.entries(req.cookies ?? {})
+            .filter(([, value]) => value !== undefined && value !== null)
+            .map(([key, value]) => `${key}=${encodeURIComponent(toString(value))}`);
+        if (cookies.length > 0) {
+            headers["Cookie"] = cookies.join("; ");
+        }
+        let body: string | undefined;
+        if (req.body !== undefined) {
+            headers["Content-Type"] = "application/json";
+            body = JSON.stringify(req.body);
+        }
+        const search = query.toString();
+        const url = opts.baseURL.replace(/\/+$/, "") + req.path + (search !== "" ? "?" + search : "");
+        const res = await fetch(url, {
+            method: req.method,
+            headers,
+            body,
+            credentials: opts.credentials,
+            signal: req.signal,
+        });
+        const text = req.method === "HEAD" ? "" : await res.text();
+        let data: any = undefined;
+        if (text !== "") {
+            try {
+                data = JSON.parse(text);
+            } catch {
+                data = text;
+            }
+        }
+        if (res.status !== req.success) {
+            throw new HttpError(res.statusText || `HTTP error: ${res.status}`, res.status, data);
+        }
+        return {body: data, headers: res.headers, cookies: responseCookies(res.headers)};
+    };
+}
+
+export function fromString(value: string | null | undefined, type: "string" | "number" | "boolean"): any {
+
+    if (value === null || value === undefined) {
+        return undefined;
+    }
+    switch (type) {
+        case "number":
+            return Number(value);
+        case "boolean":
+            return value === "true";
+        default:
+            return value;
+    }
+}
+
+// responseCookies are available outside of browsers only, which hide Set-Cookie headers
+function responseCookies(headers: Headers): Record<string, string> {
+
+    const cookies: Record<string, string> = {};
+    const setCookies: string[] = (headers as any).getSetCookie?.() ?? [];
+    for (const setCookie of setCookies) {
+        const [pair] = setCookie.split(";");
+        const idx = pair.indexOf("=");
+        if (idx > 0) {
+            cookies[pair.slice(0, idx).trim()] = decodeURIComponent(pair.slice(idx + 1).trim());
+        }
+    }
+    return cookies;
+}
+
+function toString(value: unknown): string {
+
+    if (value instanceof Date) {
+        return value.toISOString();
+    }
+    if (typeof value === "object") {
+        return JSON.stringify(value);
+    }
+    return String(value);
+}
@@ -13,15 +13,31 @@ export class RpcError extends Error {
 }
 
 export type RpcTransport = (
-    req: JsonRpcRequest,
+    req: JsonRpcRequest | JsonRpcRequest[],
     abortSignal: AbortSignal
-) => Promise<JsonRpcResponse>;
+) => Promise<JsonRpcResponse | JsonRpcResponse[]>;
+
+export type BatchOptions = {
+    // maxSize limits count of calls in one automatic batch (100 by default)
+    maxSize?: number;
+    // delay in milliseconds for collecting calls, by default calls of current tick are collected
+    delay?: number;
+};
+
+// RpcValidator checks result of method and throws error, when it is invalid
+export type RpcValidator = (method: string, result: any) => void;
+
+// RpcNames maps methods of client to JSON-RPC method names, e.g. versioned ones
+export type RpcNames = Record<string, string>;
 
 type RpcClientOptions =
     | string
-    | FetchOptions
+    | (FetchOptions & { batch?: boolean | BatchOptions; validate?: RpcValidator; names?: RpcNames })
     | {
     transport: RpcTransport;
+    batch?: boolean | BatchOptions;
+    validate?: RpcValidator;
+    names?: RpcNames;
 };
 
 type FetchOptions = {
@@ -39,6 +55,41 @@ type PromisifyMethods<T extends object> = {
     [K in keyof T]: Promisify<T[K]>;
 };
 
+type Unpromise<T> = T extends Promise<infer R> ? R : T;
+
+export type BatchCall<R> = {
+    method: string;
+    params: any;
+    readonly result?: R;
+};
+
+export type BatchResult<R> =
+    | { result: R; error?: undefined }
+    | { result?: undefined; error: RpcError };
+
+type BatchBuilder<T extends object> = {
+    [K in keyof T]: T[K] extends (params: infer P) => infer R ? (params: P) => BatchCall<Unpromise<R>> : never;
+};
+
+type BatchResults<C extends BatchCall<any>[]> = {
+    [I in keyof C]: C[I] extends BatchCall<infer R> ? BatchResult<R> : never;
+};
+
+type PendingCall = {
+    method: string;
+    request: JsonRpcRequest;
+    signal: AbortSignal;
+    resolve(result: any): void;
+    reject(error: unknown): void;
+};
+
+let lastID = 0;
+
+function nextID(): number {
+    lastID = lastID >= Number.MAX_SAFE_INTEGER ? 1 : lastID + 1;
+    return lastID;
+}
+
 export function rpcClient<T extends object>(options: RpcClientOptions) {
 
     if (typeof options === "string") {
@@ -48,17 +99,87 @@ export function rpcClient<T extends object>(options: RpcClientOptions) {
     const transport =
         "transport" in options ? options.transport : fetchTransport(options);
 
-    const sendRequest = async (method: string, params: any, signal: AbortSignal) => {
-        const res = await transport(createRequest(method, params), signal);
-        if ("result" in res) {
+    const batching = options.batch ? {maxSize: 100, delay: 0, ...(options.batch === true ? {} : options.batch)} : undefined;
+
+    const validate = options.validate;
+
+    const names = options.names ?? {};
+
+    const request = (method: string, params: any) => createRequest(names[method] ?? method, params);
+
+    const unwrap = (res: JsonRpcResponse | undefined, method: string) => {
+        if (res && "result" in res) {
+            validate?.(method, res.result);
             return res.result;
-        } else if ("error" in res) {
+        } else if (res && "error" in res) {
             const {code, message, data} = res.error;
             throw new RpcError(message, code, data);
         }
         throw new TypeError("Invalid response");
     };
 
+    const sendBatch = async (requests: JsonRpcRequest[], signal: AbortSignal) => {
+        const res = await transport(requests, signal);
+        if (!Array.isArray(res)) {
+            // whole batch is rejected by server
+            unwrap(res, "");
+            throw new TypeError("Invalid response");
+        }
+        const responses = new Map<JsonRpcResponse["id"], JsonRpcResponse>();
+        for (const item of res) {
+            responses.set(item.id, item);
+        }
+        return responses;
+    };
+
+    let queue: PendingCall[] = [];
+    let scheduled = false;
+
+    const flush = () => {
+        scheduled = false;
+        const calls = queue.filter((call) => !call.signal.aborted);
+        queue = [];
+        for (let i = 0; i < calls.length; i += batching!.maxSize) {
+            const chunk = calls.slice(i, i + batching!.maxSize);
+            sendBatch(chunk.map((call) => call.request), new AbortController().signal).then(
+                (responses) => {
+                    for (const call of chunk) {
+                        try {
+                            call.resolve(unwrap(responses.get(call.request.id), call.method));
+                        } catch (error) {
+                            call.reject(error);
+                        }
+                    }
+                },
+                (error) => chunk.forEach((call) => call.reject(error))
+            );
+        }
+    };
+
+    const sendRequest = async (method: string, params: any, signal: AbortSignal) => {
+        const req = request(method, params);
+        if (!batching) {
+            const res = await transport(req, signal);
+            return unwrap(Array.isArray(res) ? res[0] : res, method);
+        }
+        return new Promise((resolve, reject) => {
+            signal.addEventListener("abort", () => reject(signal.reason));
+            queue.push({method, request: req, signal, resolve, reject});
+            if (queue.length >= batching.maxSize) {
+                flush();
+            } else if (!scheduled) {
+                scheduled = true;
+                batching.delay > 0 ? setTimeout(flush, batching.delay) : queueMicrotask(flush);
+            }
+        });
+    };
+
+    const builder = new Proxy({}, {
+        get(_, prop) {
+            return (params: any) => ({method: prop.toString(), params});
+        },
+    }) as BatchBuilder<T>;
+
     const abortControllers = new WeakMap<Promise<any>, AbortController>();
 
     const target = {
@@ -66,6 +187,19 @@ export function rpcClient<T extends object>(options: RpcClientOptions) {
             const ac = abortControllers.get(promise);
             ac?.abort();
         },
+        // $batch sends calls in one request. Results are in order of calls, failed calls have error instead of result.
+        $batch: async <C extends BatchCall<any>[]>(build: (calls: BatchBuilder<T>) => [...C], signal?: AbortSignal): Promise<BatchResults<C>> => {
+            const calls = build(builder);
+            const requests = calls.map((call) => request(call.method, call.params));
+            const responses = await sendBatch(requests, signal ?? new AbortController().signal);
+            return requests.map((req, i) => {
+                try {
+                    return {result: unwrap(responses.get(req.id), calls[i].method)};
+                } catch (error) {
+                    return {error: error instanceof RpcError ? error : new RpcError(String(error), -32603, error)};
+                }
+            }) as BatchResults<C>;
+        },
     };
 
     return new Proxy(target, {
@@ -97,14 +231,14 @@ export function rpcClient<T extends object>(options: RpcClientOptions) {
 export function createRequest(method: string, params: any): JsonRpcRequest {
     return {
         jsonrpc: "2.0",
-        id: Date.now(),
+        id: nextID(),
         method,
         params: params,
     };
 }
 
 export function fetchTransport(options: FetchOptions): RpcTransport {
-    return async (req: JsonRpcRequest, signal: AbortSignal): Promise<any> => {
+    return async (req: JsonRpcRequest | JsonRpcRequest[], signal: AbortSignal): Promise<any> => {
         const headers = options?.getHeaders ? await options.getHeaders() : {};
         const res = await fetch(options.url, {
             method: "POST",
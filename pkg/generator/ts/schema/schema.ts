@@ -0,0 +1,23 @@
+import {z} from "zod";
+
+export class SchemaError extends Error {
+    method: string;
+    issues: z.ZodIssue[];
+
+    constructor(method: string, issues: z.ZodIssue[]) {
+        super(`invalid result of ${method}: ${issues.map((issue) => `${issue.path.join(".")}: ${issue.message}`).join("; ")}`);
+        this.method = method;
+        this.issues = issues;
+        Object.setPrototypeOf(this, SchemaError.prototype);
+    }
+}
+
+// validator returns function, which checks result of method by its schema
+export function validator(schemas: Record<string, z.ZodTypeAny>) {
+    return (method: string, result: unknown) => {
+        const parsed = schemas[method]?.safeParse(result);
+        if (parsed && !parsed.success) {
+            throw new SchemaError(method, parsed.error.issues);
+        }
+    };
+}
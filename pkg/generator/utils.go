@@ -89,6 +89,80 @@ func searchType(pkg, name string) (retType types.Type) {
 	return
 }
 
+// searchMethods returns methods declared with receiver of type in package.
+func searchMethods(pkg, name string) (methods []types.Method) {
+
+	for _, pkgPath := range []string{pkg, mod.PkgModPath(pkg), path.Join("./vendor", pkg), trimLocalPkg(pkg)} {
+		if methods = parseMethods(pkgPath, name); len(methods) != 0 {
+			return
+		}
+	}
+	return
+}
+
+func parseMethods(relPath, name string) (methods []types.Method) {
+
+	pkgPath, _ := filepath.Abs(relPath)
+	files, err := os.ReadDir(pkgPath)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") || strings.HasSuffix(file.Name(), "_test.go") {
+			continue
+		}
+		var srcFile *types.File
+		if srcFile, err = astra.ParseFile(path.Join(pkgPath, file.Name()), astra.IgnoreFunctions); err != nil {
+			continue
+		}
+		for _, method := range srcFile.Methods {
+			receiver := method.Receiver.Type
+			if pointer, isPointer := receiver.(types.TPointer); isPointer {
+				receiver = pointer.Next
+			}
+			if receiverName, isName := receiver.(types.TName); isName && receiverName.TypeName == name {
+				methods = append(methods, method)
+			}
+		}
+	}
+	return
+}
+
+// searchTypeDocs returns comments of named type, which is not a struct, declared in package.
+func searchTypeDocs(pkg, name string) (docs []string) {
+
+	for _, pkgPath := range []string{pkg, mod.PkgModPath(pkg), path.Join("./vendor", pkg), trimLocalPkg(pkg)} {
+		if docs = parseTypeDocs(pkgPath, name); len(docs) != 0 {
+			return
+		}
+	}
+	return
+}
+
+func parseTypeDocs(relPath, name string) (docs []string) {
+
+	pkgPath, _ := filepath.Abs(relPath)
+	files, err := os.ReadDir(pkgPath)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") || strings.HasSuffix(file.Name(), "_test.go") {
+			continue
+		}
+		var srcFile *types.File
+		if srcFile, err = astra.ParseFile(path.Join(pkgPath, file.Name()), astra.IgnoreFunctions, astra.IgnoreMethods); err != nil {
+			continue
+		}
+		for _, fileType := range srcFile.Types {
+			if fileType.Name == name {
+				return fileType.Docs
+			}
+		}
+	}
+	return
+}
+
 func isPointerType(v types.Type) (isPointer bool) {
 
 	_, isPointer = v.(types.TPointer)
@@ -8,10 +8,18 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/seniorGolang/tg/v2/pkg/logger"
 	"github.com/seniorGolang/tg/v2/pkg/utils"
 )
 
+var (
+	log = logger.Log.WithField("module", "tags")
+	// reported are invalid annotations, which were already logged, because the same docs are parsed many times.
+	reported sync.Map
+)
+
 const (
 	mark = "@tg"
 )
@@ -46,11 +54,13 @@ func ParseTags(docs []string) (tags DocTags) {
 
 	for _, doc := range docs {
 
-		doc = strings.TrimSpace(strings.TrimPrefix(doc, "//"))
-
-		if strings.HasPrefix(doc, mark) {
-
-			values, _ := TagScanner(doc[len(mark):])
+		values, isTag, err := ParseLine(doc)
+		if err != nil {
+			if _, found := reported.LoadOrStore(doc, true); !found {
+				log.WithError(err).Warnf("parse annotation '%s'", strings.TrimSpace(doc))
+			}
+		}
+		if isTag {
 
 			for k, v := range values {
 
@@ -78,6 +88,17 @@ func ParseTags(docs []string) (tags DocTags) {
 	return
 }
 
+// ParseLine parses single comment line. isTag is false, when line is not an annotation.
+func ParseLine(doc string) (values map[string]string, isTag bool, err error) {
+
+	doc = strings.TrimSpace(strings.TrimPrefix(doc, "//"))
+	if !strings.HasPrefix(doc, mark) {
+		return
+	}
+	values, err = TagScanner(doc[len(mark):])
+	return values, true, err
+}
+
 func (tags DocTags) IsSet(tagName string) (found bool) {
 	_, found = tags[tagName]
 	return
@@ -76,3 +76,25 @@ func addWordBoundariesToNumbers(s string) string {
 	b = numberSequence.ReplaceAll(b, numberReplacement)
 	return string(b)
 }
+
+// Converts a string to snake_case
+func ToSnake(s string) string {
+
+	runes := []rune(strings.Trim(s, " "))
+	n := ""
+	for i, v := range runes {
+		if v == '-' || v == ' ' {
+			n += "_"
+			continue
+		}
+		if v >= 'A' && v <= 'Z' && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') || (prev >= 'A' && prev <= 'Z' && nextLower) {
+				n += "_"
+			}
+		}
+		n += strings.ToLower(string(v))
+	}
+	return n
+}